@@ -0,0 +1,38 @@
+package antchain
+
+import "sync"
+
+// ABIRegistry 合约ABI注册表(合约名称 => ABI JSON)，部署合约时自动注册
+type ABIRegistry struct {
+	mutex sync.RWMutex
+	abis  map[string]string
+}
+
+// NewABIRegistry 返回 ABIRegistry
+func NewABIRegistry() *ABIRegistry {
+	return &ABIRegistry{
+		abis: make(map[string]string),
+	}
+}
+
+// Register 注册合约ABI
+func (r *ABIRegistry) Register(contract, abi string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.abis[contract] = abi
+}
+
+// Get 返回合约ABI
+func (r *ABIRegistry) Get(contract string) (string, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	abi, ok := r.abis[contract]
+
+	return abi, ok
+}
+
+func (c *client) ABIRegistry() *ABIRegistry {
+	return c.abis
+}
@@ -0,0 +1,424 @@
+package antchain
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/shenghui0779/antchain/codec"
+	"github.com/tidwall/gjson"
+)
+
+// ErrABINotFound ABI 中不存在指定的方法或事件
+var ErrABINotFound = errors.New("antchain: abi entry not found")
+
+// ABIArgument ABI 中方法或事件的参数
+type ABIArgument struct {
+	Name       string        `json:"name"`
+	Type       string        `json:"type"`
+	Indexed    bool          `json:"indexed,omitempty"`    // 事件参数是否为 indexed(在 topics 中)
+	Components []ABIArgument `json:"components,omitempty"` // tuple 的成员
+}
+
+// CanonicalType 返回用于签名的类型，tuple 展开为 (t1,t2)
+func (a *ABIArgument) CanonicalType() string {
+	if !strings.HasPrefix(a.Type, "tuple") {
+		return a.Type
+	}
+
+	types := make([]string, 0, len(a.Components))
+
+	for i := range a.Components {
+		types = append(types, a.Components[i].CanonicalType())
+	}
+
+	return "(" + strings.Join(types, ",") + ")" + strings.TrimPrefix(a.Type, "tuple")
+}
+
+// ABIEntry ABI 中的函数、事件、构造函数等
+type ABIEntry struct {
+	Type            string        `json:"type"` // function、event、constructor、fallback、receive、error
+	Name            string        `json:"name,omitempty"`
+	Inputs          []ABIArgument `json:"inputs,omitempty"`
+	Outputs         []ABIArgument `json:"outputs,omitempty"`
+	StateMutability string        `json:"stateMutability,omitempty"`
+	Anonymous       bool          `json:"anonymous,omitempty"`
+}
+
+// Signature 返回方法或事件的签名，如：transfer(address,uint256)
+func (e *ABIEntry) Signature() string {
+	types := make([]string, 0, len(e.Inputs))
+
+	for i := range e.Inputs {
+		types = append(types, e.Inputs[i].CanonicalType())
+	}
+
+	return e.Name + "(" + strings.Join(types, ",") + ")"
+}
+
+// OutTypes 返回方法的返回值类型
+func (e *ABIEntry) OutTypes() []string {
+	types := make([]string, 0, len(e.Outputs))
+
+	for i := range e.Outputs {
+		types = append(types, e.Outputs[i].CanonicalType())
+	}
+
+	return types
+}
+
+// IsView 是否为不修改状态的方法(view、pure)
+func (e *ABIEntry) IsView() bool {
+	return e.StateMutability == "view" || e.StateMutability == "pure"
+}
+
+// ABI 解析后的 Solidity 合约 ABI
+type ABI struct {
+	Entries []ABIEntry
+
+	raw string
+}
+
+// LoadABI 读取并解析 Solidity ABI JSON，兼容包含 abi 字段的 Truffle/Hardhat artifact
+func LoadABI(r io.Reader) (*ABI, error) {
+	b, err := ioutil.ReadAll(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseABI(string(b))
+}
+
+// ParseABI 解析 Solidity ABI JSON，兼容包含 abi 字段的 Truffle/Hardhat artifact
+func ParseABI(data string) (*ABI, error) {
+	if !gjson.Valid(data) {
+		return nil, wrapErr(ErrDecodeFailed, errors.New("invalid abi json"))
+	}
+
+	ret := gjson.Parse(data)
+
+	if ret.IsObject() {
+		ret = ret.Get("abi")
+	}
+
+	if !ret.IsArray() {
+		return nil, wrapErr(ErrDecodeFailed, errors.New("abi must be a json array"))
+	}
+
+	abi := &ABI{raw: ret.Raw}
+
+	if err := json.Unmarshal([]byte(ret.Raw), &abi.Entries); err != nil {
+		return nil, wrapErr(ErrDecodeFailed, err)
+	}
+
+	for i, e := range abi.Entries {
+		if len(e.Type) == 0 {
+			// type 缺省为 function
+			abi.Entries[i].Type = "function"
+		}
+
+		if (abi.Entries[i].Type == "function" || abi.Entries[i].Type == "event") && len(e.Name) == 0 {
+			return nil, wrapErr(ErrDecodeFailed, fmt.Errorf("abi entry %d has no name", i))
+		}
+	}
+
+	return abi, nil
+}
+
+// JSON 返回 ABI 的 JSON(可注册到 ABIRegistry)
+func (a *ABI) JSON() string {
+	return a.raw
+}
+
+// Method 返回方法，name 为方法名称或签名(存在重载时须使用签名)
+func (a *ABI) Method(name string) (*ABIEntry, error) {
+	return a.find("function", name)
+}
+
+// Event 返回事件，name 为事件名称或签名(存在重载时须使用签名)
+func (a *ABI) Event(name string) (*ABIEntry, error) {
+	return a.find("event", name)
+}
+
+// Events 返回全部事件
+func (a *ABI) Events() []*ABIEntry {
+	events := make([]*ABIEntry, 0)
+
+	for i := range a.Entries {
+		if a.Entries[i].Type == "event" {
+			events = append(events, &a.Entries[i])
+		}
+	}
+
+	return events
+}
+
+func (a *ABI) find(typ, name string) (*ABIEntry, error) {
+	var found *ABIEntry
+
+	for i := range a.Entries {
+		e := &a.Entries[i]
+
+		if e.Type != typ {
+			continue
+		}
+
+		if e.Signature() == name {
+			return e, nil
+		}
+
+		if e.Name == name {
+			if found != nil {
+				return nil, fmt.Errorf("antchain: %s %s is overloaded, use signature instead", typ, name)
+			}
+
+			found = e
+		}
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("%w: %s %s", ErrABINotFound, typ, name)
+	}
+
+	return found, nil
+}
+
+// DecodeLog 根据事件定义解码日志，topics 为日志的 topic(hex)，不含签名的 topic；data 为日志数据(hex 或 base64)；
+// 整数以十进制字符串返回，address/identity、bytes 以 hex 返回，indexed 的动态类型(string、bytes、数组)只能返回其哈希(hex)，
+// 暂不支持解码的类型(数组、tuple)返回其 ABI 编码头部的原始数据(hex)
+func (e *ABIEntry) DecodeLog(topics []string, data string) (map[string]interface{}, error) {
+	b, err := decodeLogData(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]interface{}, len(e.Inputs))
+
+	ti, pos := 0, 0
+
+	for i, arg := range e.Inputs {
+		name := arg.Name
+
+		if len(name) == 0 {
+			name = strconv.Itoa(i)
+		}
+
+		if arg.Indexed {
+			if ti >= len(topics) {
+				return nil, wrapErr(ErrDecodeFailed, fmt.Errorf("event %s: missing topic for %s", e.Name, name))
+			}
+
+			word, err := hex.DecodeString(codec.Trim0x(topics[ti]))
+
+			if err != nil || len(word) != 32 {
+				return nil, wrapErr(ErrDecodeFailed, fmt.Errorf("event %s: invalid topic %q", e.Name, topics[ti]))
+			}
+
+			ti++
+
+			if isDynamicABIType(arg.Type) || strings.HasPrefix(arg.Type, "tuple") || strings.HasSuffix(arg.Type, "]") {
+				ret[name] = hex.EncodeToString(word)
+
+				continue
+			}
+
+			ret[name] = decodeABIWord(arg.Type, word)
+
+			continue
+		}
+
+		size := 32 * abiHeadWords(&arg)
+
+		if pos > len(b)-size {
+			return nil, wrapErr(ErrDecodeFailed, fmt.Errorf("event %s: data too short for %s", e.Name, name))
+		}
+
+		word := b[pos : pos+size]
+
+		pos += size
+
+		switch {
+		case arg.Type == "string" || arg.Type == "bytes":
+			v, err := decodeABIDynamic(b, word)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if arg.Type == "string" {
+				ret[name] = string(v)
+			} else {
+				ret[name] = hex.EncodeToString(v)
+			}
+		case strings.HasPrefix(arg.Type, "tuple") || strings.HasSuffix(arg.Type, "]"):
+			ret[name] = hex.EncodeToString(word)
+		default:
+			ret[name] = decodeABIWord(arg.Type, word)
+		}
+	}
+
+	return ret, nil
+}
+
+// decodeLogData 解码日志数据，兼容 hex(可带 0x 前缀)及 base64
+func decodeLogData(data string) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	if b, err := hex.DecodeString(codec.Trim0x(data)); err == nil {
+		return b, nil
+	}
+
+	b, err := base64.StdEncoding.DecodeString(data)
+
+	if err != nil {
+		return nil, wrapErr(ErrDecodeFailed, errors.New("log data is neither hex nor base64"))
+	}
+
+	return b, nil
+}
+
+func isDynamicABIType(typ string) bool {
+	return typ == "string" || typ == "bytes"
+}
+
+// abiHeadWords 返回参数在 ABI 编码头部占用的字数：动态类型为 1(偏移量)，静态 tuple 及定长数组为其成员之和
+func abiHeadWords(arg *ABIArgument) int {
+	if !abiStatic(arg) {
+		return 1
+	}
+
+	typ := arg.Type
+
+	if i := strings.LastIndex(typ, "["); i >= 0 {
+		n, _ := strconv.Atoi(typ[i+1 : len(typ)-1])
+		elem := *arg
+		elem.Type = typ[:i]
+
+		return n * abiHeadWords(&elem)
+	}
+
+	if typ == "tuple" {
+		words := 0
+
+		for i := range arg.Components {
+			words += abiHeadWords(&arg.Components[i])
+		}
+
+		return words
+	}
+
+	return 1
+}
+
+// abiStatic 是否为静态类型(编码长度固定)
+func abiStatic(arg *ABIArgument) bool {
+	typ := arg.Type
+
+	if isDynamicABIType(typ) || strings.HasSuffix(typ, "[]") {
+		return false
+	}
+
+	if i := strings.LastIndex(typ, "["); i >= 0 {
+		elem := *arg
+		elem.Type = typ[:i]
+
+		return abiStatic(&elem)
+	}
+
+	if typ == "tuple" {
+		for i := range arg.Components {
+			if !abiStatic(&arg.Components[i]) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// decodeABIWord 解码 32 字节的静态类型
+func decodeABIWord(typ string, word []byte) interface{} {
+	switch {
+	case typ == "bool":
+		return word[31] != 0
+	case typ == "address":
+		return hex.EncodeToString(word[12:])
+	case typ == "identity":
+		return hex.EncodeToString(word)
+	case strings.HasPrefix(typ, "uint"):
+		return new(big.Int).SetBytes(word).String()
+	case strings.HasPrefix(typ, "int"):
+		v := new(big.Int).SetBytes(word)
+
+		// 补码转换为负数
+		if word[0]&0x80 != 0 {
+			v.Sub(v, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+
+		return v.String()
+	case strings.HasPrefix(typ, "bytes"):
+		if n, err := strconv.Atoi(strings.TrimPrefix(typ, "bytes")); err == nil && n > 0 && n <= 32 {
+			return hex.EncodeToString(word[:n])
+		}
+	}
+
+	return hex.EncodeToString(word)
+}
+
+// decodeABIDynamic 按偏移量读取 string、bytes 的内容
+func decodeABIDynamic(b, word []byte) ([]byte, error) {
+	offset := new(big.Int).SetBytes(word)
+
+	if !offset.IsInt64() || offset.Int64() > int64(len(b)) {
+		return nil, wrapErr(ErrDecodeFailed, fmt.Errorf("abi offset %s out of range", offset.String()))
+	}
+
+	length, err := abiWordToInt(b, int(offset.Int64()))
+
+	if err != nil {
+		return nil, err
+	}
+
+	start := int(offset.Int64()) + 32
+
+	if length > len(b)-start {
+		return nil, wrapErr(ErrDecodeFailed, fmt.Errorf("abi length %d out of range", length))
+	}
+
+	return b[start : start+length], nil
+}
+
+// Load 读取 ABI JSON(或 artifact)并注册为合约 contract 的ABI
+func (r *ABIRegistry) Load(contract string, rd io.Reader) (*ABI, error) {
+	abi, err := LoadABI(rd)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r.Register(contract, abi.JSON())
+
+	return abi, nil
+}
+
+// Parsed 返回解析后的合约ABI
+func (r *ABIRegistry) Parsed(contract string) (*ABI, error) {
+	data, ok := r.Get(contract)
+
+	if !ok || len(data) == 0 {
+		return nil, fmt.Errorf("%w: contract %s", ErrABINotFound, contract)
+	}
+
+	return ParseABI(data)
+}
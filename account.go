@@ -0,0 +1,66 @@
+package antchain
+
+import "encoding/json"
+
+// AccountStatus 链账户状态
+type AccountStatus string
+
+const (
+	// AccountStatusNormal 正常
+	AccountStatusNormal AccountStatus = "NORMAL"
+	// AccountStatusFreeze 冻结
+	AccountStatusFreeze AccountStatus = "FREEZE"
+	// AccountStatusRecovering 恢复中
+	AccountStatusRecovering AccountStatus = "RECOVERING"
+)
+
+// Account 链账户
+type Account struct {
+	ID            string         `json:"id"`            // 账户Identity
+	Balance       int64          `json:"balance"`       // 余额
+	Status        AccountStatus  `json:"status"`        // 状态
+	RecoverKey    string         `json:"recoverKey"`    // 恢复公钥
+	RecoverTime   int64          `json:"recoverTime"`   // 最近一次恢复时间
+	EncryptionKey string         `json:"encryptionKey"` // 加密公钥
+	AuthMap       map[string]int `json:"authMap"`       // 公钥 => 权重
+	Version       int64          `json:"version"`
+}
+
+// IsFrozen 账户是否被冻结
+func (a *Account) IsFrozen() bool {
+	return a.Status == AccountStatusFreeze
+}
+
+// IsRecovering 账户是否处于恢复中
+func (a *Account) IsRecovering() bool {
+	return a.Status == AccountStatusRecovering
+}
+
+// AuthWeight 返回公钥的权重，公钥未授权返回0
+func (a *Account) AuthWeight(pubKey string) int {
+	return a.AuthMap[pubKey]
+}
+
+// ParseAccount 解析账户查询结果
+func ParseAccount(data string) (*Account, error) {
+	return parseAccount(data, false)
+}
+
+// parseAccount 解析账户查询结果，strict 时要求 id、balance、status 字段且不允许未知字段
+func parseAccount(data string, strict bool) (*Account, error) {
+	account := new(Account)
+
+	if strict {
+		if err := strictUnmarshal([]byte(data), account, "id", "balance", "status"); err != nil {
+			return nil, err
+		}
+
+		return account, nil
+	}
+
+	if err := json.Unmarshal([]byte(data), account); err != nil {
+		return nil, wrapErr(ErrDecodeFailed, err)
+	}
+
+	return account, nil
+}
@@ -0,0 +1,226 @@
+package antchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultAccountMaxFailures 账户连续失败多少次后暂停使用
+	defaultAccountMaxFailures = 3
+	// defaultAccountCooldown 账户暂停使用的时长
+	defaultAccountCooldown = 30 * time.Second
+)
+
+// ErrNoHealthyAccount AccountPool 中没有可用的账户
+var ErrNoHealthyAccount = errors.New("antchain: no healthy account")
+
+// PoolAccount AccountPool 中的链账户及其托管密钥
+type PoolAccount struct {
+	Account  string
+	KmsKeyID string
+}
+
+// AccountHealth 账户的健康状态
+type AccountHealth struct {
+	Account       string
+	Healthy       bool
+	InFlight      int       // 进行中的提交数
+	Successes     int64     // 累计成功数
+	Failures      int64     // 累计失败数
+	ConsecFails   int       // 连续失败数
+	LastError     string    // 最近一次失败的错误
+	CooldownUntil time.Time // 暂停使用的截止时间
+}
+
+type pooledAccount struct {
+	PoolAccount
+
+	inflight      int
+	successes     int64
+	failures      int64
+	consecFails   int
+	lastError     string
+	cooldownUntil time.Time
+}
+
+// AccountPoolOption AccountPool 的可选配置
+type AccountPoolOption func(p *AccountPool)
+
+// WithAccountHealth 账户连续失败 maxFailures 次后暂停使用 cooldown(默认：3次、30s)
+func WithAccountHealth(maxFailures int, cooldown time.Duration) AccountPoolOption {
+	return func(p *AccountPool) {
+		p.maxFailures = maxFailures
+		p.cooldown = cooldown
+	}
+}
+
+// AccountPool 将交易轮流分配到多个链账户(各自使用托管密钥)提交，突破单账户串行提交的吞吐上限；
+// 连续失败的账户暂停使用一段时间，全部暂停时仍选择最早恢复的账户
+type AccountPool struct {
+	maxFailures int
+	cooldown    time.Duration
+	now         func() time.Time
+
+	mutex    sync.Mutex
+	accounts []*pooledAccount
+	next     int
+}
+
+// NewAccountPool 返回由 accounts 组成的 AccountPool，账户须配置托管密钥
+func NewAccountPool(accounts []PoolAccount, options ...AccountPoolOption) (*AccountPool, error) {
+	p := &AccountPool{
+		maxFailures: defaultAccountMaxFailures,
+		cooldown:    defaultAccountCooldown,
+		now:         time.Now,
+		accounts:    make([]*pooledAccount, 0, len(accounts)),
+	}
+
+	for _, v := range accounts {
+		if len(v.KmsKeyID) == 0 {
+			return nil, fmt.Errorf("antchain: pool account %q has no kms key", v.Account)
+		}
+
+		p.accounts = append(p.accounts, &pooledAccount{PoolAccount: v})
+	}
+
+	for _, f := range options {
+		f(p)
+	}
+
+	if p.maxFailures <= 0 {
+		p.maxFailures = defaultAccountMaxFailures
+	}
+
+	return p, nil
+}
+
+// pick 轮流选择健康的账户
+func (p *AccountPool) pick() (*pooledAccount, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if len(p.accounts) == 0 {
+		return nil, ErrNoHealthyAccount
+	}
+
+	now := p.now()
+
+	var fallback *pooledAccount
+
+	for i := 0; i < len(p.accounts); i++ {
+		a := p.accounts[(p.next+i)%len(p.accounts)]
+
+		if !now.Before(a.cooldownUntil) {
+			p.next = (p.next + i + 1) % len(p.accounts)
+			a.inflight++
+
+			return a, nil
+		}
+
+		if fallback == nil || a.cooldownUntil.Before(fallback.cooldownUntil) {
+			fallback = a
+		}
+	}
+
+	fallback.inflight++
+
+	return fallback, nil
+}
+
+// report 记录提交结果
+func (p *AccountPool) report(a *pooledAccount, err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	a.inflight--
+
+	if err == nil {
+		a.successes++
+		a.consecFails = 0
+		a.cooldownUntil = time.Time{}
+
+		return
+	}
+
+	a.failures++
+	a.lastError = err.Error()
+
+	// 业务错误(如：合约 revert、参数错误)及调用方取消与账户无关，不计入连续失败
+	if !accountFault(err) {
+		return
+	}
+
+	a.consecFails++
+
+	if a.consecFails >= p.maxFailures {
+		a.cooldownUntil = p.now().Add(p.cooldown)
+	}
+}
+
+// accountFault 判断错误是否可能与账户有关：限流、网关临时故障及鉴权(HTTP 401/403)、签名失败
+func accountFault(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if IsThrottled(err) || IsRetryable(err) {
+		return true
+	}
+
+	if errors.Is(err, ErrInvalidKey) || errors.Is(err, ErrSignFailed) {
+		return true
+	}
+
+	var ae *APIError
+
+	return errors.As(err, &ae) && (ae.StatusCode == http.StatusUnauthorized || ae.StatusCode == http.StatusForbidden)
+}
+
+// Submit 选择账户并执行提交，fn 须将 account 选项传给交易方法，如：
+//
+//	pool.Submit(ctx, func(ctx context.Context, account ChainCallOption) (string, error) {
+//		return cli.Deposit(ctx, content, gas, account)
+//	})
+func (p *AccountPool) Submit(ctx context.Context, fn func(ctx context.Context, account ChainCallOption) (string, error)) (string, error) {
+	a, err := p.pick()
+
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := fn(ctx, WithAccount(a.Account, a.KmsKeyID))
+
+	p.report(a, err)
+
+	return hash, err
+}
+
+// Health 返回各账户的健康状态
+func (p *AccountPool) Health() []AccountHealth {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	now := p.now()
+
+	ret := make([]AccountHealth, 0, len(p.accounts))
+
+	for _, a := range p.accounts {
+		ret = append(ret, AccountHealth{
+			Account:       a.Account,
+			Healthy:       !now.Before(a.cooldownUntil),
+			InFlight:      a.inflight,
+			Successes:     a.successes,
+			Failures:      a.failures,
+			ConsecFails:   a.consecFails,
+			LastError:     a.lastError,
+			CooldownUntil: a.cooldownUntil,
+		})
+	}
+
+	return ret
+}
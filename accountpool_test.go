@@ -0,0 +1,44 @@
+package antchain
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAccountPoolRequiresKmsKey(t *testing.T) {
+	if _, err := NewAccountPool([]PoolAccount{{Account: "a"}}); err == nil {
+		t.Fatal("expected error for account without kms key")
+	}
+}
+
+func TestAccountPoolCooldownOnlyOnAccountFaults(t *testing.T) {
+	p, err := NewAccountPool([]PoolAccount{{Account: "a", KmsKeyID: "k"}}, WithAccountHealth(2, 0))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revert := &APIError{Code: "GW_REVERT", Message: "revert", StatusCode: 200}
+
+	for i := 0; i < 5; i++ {
+		p.Submit(context.Background(), func(ctx context.Context, account ChainCallOption) (string, error) {
+			return "", revert
+		})
+	}
+
+	if h := p.Health()[0]; h.ConsecFails != 0 || h.Failures != 5 || !h.CooldownUntil.IsZero() {
+		t.Fatalf("business errors penalized the account: %+v", h)
+	}
+
+	throttled := &ThrottleError{APIError: APIError{Code: ErrCodeTooManyRequests, StatusCode: 429}}
+
+	for i := 0; i < 2; i++ {
+		p.Submit(context.Background(), func(ctx context.Context, account ChainCallOption) (string, error) {
+			return "", throttled
+		})
+	}
+
+	if h := p.Health()[0]; h.ConsecFails != 2 || h.CooldownUntil.IsZero() {
+		t.Fatalf("throttling not penalized: %+v", h)
+	}
+}
@@ -0,0 +1,187 @@
+// Package aliyunkms 使用阿里云KMS非对称密钥进行 shakehand 签名，私钥不出KMS
+package aliyunkms
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shenghui0779/antchain"
+	"github.com/tidwall/gjson"
+)
+
+// Config 阿里云KMS配置
+type Config struct {
+	RegionID        string // 地域，如：cn-hangzhou
+	Endpoint        string // KMS 服务地址，默认 https://kms.{RegionID}.aliyuncs.com
+	AccessKeyID     string // 阿里云 AccessKeyId
+	AccessKeySecret string // 阿里云 AccessKeySecret
+	KeyID           string // 非对称密钥ID
+	KeyVersionID    string // 密钥版本ID
+
+	Timeout    time.Duration // 单次签名的超时时间，默认10秒
+	HTTPClient *http.Client
+}
+
+// algorithms 摘要算法对应的KMS签名算法
+var algorithms = map[crypto.Hash]string{
+	crypto.SHA256: "RSA_PKCS1_SHA_256",
+}
+
+// Signer 实现 antchain.Signer，签名在KMS内完成
+type Signer struct {
+	cfg *Config
+	cli *http.Client
+}
+
+// NewSigner 返回阿里云KMS签名器
+func NewSigner(cfg *Config) *Signer {
+	s := &Signer{
+		cfg: cfg,
+		cli: cfg.HTTPClient,
+	}
+
+	if s.cli == nil {
+		s.cli = http.DefaultClient
+	}
+
+	return s
+}
+
+var _ antchain.Signer = (*Signer)(nil)
+
+func (s *Signer) Sign(hash crypto.Hash, data []byte) ([]byte, error) {
+	algorithm, ok := algorithms[hash]
+
+	if !ok || !hash.Available() {
+		return nil, fmt.Errorf("aliyunkms: unsupported hash %s", hash.String())
+	}
+
+	h := hash.New()
+	h.Write(data)
+
+	timeout := s.cfg.Timeout
+
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	ret, err := s.call(ctx, map[string]string{
+		"Action":       "AsymmetricSign",
+		"KeyId":        s.cfg.KeyID,
+		"KeyVersionId": s.cfg.KeyVersionID,
+		"Algorithm":    algorithm,
+		"Digest":       base64.StdEncoding.EncodeToString(h.Sum(nil)),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	sign, err := base64.StdEncoding.DecodeString(ret.Get("Value").String())
+
+	if err != nil {
+		return nil, fmt.Errorf("aliyunkms: invalid signature: %w", err)
+	}
+
+	return sign, nil
+}
+
+// call 调用KMS的RPC接口(签名方式：HMAC-SHA1)
+func (s *Signer) call(ctx context.Context, params map[string]string) (gjson.Result, error) {
+	params["Format"] = "JSON"
+	params["Version"] = "2016-01-20"
+	params["AccessKeyId"] = s.cfg.AccessKeyID
+	params["SignatureMethod"] = "HMAC-SHA1"
+	params["SignatureVersion"] = "1.0"
+	params["SignatureNonce"] = uuid.New().String()
+	params["Timestamp"] = time.Now().UTC().Format("2006-01-02T15:04:05Z")
+
+	query := canonicalize(params)
+	stringToSign := http.MethodPost + "&" + percentEncode("/") + "&" + percentEncode(query)
+
+	mac := hmac.New(sha1.New, []byte(s.cfg.AccessKeySecret+"&"))
+	mac.Write([]byte(stringToSign))
+
+	body := query + "&Signature=" + percentEncode(base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint(), strings.NewReader(body))
+
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.cli.Do(req)
+
+	if err != nil {
+		return gjson.Result{}, fmt.Errorf("aliyunkms: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+
+	if err != nil {
+		return gjson.Result{}, fmt.Errorf("aliyunkms: %w", err)
+	}
+
+	ret := gjson.ParseBytes(b)
+
+	if resp.StatusCode != http.StatusOK {
+		return gjson.Result{}, fmt.Errorf("aliyunkms: %s | %s", ret.Get("Code").String(), ret.Get("Message").String())
+	}
+
+	return ret, nil
+}
+
+func (s *Signer) endpoint() string {
+	if len(s.cfg.Endpoint) != 0 {
+		return s.cfg.Endpoint
+	}
+
+	return "https://kms." + s.cfg.RegionID + ".aliyuncs.com/"
+}
+
+// canonicalize 按参数名排序并编码
+func canonicalize(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+
+	for k := range params {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+
+	for _, k := range keys {
+		pairs = append(pairs, percentEncode(k)+"="+percentEncode(params[k]))
+	}
+
+	return strings.Join(pairs, "&")
+}
+
+// percentEncode 阿里云RPC签名要求的URL编码
+func percentEncode(s string) string {
+	s = url.QueryEscape(s)
+	s = strings.ReplaceAll(s, "+", "%20")
+	s = strings.ReplaceAll(s, "*", "%2A")
+	s = strings.ReplaceAll(s, "%7E", "~")
+
+	return s
+}
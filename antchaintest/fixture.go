@@ -0,0 +1,170 @@
+package antchaintest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/shenghui0779/antchain"
+)
+
+// Fixture 一次查询的请求方法、查询参数及网关返回的数据
+type Fixture struct {
+	Method string `json:"method"` // 网关方法，如：QUERYTRANSACTION
+	Key    string `json:"key"`    // 查询参数(交易hash或块高)
+	Data   string `json:"data"`   // 网关返回的 data
+}
+
+// FixtureSet 一组 Fixture
+type FixtureSet struct {
+	Fixtures []*Fixture `json:"fixtures"`
+}
+
+// Add 添加 Fixture
+func (fs *FixtureSet) Add(method, key, data string) {
+	fs.Fixtures = append(fs.Fixtures, &Fixture{
+		Method: method,
+		Key:    key,
+		Data:   data,
+	})
+}
+
+// WriteFile 将 Fixture 写入文件
+func (fs *FixtureSet) WriteFile(path string) error {
+	b, err := json.MarshalIndent(fs, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, b, 0644)
+}
+
+// LoadFixtures 从文件加载 Fixture
+func LoadFixtures(path string) (*FixtureSet, error) {
+	b, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	fs := new(FixtureSet)
+
+	if err = json.Unmarshal(b, fs); err != nil {
+		return nil, err
+	}
+
+	return fs, nil
+}
+
+// CaptureRequest 需要从链上采集的数据
+type CaptureRequest struct {
+	TxHashes []string // 采集交易及回执
+	Blocks   []int64  // 采集块头及块体
+
+	// Sanitize 对采集的数据脱敏，为空则使用 DefaultSanitize
+	Sanitize func(data string) string
+}
+
+// Capture 从真实链上采集数据，生成可供 Server 使用的 Fixture
+func Capture(ctx context.Context, cli antchain.QueryService, req *CaptureRequest) (*FixtureSet, error) {
+	sanitize := req.Sanitize
+
+	if sanitize == nil {
+		sanitize = DefaultSanitize
+	}
+
+	fs := new(FixtureSet)
+
+	for _, hash := range req.TxHashes {
+		tx, err := cli.QueryTransaction(ctx, hash)
+
+		if err != nil {
+			return nil, err
+		}
+
+		fs.Add(string(antchain.MethodQueryTransaction), hash, sanitize(tx))
+
+		receipt, err := cli.QueryReceipt(ctx, hash)
+
+		if err != nil {
+			return nil, err
+		}
+
+		fs.Add(string(antchain.MethodQueryReceipt), hash, sanitize(receipt))
+	}
+
+	for _, number := range req.Blocks {
+		key := strconv.FormatInt(number, 10)
+
+		header, err := cli.QueryBlockHeader(ctx, number)
+
+		if err != nil {
+			return nil, err
+		}
+
+		fs.Add(string(antchain.MethodQueryBlock), key, sanitize(header))
+
+		body, err := cli.QueryBlockBody(ctx, number)
+
+		if err != nil {
+			return nil, err
+		}
+
+		fs.Add(string(antchain.MethodQueryBlockBody), key, sanitize(body))
+	}
+
+	return fs, nil
+}
+
+// sensitiveKeys 默认脱敏的字段
+var sensitiveKeys = map[string]bool{
+	"token":      true,
+	"accessId":   true,
+	"secret":     true,
+	"mykmsKeyId": true,
+	"tenantid":   true,
+}
+
+// DefaultSanitize 删除数据中的敏感字段(token、accessId、mykmsKeyId等)，非JSON数据原样返回
+func DefaultSanitize(data string) string {
+	var v interface{}
+
+	dec := json.NewDecoder(bytes.NewReader([]byte(data)))
+	dec.UseNumber()
+
+	if err := dec.Decode(&v); err != nil {
+		return data
+	}
+
+	b, err := json.Marshal(scrub(v))
+
+	if err != nil {
+		return data
+	}
+
+	return string(b)
+}
+
+func scrub(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		for k, item := range vv {
+			if sensitiveKeys[k] {
+				delete(vv, k)
+
+				continue
+			}
+
+			vv[k] = scrub(item)
+		}
+	case []interface{}:
+		for i, item := range vv {
+			vv[i] = scrub(item)
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,98 @@
+// Package antchaintest 提供模拟蚂蚁链REST网关的测试服务器及从真实链上采集测试数据的工具
+package antchaintest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/shenghui0779/antchain"
+)
+
+// Server 模拟蚂蚁链REST网关，按 Fixture 返回查询结果
+type Server struct {
+	*httptest.Server
+
+	mutex    sync.RWMutex
+	fixtures map[string]string
+}
+
+// NewServer 启动模拟网关，使用 Server.URL 作为 Config.Endpoint
+func NewServer(fs *FixtureSet) *Server {
+	s := &Server{
+		fixtures: make(map[string]string),
+	}
+
+	if fs != nil {
+		for _, v := range fs.Fixtures {
+			s.fixtures[fixtureKey(v.Method, v.Key)] = v.Data
+		}
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// Set 设置方法及查询参数对应的返回数据
+func (s *Server) Set(method, key, data string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.fixtures[fixtureKey(method, key)] = data
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == antchain.SHAKE_HAND {
+		writeResult(w, true, "200", "antchaintest-token")
+
+		return
+	}
+
+	params := make(map[string]interface{})
+
+	dec := json.NewDecoder(r.Body)
+	dec.UseNumber()
+
+	if err := dec.Decode(&params); err != nil {
+		writeResult(w, false, "400", err.Error())
+
+		return
+	}
+
+	method := fmt.Sprint(params["method"])
+
+	key := fmt.Sprint(params["hash"])
+
+	if _, ok := params["hash"]; !ok {
+		key = fmt.Sprint(params["requestStr"])
+	}
+
+	s.mutex.RLock()
+	data, ok := s.fixtures[fixtureKey(method, key)]
+	s.mutex.RUnlock()
+
+	if !ok {
+		writeResult(w, false, "404", fmt.Sprintf("no fixture for %s(%s)", method, key))
+
+		return
+	}
+
+	writeResult(w, true, "200", data)
+}
+
+func fixtureKey(method, key string) string {
+	return method + ":" + key
+}
+
+func writeResult(w http.ResponseWriter, success bool, code, data string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": success,
+		"code":    code,
+		"data":    data,
+	})
+}
@@ -0,0 +1,65 @@
+package antchain
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// LoadArtifact 读取合约编译产物，返回字节码(hex)及ABI：
+// 支持 Truffle/Hardhat 的 artifact JSON，以及 solc 输出的 .bin 文件(同目录下同名 .abi 文件作为ABI)
+func LoadArtifact(path string) (string, string, error) {
+	b, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".bin") {
+		abi, err := ioutil.ReadFile(strings.TrimSuffix(path, filepath.Ext(path)) + ".abi")
+
+		if err != nil && !os.IsNotExist(err) {
+			return "", "", err
+		}
+
+		return strings.TrimSpace(string(b)), strings.TrimSpace(string(abi)), nil
+	}
+
+	if !gjson.ValidBytes(b) {
+		return "", "", wrapErr(ErrDecodeFailed, fmt.Errorf("invalid artifact %s", path))
+	}
+
+	ret := gjson.ParseBytes(b)
+
+	// Truffle/Hardhat 为 bytecode 字符串，solc standard-json 为 evm.bytecode.object
+	bin := ret.Get("bytecode")
+
+	if bin.IsObject() {
+		bin = bin.Get("object")
+	}
+
+	if !bin.Exists() {
+		bin = ret.Get("evm.bytecode.object")
+	}
+
+	if len(bin.String()) == 0 || bin.String() == "0x" {
+		return "", "", fmt.Errorf("antchain: no bytecode found in %s (abstract contract or interface?)", path)
+	}
+
+	return bin.String(), ret.Get("abi").Raw, nil
+}
+
+func (c *client) DeploySolidityFromArtifact(ctx context.Context, name, artifactPath string, gas int, options ...DeployOption) (string, error) {
+	bin, abi, err := LoadArtifact(artifactPath)
+
+	if err != nil {
+		return "", err
+	}
+
+	return c.deployBytecode(ctx, name, bin, abi, gas, newDeploySetting(name, options...))
+}
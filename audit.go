@@ -0,0 +1,137 @@
+package antchain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// AuditRecord 一次网关调用的审计记录
+type AuditRecord struct {
+	Time       time.Time     `json:"time"`
+	Caller     string        `json:"caller,omitempty"` // 调用方身份(Metadata.Caller)
+	BizID      string        `json:"biz_id,omitempty"` // 业务ID(Metadata.BizID)
+	Method     string        `json:"method"`
+	ParamsHash string        `json:"params_hash"`        // 请求参数(不含 token)的 SHA-256 摘要(hex)
+	OrderID    string        `json:"order_id,omitempty"` // chainCallForBiz 的 orderId
+	TxHash     string        `json:"tx_hash,omitempty"`  // chainCallForBiz 成功时返回的交易hash
+	Error      string        `json:"error,omitempty"`
+	Duration   time.Duration `json:"duration"`
+}
+
+// AuditStore 审计记录的存储，可自行实现(如：数据库、WORM 存储)
+type AuditStore interface {
+	Record(ctx context.Context, r *AuditRecord) error
+}
+
+// AuditStoreFunc 函数形式的 AuditStore
+type AuditStoreFunc func(ctx context.Context, r *AuditRecord) error
+
+// Record 写入审计记录
+func (f AuditStoreFunc) Record(ctx context.Context, r *AuditRecord) error {
+	return f(ctx, r)
+}
+
+// FileAuditStore 以 JSON Lines 追加写入本地文件的审计存储
+type FileAuditStore struct {
+	mutex sync.Mutex
+	path  string
+}
+
+// NewFileAuditStore 返回写入本地文件的审计存储
+func NewFileAuditStore(path string) *FileAuditStore {
+	return &FileAuditStore{path: path}
+}
+
+// Record 追加写入审计记录
+func (s *FileAuditStore) Record(ctx context.Context, r *AuditRecord) error {
+	b, err := json.Marshal(r)
+
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(append(b, '\n'))
+
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+
+	return err
+}
+
+// WithAudit 记录全部网关调用(谁、做了什么、何时、结果交易hash)到 store，满足受监管用户的审计要求；
+// 调用方身份取自 ContextWithMetadata 附加的 Metadata；写入失败只记录日志，不影响请求结果
+func WithAudit(store AuditStore) ClientOption {
+	return func(c *client) {
+		c.audit = store
+	}
+}
+
+// auditParamsHash 计算请求参数(不含 accessId、token)的摘要
+func auditParamsHash(params X) string {
+	v := make(X, len(params))
+
+	for key, value := range params {
+		if key == "accessId" || key == "token" {
+			continue
+		}
+
+		v[key] = value
+	}
+
+	// map 的键按字典序序列化，摘要稳定
+	b, err := json.Marshal(v)
+
+	if err != nil {
+		return ""
+	}
+
+	h := sha256.Sum256(b)
+
+	return hex.EncodeToString(h[:])
+}
+
+// record 写入审计记录
+func (c *client) record(ctx context.Context, path string, params X, data string, err error, start time.Time) {
+	if c.audit == nil {
+		return
+	}
+
+	r := &AuditRecord{
+		Time:       start,
+		ParamsHash: auditParamsHash(params),
+		Duration:   time.Since(start),
+	}
+
+	r.Method, _ = params["method"].(string)
+	r.OrderID, _ = params["orderId"].(string)
+
+	if md := MetadataFromContext(ctx); md != nil {
+		r.Caller = md.Caller
+		r.BizID = md.BizID
+	}
+
+	if err != nil {
+		r.Error = err.Error()
+	} else if path == CHAIN_CALL_FOR_BIZ {
+		r.TxHash = data
+	}
+
+	if serr := c.audit.Record(ctx, r); serr != nil {
+		c.log.ErrorContext(ctx, "audit record failed", "method", r.Method, "order_id", r.OrderID, "error", serr)
+	}
+}
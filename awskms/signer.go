@@ -0,0 +1,233 @@
+// Package awskms 使用 AWS KMS 非对称密钥(RSASSA_PKCS1_V1_5_SHA_256)进行 shakehand 签名，私钥不出KMS
+package awskms
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shenghui0779/antchain"
+	"github.com/tidwall/gjson"
+)
+
+// Config AWS KMS 配置
+type Config struct {
+	Region          string // 区域，如：ap-southeast-1
+	Endpoint        string // KMS 服务地址，默认 https://kms.{Region}.amazonaws.com
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string // 临时凭证的 SessionToken(可选)
+	KeyID           string // 密钥ID、ARN 或别名
+
+	Timeout    time.Duration // 单次签名的超时时间，默认10秒
+	HTTPClient *http.Client
+}
+
+// algorithms 摘要算法对应的KMS签名算法
+var algorithms = map[crypto.Hash]string{
+	crypto.SHA256: "RSASSA_PKCS1_V1_5_SHA_256",
+}
+
+// Signer 实现 antchain.Signer，签名在KMS内完成
+type Signer struct {
+	cfg *Config
+	cli *http.Client
+}
+
+// NewSigner 返回 AWS KMS 签名器
+func NewSigner(cfg *Config) *Signer {
+	s := &Signer{
+		cfg: cfg,
+		cli: cfg.HTTPClient,
+	}
+
+	if s.cli == nil {
+		s.cli = http.DefaultClient
+	}
+
+	return s
+}
+
+var _ antchain.Signer = (*Signer)(nil)
+
+func (s *Signer) Sign(hash crypto.Hash, data []byte) ([]byte, error) {
+	algorithm, ok := algorithms[hash]
+
+	if !ok || !hash.Available() {
+		return nil, fmt.Errorf("awskms: unsupported hash %s", hash.String())
+	}
+
+	h := hash.New()
+	h.Write(data)
+
+	body, err := json.Marshal(map[string]string{
+		"KeyId":            s.cfg.KeyID,
+		"Message":          base64.StdEncoding.EncodeToString(h.Sum(nil)),
+		"MessageType":      "DIGEST",
+		"SigningAlgorithm": algorithm,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := s.cfg.Timeout
+
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	ret, err := s.call(ctx, "TrentService.Sign", body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sign, err := base64.StdEncoding.DecodeString(ret.Get("Signature").String())
+
+	if err != nil {
+		return nil, fmt.Errorf("awskms: invalid signature: %w", err)
+	}
+
+	return sign, nil
+}
+
+// call 调用KMS接口(签名方式：Signature Version 4)
+func (s *Signer) call(ctx context.Context, target string, body []byte) (gjson.Result, error) {
+	endpoint := s.endpoint()
+
+	u, err := url.Parse(endpoint)
+
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", target)
+
+	s.signV4(req, u.Host, body, time.Now().UTC())
+
+	resp, err := s.cli.Do(req)
+
+	if err != nil {
+		return gjson.Result{}, fmt.Errorf("awskms: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+
+	if err != nil {
+		return gjson.Result{}, fmt.Errorf("awskms: %w", err)
+	}
+
+	ret := gjson.ParseBytes(b)
+
+	if resp.StatusCode != http.StatusOK {
+		return gjson.Result{}, fmt.Errorf("awskms: %s | %s", ret.Get("__type").String(), ret.Get("message").String())
+	}
+
+	return ret, nil
+}
+
+func (s *Signer) signV4(req *http.Request, host string, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	if len(s.cfg.SessionToken) != 0 {
+		req.Header.Set("X-Amz-Security-Token", s.cfg.SessionToken)
+	}
+
+	headers := map[string]string{"host": host}
+
+	for k, v := range req.Header {
+		headers[strings.ToLower(k)] = strings.TrimSpace(strings.Join(v, ","))
+	}
+
+	names := make([]string, 0, len(headers))
+
+	for k := range headers {
+		names = append(names, k)
+	}
+
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		"/",
+		"",
+		canonicalHeaders.String(),
+		signedHeaders,
+		hexSHA256(body),
+	}, "\n")
+
+	scope := date + "/" + s.cfg.Region + "/kms/aws4_request"
+
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hexSHA256([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretAccessKey), date)
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, "kms")
+	key = hmacSHA256(key, "aws4_request")
+
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", s.cfg.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func (s *Signer) endpoint() string {
+	if len(s.cfg.Endpoint) != 0 {
+		return s.cfg.Endpoint
+	}
+
+	return "https://kms." + s.cfg.Region + ".amazonaws.com/"
+}
+
+func hexSHA256(b []byte) string {
+	h := sha256.Sum256(b)
+
+	return hex.EncodeToString(h[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
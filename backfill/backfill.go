@@ -0,0 +1,329 @@
+// Package backfill 将 [From, To] 的区块按固定大小切分为区间，分配给多个进程并行扫描，用于大规模历史区块回填：
+// 按序号取模静态分片，或通过 antchain.Locker 租约动态领取区间(进程退出后区间由其它进程接管)；
+// 已完成的区间记录在 Progress 中，重启后跳过
+package backfill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/shenghui0779/antchain"
+)
+
+const (
+	defaultRangeSize = 1000
+	defaultLeaseTTL  = time.Minute
+	defaultRetry     = 10 * time.Second
+	defaultPrefix    = "antchain:backfill:"
+)
+
+// ErrProgressRequired 使用 Locker 动态领取区间时未配置 Progress：
+// 区间释放后无法得知是否已完成，会被每个进程重复扫描
+var ErrProgressRequired = errors.New("backfill: progress required when locker is set")
+
+// Handler 处理单个区块，返回错误则所在区间不标记为完成
+type Handler func(ctx context.Context, block *antchain.BlockResult) error
+
+// Range 待扫描的区块区间 [From, To]
+type Range struct {
+	Index int64
+	From  int64
+	To    int64
+}
+
+// Progress 记录已完成的区间
+type Progress interface {
+	// Done 区间是否已完成
+	Done(ctx context.Context, key string) (bool, error)
+
+	// MarkDone 标记区间已完成
+	MarkDone(ctx context.Context, key string) error
+}
+
+// KV 键值存储，与 redisstore.Client 一致，可直接复用 Redis 客户端的适配
+type KV interface {
+	Get(ctx context.Context, key string) (string, bool, error)
+	Set(ctx context.Context, key, value string, ttl time.Duration) error
+}
+
+type kvProgress struct {
+	kv KV
+}
+
+// NewKVProgress 返回基于键值存储(如：Redis)的 Progress，多个进程共享进度
+func NewKVProgress(kv KV) Progress {
+	return &kvProgress{kv: kv}
+}
+
+func (p *kvProgress) Done(ctx context.Context, key string) (bool, error) {
+	_, ok, err := p.kv.Get(ctx, key)
+
+	return ok, err
+}
+
+func (p *kvProgress) MarkDone(ctx context.Context, key string) error {
+	return p.kv.Set(ctx, key, "1", 0)
+}
+
+type memoryProgress struct {
+	mutex sync.Mutex
+	done  map[string]bool
+}
+
+// NewMemoryProgress 返回内存中的 Progress，仅适用于单进程
+func NewMemoryProgress() Progress {
+	return &memoryProgress{done: make(map[string]bool)}
+}
+
+func (p *memoryProgress) Done(ctx context.Context, key string) (bool, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.done[key], nil
+}
+
+func (p *memoryProgress) MarkDone(ctx context.Context, key string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.done[key] = true
+
+	return nil
+}
+
+// Config 回填配置
+type Config struct {
+	From      int64  // 起始块高
+	To        int64  // 结束块高(包含)
+	RangeSize int64  // 每个区间的区块数，默认 1000
+	Prefix    string // Progress 及锁的键前缀，默认 antchain:backfill:
+
+	// 取模分片：只扫描序号 % Shards == Shard 的区间，Shards<=1 表示不分片
+	Shard  int64
+	Shards int64
+
+	// Locker 不为空时按租约动态领取区间，被其它进程持有的区间稍后重试，直到全部区间完成；
+	// 须同时配置多个进程共享的 Progress(如：NewKVProgress)
+	Locker   antchain.Locker
+	LeaseTTL time.Duration // 区间租约有效期(执行期间自动续期)，默认 1 分钟
+	Retry    time.Duration // 存在被其它进程持有的区间时，再次尝试的间隔，默认 10 秒
+
+	Progress    Progress // 已完成区间的记录，为空则不记录
+	Concurrency int      // 区间内并发获取区块数，默认 1
+}
+
+// Stats 回填统计
+type Stats struct {
+	Ranges  int64 `json:"ranges"`  // 本进程完成的区间数
+	Blocks  int64 `json:"blocks"`  // 本进程处理的区块数
+	Skipped int64 `json:"skipped"` // 已完成而跳过的区间数
+	Failed  int64 `json:"failed"`  // 失败的区间数
+}
+
+// Scanner 分片回填扫描
+type Scanner struct {
+	cli     antchain.QueryService
+	cfg     *Config
+	handler Handler
+}
+
+// New 返回分片回填扫描
+func New(cli antchain.QueryService, cfg *Config, handler Handler) *Scanner {
+	return &Scanner{
+		cli:     cli,
+		cfg:     cfg,
+		handler: handler,
+	}
+}
+
+// Ranges 返回本进程负责的区间
+func (s *Scanner) Ranges() []Range {
+	size := s.cfg.RangeSize
+
+	if size <= 0 {
+		size = defaultRangeSize
+	}
+
+	ranges := make([]Range, 0)
+
+	for idx, from := int64(0), s.cfg.From; from <= s.cfg.To; idx, from = idx+1, from+size {
+		if s.cfg.Shards > 1 && idx%s.cfg.Shards != s.cfg.Shard {
+			continue
+		}
+
+		to := from + size - 1
+
+		if to > s.cfg.To {
+			to = s.cfg.To
+		}
+
+		ranges = append(ranges, Range{Index: idx, From: from, To: to})
+	}
+
+	return ranges
+}
+
+func (s *Scanner) key(r Range) string {
+	prefix := s.cfg.Prefix
+
+	if len(prefix) == 0 {
+		prefix = defaultPrefix
+	}
+
+	return prefix + strconv.FormatInt(r.From, 10) + "-" + strconv.FormatInt(r.To, 10)
+}
+
+// Run 扫描本进程负责的全部区间，单个区间失败不影响其它区间，返回第一个失败区间的错误；
+// 配置了 Locker 而未配置 Progress 时返回 ErrProgressRequired
+func (s *Scanner) Run(ctx context.Context) (Stats, error) {
+	var (
+		stats    Stats
+		firstErr error
+	)
+
+	if s.cfg.Locker != nil && s.cfg.Progress == nil {
+		return stats, ErrProgressRequired
+	}
+
+	pending := s.Ranges()
+
+	retry := s.cfg.Retry
+
+	if retry <= 0 {
+		retry = defaultRetry
+	}
+
+	for len(pending) != 0 {
+		held := make([]Range, 0)
+
+		for _, r := range pending {
+			if err := ctx.Err(); err != nil {
+				return stats, err
+			}
+
+			err := s.scan(ctx, r, &stats)
+
+			switch {
+			case err == nil:
+			case errors.Is(err, antchain.ErrLockHeld):
+				held = append(held, r)
+			case ctx.Err() != nil:
+				return stats, ctx.Err()
+			default:
+				stats.Failed++
+
+				if firstErr == nil {
+					firstErr = err
+				}
+			}
+		}
+
+		if len(held) == 0 {
+			break
+		}
+
+		// 被其它进程持有的区间稍后重试，持有者退出后租约过期即可接管
+		timer := time.NewTimer(retry)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return stats, ctx.Err()
+		case <-timer.C:
+		}
+
+		pending = held
+	}
+
+	return stats, firstErr
+}
+
+// scan 扫描单个区间，已完成的区间跳过
+func (s *Scanner) scan(ctx context.Context, r Range, stats *Stats) error {
+	key := s.key(r)
+
+	if done, err := s.done(ctx, key); err != nil || done {
+		if done {
+			stats.Skipped++
+		}
+
+		return err
+	}
+
+	if s.cfg.Locker == nil {
+		return s.process(ctx, r, key, stats)
+	}
+
+	ttl := s.cfg.LeaseTTL
+
+	if ttl <= 0 {
+		ttl = defaultLeaseTTL
+	}
+
+	return antchain.RunExclusive(ctx, s.cfg.Locker, key+":lease", ttl, func(ctx context.Context) error {
+		// 获取租约前区间可能刚被其它进程完成
+		if done, err := s.done(ctx, key); err != nil || done {
+			if done {
+				stats.Skipped++
+			}
+
+			return err
+		}
+
+		return s.process(ctx, r, key, stats)
+	})
+}
+
+func (s *Scanner) done(ctx context.Context, key string) (bool, error) {
+	if s.cfg.Progress == nil {
+		return false, nil
+	}
+
+	done, err := s.cfg.Progress.Done(ctx, key)
+
+	if err != nil {
+		return false, fmt.Errorf("backfill: progress %s: %w", key, err)
+	}
+
+	return done, nil
+}
+
+// process 获取并处理区间内的全部区块，成功后标记区间完成
+func (s *Scanner) process(ctx context.Context, r Range, key string, stats *Stats) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var blocks int64
+
+	for ret := range s.cli.FetchBlocks(ctx, r.From, r.To, s.cfg.Concurrency) {
+		if ret.Err != nil {
+			return fmt.Errorf("backfill: block %d: %w", ret.Number, ret.Err)
+		}
+
+		if err := s.handler(ctx, ret); err != nil {
+			return fmt.Errorf("backfill: handle block %d: %w", ret.Number, err)
+		}
+
+		blocks++
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if s.cfg.Progress != nil {
+		if err := s.cfg.Progress.MarkDone(ctx, key); err != nil {
+			return fmt.Errorf("backfill: progress %s: %w", key, err)
+		}
+	}
+
+	stats.Ranges++
+	stats.Blocks += blocks
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package backfill
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shenghui0779/antchain"
+)
+
+type nopLocker struct{}
+
+func (nopLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (antchain.Lock, error) {
+	return nil, errors.New("unexpected lock")
+}
+
+func TestLockerRequiresProgress(t *testing.T) {
+	s := New(nil, &Config{From: 0, To: 10, Locker: nopLocker{}}, func(ctx context.Context, block *antchain.BlockResult) error {
+		return nil
+	})
+
+	if _, err := s.Run(context.Background()); !errors.Is(err, ErrProgressRequired) {
+		t.Fatalf("err = %v, want ErrProgressRequired", err)
+	}
+}
+
+func TestRangesSharded(t *testing.T) {
+	s := New(nil, &Config{From: 1, To: 25, RangeSize: 10, Shard: 1, Shards: 2}, nil)
+
+	ranges := s.Ranges()
+
+	if len(ranges) != 1 || ranges[0] != (Range{Index: 1, From: 11, To: 20}) {
+		t.Fatalf("ranges = %+v", ranges)
+	}
+}
@@ -0,0 +1,188 @@
+package antchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/tidwall/gjson"
+)
+
+// ErrDiscontinuity 区块的 parentHash 与前一区块的 hash 不一致
+var ErrDiscontinuity = errors.New("antchain: block discontinuity")
+
+// DiscontinuityError 区块不连续的详细信息
+type DiscontinuityError struct {
+	Number     int64  // 当前块高
+	ParentHash string // 当前区块记录的 parentHash
+	PrevHash   string // 前一区块的 hash
+}
+
+func (e *DiscontinuityError) Error() string {
+	return fmt.Sprintf("antchain: block %d parentHash %s mismatches previous hash %s", e.Number, e.ParentHash, e.PrevHash)
+}
+
+// Is 支持 errors.Is(err, ErrDiscontinuity)
+func (e *DiscontinuityError) Is(target error) bool {
+	return target == ErrDiscontinuity
+}
+
+// FetchOption FetchBlocks 的可选配置
+type FetchOption func(s *fetchSetting)
+
+type fetchSetting struct {
+	checkContinuity bool
+	prevHash        string
+}
+
+// WithContinuityCheck 校验每个区块的 parentHash 与前一区块的 hash 是否一致，
+// 不一致时该区块的 Err 为 *DiscontinuityError；
+// prevHash 为 from-1 区块的 hash(用于衔接上一次获取的区间)，为空则从 from 区块开始校验
+func WithContinuityCheck(prevHash string) FetchOption {
+	return func(s *fetchSetting) {
+		s.checkContinuity = true
+		s.prevHash = prevHash
+	}
+}
+
+// headerField 读取块头字段，兼容块头数据被 block/blockHeader 包裹的情况
+func headerField(header, field string) gjson.Result {
+	for _, path := range []string{field, "blockHeader." + field, "block.blockHeader." + field} {
+		if v := gjson.Get(header, path); v.Exists() {
+			return v
+		}
+	}
+
+	return gjson.Result{}
+}
+
+// BlockResult 区块数据，Err 不为空表示该区块获取失败
+type BlockResult struct {
+	Number int64
+	Header string
+	Body   string
+	Err    error
+}
+
+type blockJob struct {
+	number int64
+	ret    chan *BlockResult
+}
+
+func (c *client) FetchBlocks(ctx context.Context, from, to int64, concurrency int, options ...FetchOption) <-chan *BlockResult {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	setting := new(fetchSetting)
+
+	for _, f := range options {
+		f(setting)
+	}
+
+	out := make(chan *BlockResult)
+	jobs := make(chan *blockJob)
+	// 按块高顺序排队的结果，容量限制了同时在途的区块数
+	queue := make(chan chan *BlockResult, concurrency)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for job := range jobs {
+				job.ret <- c.fetchBlock(ctx, job.number)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(queue)
+		defer close(jobs)
+
+		for n := from; n <= to; n++ {
+			job := &blockJob{
+				number: n,
+				ret:    make(chan *BlockResult, 1),
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case queue <- job.ret:
+			}
+
+			jobs <- job
+		}
+	}()
+
+	go func() {
+		defer close(out)
+
+		prevHash := setting.prevHash
+
+		for ret := range queue {
+			// worker 总会写入结果(ctx 取消时为错误)
+			v := <-ret
+
+			if setting.checkContinuity && v.Err == nil {
+				v.Err = checkContinuity(v, prevHash)
+				prevHash = headerField(v.Header, "hash").String()
+			} else {
+				prevHash = ""
+			}
+
+			select {
+			case <-ctx.Done():
+				// 排空队列，使生产者及 worker 退出
+				for range queue {
+				}
+
+				wg.Wait()
+
+				return
+			case out <- v:
+			}
+		}
+
+		wg.Wait()
+	}()
+
+	return out
+}
+
+func (c *client) fetchBlock(ctx context.Context, number int64) *BlockResult {
+	ret := &BlockResult{Number: number}
+
+	ret.Header, ret.Err = c.QueryBlockHeader(ctx, number)
+
+	if ret.Err != nil {
+		return ret
+	}
+
+	ret.Body, ret.Err = c.QueryBlockBody(ctx, number)
+
+	return ret
+}
+
+func checkContinuity(ret *BlockResult, prevHash string) error {
+	if len(prevHash) == 0 {
+		return nil
+	}
+
+	parentHash := headerField(ret.Header, "parentHash").String()
+
+	if parentHash == prevHash {
+		return nil
+	}
+
+	return &DiscontinuityError{
+		Number:     ret.Number,
+		ParentHash: parentHash,
+		PrevHash:   prevHash,
+	}
+}
@@ -0,0 +1,165 @@
+package antchain
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrGasBudgetExceeded 账户累计消耗的gas超出预算
+var ErrGasBudgetExceeded = errors.New("antchain: gas budget exceeded")
+
+// GasAlertFunc 账户gas消耗达到告警阈值时的回调
+type GasAlertFunc func(account string, spent, limit int64)
+
+// GasBudgetOption GasBudget 的可选配置
+type GasBudgetOption func(b *GasBudget)
+
+// WithGasAlert 累计消耗达到预算的 ratio(如：0.8)时回调 fn，每个账户每个预算周期只回调一次
+func WithGasAlert(ratio float64, fn GasAlertFunc) GasBudgetOption {
+	return func(b *GasBudget) {
+		b.alertRatio = ratio
+		b.alertFunc = fn
+	}
+}
+
+// WithGasBlocking 超出预算后拒绝提交新交易(返回 ErrGasBudgetExceeded)
+func WithGasBlocking() GasBudgetOption {
+	return func(b *GasBudget) {
+		b.blocking = true
+	}
+}
+
+// GasBudget 按账户跟踪累计消耗的gas并与预算比较，防止失控的任务耗尽额度；
+// 通过 WithGasBudget 接入客户端时，以交易的 gas 上限作为消耗(偏保守)，也可通过 Spend 记录回执中实际的 gasUsed
+type GasBudget struct {
+	alertRatio float64
+	alertFunc  GasAlertFunc
+	blocking   bool
+
+	mutex   sync.Mutex
+	limits  map[string]int64
+	spent   map[string]int64
+	alerted map[string]bool
+}
+
+// NewGasBudget 返回 GasBudget
+func NewGasBudget(options ...GasBudgetOption) *GasBudget {
+	b := &GasBudget{
+		limits:  make(map[string]int64),
+		spent:   make(map[string]int64),
+		alerted: make(map[string]bool),
+	}
+
+	for _, f := range options {
+		f(b)
+	}
+
+	return b
+}
+
+// SetLimit 设置账户的gas预算，未设置预算的账户不受限制
+func (b *GasBudget) SetLimit(account string, limit int64) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.limits[account] = limit
+}
+
+// Check 检查账户是否还能消耗 gas
+func (b *GasBudget) Check(account string, gas int64) error {
+	if !b.blocking {
+		return nil
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	limit, ok := b.limits[account]
+
+	if !ok || b.spent[account]+gas <= limit {
+		return nil
+	}
+
+	return fmt.Errorf("%w: account %s spent %d + %d > %d", ErrGasBudgetExceeded, account, b.spent[account], gas, limit)
+}
+
+// Spend 记录账户消耗的gas
+func (b *GasBudget) Spend(account string, gas int64) {
+	b.add(account, gas, false)
+}
+
+// reserve 原子地检查预算并预先记录消耗，供并发提交时使用(Check 与 Spend 之间可能被其它提交穿插)；
+// 提交失败时通过 refund 退回
+func (b *GasBudget) reserve(account string, gas int64) error {
+	return b.add(account, gas, b.blocking)
+}
+
+// refund 退回 reserve 预先记录的消耗
+func (b *GasBudget) refund(account string, gas int64) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.spent[account] -= gas
+}
+
+// add 记录消耗，check 为 true 时超出预算返回 ErrGasBudgetExceeded 且不记录
+func (b *GasBudget) add(account string, gas int64, check bool) error {
+	b.mutex.Lock()
+
+	if limit, ok := b.limits[account]; check && ok && b.spent[account]+gas > limit {
+		err := fmt.Errorf("%w: account %s spent %d + %d > %d", ErrGasBudgetExceeded, account, b.spent[account], gas, limit)
+
+		b.mutex.Unlock()
+
+		return err
+	}
+
+	b.spent[account] += gas
+
+	spent := b.spent[account]
+	limit, ok := b.limits[account]
+
+	alert := ok && b.alertFunc != nil && !b.alerted[account] && float64(spent) >= b.alertRatio*float64(limit)
+
+	if alert {
+		b.alerted[account] = true
+	}
+
+	b.mutex.Unlock()
+
+	if alert {
+		b.alertFunc(account, spent, limit)
+	}
+
+	return nil
+}
+
+// Spent 返回账户累计消耗的gas
+func (b *GasBudget) Spent(account string) int64 {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.spent[account]
+}
+
+// Reset 清空账户的累计消耗，开始新的预算周期
+func (b *GasBudget) Reset(account string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	delete(b.spent, account)
+	delete(b.alerted, account)
+}
+
+// gasParam 返回请求参数中的gas
+func gasParam(params X) int64 {
+	switch v := params["gas"].(type) {
+	case int:
+		return int64(v)
+	case int64:
+		return v
+	}
+
+	return 0
+}
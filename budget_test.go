@@ -0,0 +1,83 @@
+package antchain
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGasBudgetConcurrentSubmit(t *testing.T) {
+	release := make(chan struct{})
+
+	gw := newTestGateway(t, func(params X) (interface{}, bool) {
+		<-release
+
+		return "0xhash", true
+	})
+
+	budget := NewGasBudget(WithGasBlocking())
+	budget.SetLimit("account", 300)
+
+	cli := newTestClient(t, gw, WithGasBudget(budget))
+
+	var (
+		wg       sync.WaitGroup
+		ok       int32
+		exceeded int32
+	)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := cli.Deposit(context.Background(), "hello", 100)
+
+			switch {
+			case err == nil:
+				atomic.AddInt32(&ok, 1)
+			case errors.Is(err, ErrGasBudgetExceeded):
+				atomic.AddInt32(&exceeded, 1)
+			default:
+				t.Error(err)
+			}
+		}()
+	}
+
+	// 超出预算的提交在发出请求前即被拒绝，之后才放行已发出的请求
+	for atomic.LoadInt32(&exceeded) != 7 {
+		if t.Failed() {
+			break
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	close(release)
+	wg.Wait()
+
+	if ok != 3 || budget.Spent("account") != 300 {
+		t.Fatalf("ok = %d, spent = %d", ok, budget.Spent("account"))
+	}
+}
+
+func TestGasBudgetRefundOnFailure(t *testing.T) {
+	gw := newTestGateway(t, func(params X) (interface{}, bool) { return "revert", false })
+
+	budget := NewGasBudget(WithGasBlocking())
+	budget.SetLimit("account", 100)
+
+	cli := newTestClient(t, gw, WithGasBudget(budget))
+
+	if _, err := cli.Deposit(context.Background(), "hello", 100); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if spent := budget.Spent("account"); spent != 0 {
+		t.Fatalf("spent = %d after failed submit", spent)
+	}
+}
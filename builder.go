@@ -0,0 +1,237 @@
+package antchain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var methodSignRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*\(([^()]*)\)$`)
+
+// TxBuilder 链式构造合约调用交易，提交前校验参数
+type TxBuilder struct {
+	cli      *client
+	contract string
+	method   string
+	args     []interface{}
+	outTypes []string
+	gas      int
+	options  []ChainCallOption
+}
+
+func (c *client) NewTx() *TxBuilder {
+	return &TxBuilder{cli: c}
+}
+
+// Contract 设置合约名称
+func (b *TxBuilder) Contract(name string) *TxBuilder {
+	b.contract = name
+
+	return b
+}
+
+// Method 设置合约方法签名，如：set(string)
+func (b *TxBuilder) Method(sign string) *TxBuilder {
+	b.method = sign
+
+	return b
+}
+
+// ABIMethod 使用 ABI 中方法(ABI.Method 的返回)的签名及返回值类型
+func (b *TxBuilder) ABIMethod(m *ABIEntry) *TxBuilder {
+	b.method = m.Signature()
+	b.outTypes = m.OutTypes()
+
+	return b
+}
+
+// Args 设置方法参数，需与方法签名的参数个数一致
+func (b *TxBuilder) Args(args ...interface{}) *TxBuilder {
+	b.args = args
+
+	return b
+}
+
+// OutTypes 设置方法返回值类型，如：string、uint256
+func (b *TxBuilder) OutTypes(types ...string) *TxBuilder {
+	b.outTypes = types
+
+	return b
+}
+
+// Gas 设置 gas
+func (b *TxBuilder) Gas(gas int) *TxBuilder {
+	b.gas = gas
+
+	return b
+}
+
+// Options 设置其它请求参数
+func (b *TxBuilder) Options(options ...ChainCallOption) *TxBuilder {
+	b.options = append(b.options, options...)
+
+	return b
+}
+
+// Validate 校验交易参数
+func (b *TxBuilder) Validate() error {
+	if err := b.validateCall(); err != nil {
+		return err
+	}
+
+	if b.gas <= 0 {
+		return errors.New("antchain: gas must be positive")
+	}
+
+	return nil
+}
+
+// validateCall 校验合约名称、方法签名及参数
+func (b *TxBuilder) validateCall() error {
+	if len(b.contract) == 0 {
+		return errors.New("antchain: contract name is required")
+	}
+
+	m := methodSignRegexp.FindStringSubmatch(b.method)
+
+	if m == nil {
+		return fmt.Errorf("antchain: invalid method signature %q", b.method)
+	}
+
+	paramCount := 0
+
+	if params := strings.TrimSpace(m[1]); len(params) != 0 {
+		paramCount = len(strings.Split(params, ","))
+	}
+
+	if paramCount != len(b.args) {
+		return fmt.Errorf("antchain: method %s expects %d args, got %d", b.method, paramCount, len(b.args))
+	}
+
+	return nil
+}
+
+// encodeArgs 返回 JSON 格式的方法参数及返回值类型
+func (b *TxBuilder) encodeArgs() (string, string, error) {
+	args := b.args
+
+	if args == nil {
+		args = []interface{}{}
+	}
+
+	inputParams, err := json.Marshal(args)
+
+	if err != nil {
+		return "", "", wrapErr(ErrDecodeFailed, err)
+	}
+
+	outTypes := b.outTypes
+
+	if outTypes == nil {
+		outTypes = []string{}
+	}
+
+	outTypesStr, err := json.Marshal(outTypes)
+
+	if err != nil {
+		return "", "", wrapErr(ErrDecodeFailed, err)
+	}
+
+	return string(inputParams), string(outTypesStr), nil
+}
+
+// Send 校验并异步提交交易
+func (b *TxBuilder) Send(ctx context.Context) (*TxHandle, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	inputParams, outTypes, err := b.encodeArgs()
+
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := b.cli.AsyncCallSolidity(ctx, b.contract, b.method, inputParams, outTypes, b.gas, b.options...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &TxHandle{
+		Hash: hash,
+		cli:  b.cli,
+	}, nil
+}
+
+// Simulate 校验并模拟执行交易(不改变状态、不消耗gas)，可在正式提交前验证调用
+func (b *TxBuilder) Simulate(ctx context.Context) (*SimulateResult, error) {
+	if err := b.validateCall(); err != nil {
+		return nil, err
+	}
+
+	inputParams, outTypes, err := b.encodeArgs()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return b.cli.SimulateSolidity(ctx, b.contract, b.method, inputParams, outTypes, b.options...)
+}
+
+// TxHandle 已提交的交易
+type TxHandle struct {
+	Hash string
+	cli  *client
+}
+
+// Receipt 查询交易回执
+func (h *TxHandle) Receipt(ctx context.Context) (string, error) {
+	return h.cli.QueryReceipt(ctx, h.Hash)
+}
+
+// defaultWaitInterval WaitReceipt 默认的轮询间隔
+const defaultWaitInterval = time.Second
+
+// Wait 等待交易回执，见 WaitReceipt
+func (h *TxHandle) Wait(ctx context.Context, interval time.Duration) (string, error) {
+	return WaitReceipt(ctx, h.cli, h.Hash, interval)
+}
+
+// ReceiptQuerier 查询交易回执(如：Client)
+type ReceiptQuerier interface {
+	QueryReceipt(ctx context.Context, hash string) (string, error)
+}
+
+// WaitReceipt 按 interval 轮询交易回执，直到查询到回执或 ctx 结束；interval<=0 时使用默认的 1 秒；
+// 回执的执行结果(result)非 0 时同时返回回执及 ErrTxFailed
+func WaitReceipt(ctx context.Context, q ReceiptQuerier, hash string, interval time.Duration) (string, error) {
+	if interval <= 0 {
+		interval = defaultWaitInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		receipt, err := q.QueryReceipt(ctx, hash)
+
+		if err == nil && len(receipt) != 0 {
+			return receipt, receiptError(hash, receipt)
+		}
+
+		select {
+		case <-ctx.Done():
+			if err == nil {
+				err = ctx.Err()
+			}
+
+			return "", err
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package antchain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTxHandleWaitDefaultInterval(t *testing.T) {
+	gw := newTestGateway(t, func(params X) (interface{}, bool) { return `{"result":0}`, true })
+
+	cli := newTestClient(t, gw)
+
+	receipt, err := (&TxHandle{Hash: "0x1", cli: cli}).Wait(context.Background(), 0)
+
+	if err != nil || receipt == "" {
+		t.Fatalf("receipt = %q, err = %v", receipt, err)
+	}
+}
+
+func TestWaitReceipt(t *testing.T) {
+	chain := newFakeChain()
+	chain.receipts["0xok"] = `{"result":0}`
+	chain.receipts["0xfail"] = `{"result":10201}`
+
+	cases := []struct {
+		hash    string
+		receipt bool
+		want    error
+	}{
+		{"0xok", true, nil},
+		{"0xfail", true, ErrTxFailed},
+		{"0xpending", false, context.DeadlineExceeded},
+	}
+
+	for _, c := range cases {
+		t.Run(c.hash, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			receipt, err := WaitReceipt(ctx, chain, c.hash, time.Millisecond)
+
+			if (c.want == nil && err != nil) || (c.want != nil && !errors.Is(err, c.want)) {
+				t.Fatalf("err = %v, want %v", err, c.want)
+			}
+
+			if got := len(receipt) != 0; got != c.receipt {
+				t.Fatalf("receipt = %q", receipt)
+			}
+		})
+	}
+}
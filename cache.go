@@ -0,0 +1,146 @@
+package antchain
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// Cache 查询结果缓存，可自行实现(如：Redis)
+type Cache interface {
+	// Get 返回缓存的数据
+	Get(key string) (string, bool)
+
+	// Set 缓存数据，ttl<=0 表示永不过期
+	Set(key, value string, ttl time.Duration)
+}
+
+type lruEntry struct {
+	key      string
+	value    string
+	expireAt time.Time
+}
+
+// lruCache 基于 LRU 淘汰的内存缓存
+type lruCache struct {
+	size  int
+	mutex sync.Mutex
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+// NewLRUCache 返回容量为 size 的内存 LRU 缓存
+func NewLRUCache(size int) Cache {
+	return &lruCache{
+		size:  size,
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+func (lc *lruCache) Get(key string) (string, bool) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	elem, ok := lc.items[key]
+
+	if !ok {
+		return "", false
+	}
+
+	entry := elem.Value.(*lruEntry)
+
+	if !entry.expireAt.IsZero() && time.Now().After(entry.expireAt) {
+		lc.ll.Remove(elem)
+		delete(lc.items, key)
+
+		return "", false
+	}
+
+	lc.ll.MoveToFront(elem)
+
+	return entry.value, true
+}
+
+func (lc *lruCache) Set(key, value string, ttl time.Duration) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	var expireAt time.Time
+
+	if ttl > 0 {
+		expireAt = time.Now().Add(ttl)
+	}
+
+	if elem, ok := lc.items[key]; ok {
+		entry := elem.Value.(*lruEntry)
+		entry.value = value
+		entry.expireAt = expireAt
+
+		lc.ll.MoveToFront(elem)
+
+		return
+	}
+
+	lc.items[key] = lc.ll.PushFront(&lruEntry{
+		key:      key,
+		value:    value,
+		expireAt: expireAt,
+	})
+
+	for lc.size > 0 && lc.ll.Len() > lc.size {
+		oldest := lc.ll.Back()
+
+		lc.ll.Remove(oldest)
+		delete(lc.items, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// cachedChainCall 用于不可变数据(已上链的交易、回执、区块)的查询，优先读取缓存；
+// 缓存 key 包含 BizID，避免多条链共用缓存时串链；只缓存终态结果(见 cacheable)
+func (c *client) cachedChainCall(ctx context.Context, method Method, key string, options ...ChainCallOption) (string, error) {
+	if c.cache == nil {
+		return c.chainCall(ctx, method, options...)
+	}
+
+	cacheKey := c.credential().cfg.BizID + ":" + string(method) + ":" + key
+
+	if data, ok := c.cache.Get(cacheKey); ok {
+		return data, nil
+	}
+
+	data, err := c.chainCall(ctx, method, options...)
+
+	if err != nil {
+		return "", err
+	}
+
+	if cacheable(method, data) {
+		c.cache.Set(cacheKey, data, c.cacheTTL)
+	}
+
+	return data, nil
+}
+
+// cacheable 判断查询结果是否为不可变的终态：空结果、非 JSON 对象及尚未打包(无块高)的交易不缓存，
+// 否则交易上链后仍会读到缓存中的旧结果
+func cacheable(method Method, data string) bool {
+	if !gjson.Valid(data) {
+		return false
+	}
+
+	ret := gjson.Parse(data)
+
+	if !ret.IsObject() || len(ret.Map()) == 0 {
+		return false
+	}
+
+	if method == MethodQueryTransaction && ret.Get("blockNumber").Int() <= 0 {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,71 @@
+package antchain
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCachedChainCallSkipsPendingTx(t *testing.T) {
+	var mined atomic.Bool
+
+	gw := newTestGateway(t, func(params X) (interface{}, bool) {
+		if mined.Load() {
+			return `{"hash":"0x1","blockNumber":10}`, true
+		}
+
+		return `{"hash":"0x1"}`, true
+	})
+
+	cli := newTestClient(t, gw, WithCache(NewLRUCache(16), time.Minute))
+
+	if data, err := cli.QueryTransaction(context.Background(), "0x1"); err != nil || data != `{"hash":"0x1"}` {
+		t.Fatalf("data = %q, err = %v", data, err)
+	}
+
+	mined.Store(true)
+
+	if data, err := cli.QueryTransaction(context.Background(), "0x1"); err != nil || data != `{"hash":"0x1","blockNumber":10}` {
+		t.Fatalf("pending tx served from cache: data = %q, err = %v", data, err)
+	}
+
+	if _, ok := cli.cache.Get("biz:" + string(MethodQueryTransaction) + ":0x1"); !ok {
+		t.Fatal("mined tx not cached")
+	}
+}
+
+func TestCachedChainCallKeyedByBizID(t *testing.T) {
+	cache := NewLRUCache(16)
+	cache.Set("other:"+string(MethodQueryReceipt)+":0x1", `{"result":1}`, 0)
+
+	gw := newTestGateway(t, func(params X) (interface{}, bool) { return `{"result":0}`, true })
+
+	cli := newTestClient(t, gw, WithCache(cache, time.Minute))
+
+	if data, err := cli.QueryReceipt(context.Background(), "0x1"); err != nil || data != `{"result":0}` {
+		t.Fatalf("data = %q, err = %v", data, err)
+	}
+}
+
+func TestCacheable(t *testing.T) {
+	cases := []struct {
+		method Method
+		data   string
+		want   bool
+	}{
+		{MethodQueryReceipt, "", false},
+		{MethodQueryReceipt, "null", false},
+		{MethodQueryReceipt, "{}", false},
+		{MethodQueryReceipt, `{"result":0}`, true},
+		{MethodQueryTransaction, `{"hash":"0x1"}`, false},
+		{MethodQueryTransaction, `{"hash":"0x1","blockNumber":3}`, true},
+		{MethodQueryBlock, `{"number":3}`, true},
+	}
+
+	for _, c := range cases {
+		if got := cacheable(c.method, c.data); got != c.want {
+			t.Errorf("cacheable(%s, %q) = %v, want %v", c.method, c.data, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package antchain
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"github.com/tidwall/gjson"
+)
+
+// ErrNoCertificatePDF 存证证书未包含 PDF 文件
+var ErrNoCertificatePDF = errors.New("antchain: certificate has no pdf")
+
+// DepositCertificate 存证证书(公证/司法等场景提交的存证凭证)
+type DepositCertificate struct {
+	CertID      string `json:"certId"`      // 证书编号
+	TxHash      string `json:"txHash"`      // 存证交易hash
+	BlockNumber int64  `json:"blockNumber"` // 存证所在块高
+	Timestamp   int64  `json:"timestamp"`   // 存证时间(毫秒时间戳)
+	ContentHash string `json:"contentHash"` // 存证内容摘要
+	Issuer      string `json:"issuer"`      // 出证机构
+	Signature   string `json:"signature"`   // 出证机构对证书的签名
+	PDFData     string `json:"pdf,omitempty"`
+	Raw         string `json:"-"` // 网关返回的完整数据
+}
+
+// ParseDepositCertificate 解析存证证书查询结果
+func ParseDepositCertificate(data string) (*DepositCertificate, error) {
+	if !gjson.Valid(data) {
+		return nil, wrapErr(ErrDecodeFailed, errors.New("invalid certificate json"))
+	}
+
+	cert := new(DepositCertificate)
+
+	if err := json.Unmarshal([]byte(data), cert); err != nil {
+		return nil, wrapErr(ErrDecodeFailed, err)
+	}
+
+	cert.Raw = data
+
+	return cert, nil
+}
+
+// JSON 返回用于提交的证书 JSON(缩进格式，不含 PDF 数据)
+func (dc *DepositCertificate) JSON() ([]byte, error) {
+	v := *dc
+	v.PDFData = ""
+
+	return json.MarshalIndent(&v, "", "  ")
+}
+
+// HasPDF 证书是否包含 PDF 文件
+func (dc *DepositCertificate) HasPDF() bool {
+	return len(dc.PDFData) != 0
+}
+
+// PDF 返回证书的 PDF 文件，未包含时返回 ErrNoCertificatePDF
+func (dc *DepositCertificate) PDF() ([]byte, error) {
+	if !dc.HasPDF() {
+		return nil, ErrNoCertificatePDF
+	}
+
+	b, err := base64.StdEncoding.DecodeString(dc.PDFData)
+
+	if err != nil {
+		return nil, wrapErr(ErrDecodeFailed, err)
+	}
+
+	return b, nil
+}
+
+// GetDepositCertificate 查询存证交易的存证证书；需要网关开通存证证书服务(QUERYDEPOSITCERT 方法)，
+// withPDF 为 true 时同时获取证书的 PDF 文件
+func (c *client) GetDepositCertificate(ctx context.Context, txHash string, withPDF bool) (*DepositCertificate, error) {
+	data, err := c.chainCall(ctx, MethodQueryDepositCert,
+		WithParam("hash", txHash),
+		WithParam("withPdf", withPDF),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseDepositCertificate(data)
+}
@@ -0,0 +1,193 @@
+package antchain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// ChunkManifestType 分片存证清单的类型标识
+	ChunkManifestType = "antchain.chunked-deposit.v1"
+	// DefaultChunkSize 分片存证默认的分片大小
+	DefaultChunkSize = 4 << 20
+	// MaxChunkSize 分片大小的上限，校验时按清单中的分片大小分配缓冲区，避免被篡改的清单耗尽内存
+	MaxChunkSize = 64 << 20
+)
+
+// ManifestChunk 分片存证清单中的分片
+type ManifestChunk struct {
+	Size   int64  `json:"size"`   // 分片字节数
+	SHA256 string `json:"sha256"` // 分片的 SHA-256 摘要(hex)，即该分片存证的内容
+	TxHash string `json:"txHash"` // 分片存证的交易hash
+}
+
+// ChunkManifest 分片存证清单，作为最后一笔存证写入链上
+type ChunkManifest struct {
+	Type      string           `json:"type"`
+	Size      int64            `json:"size"`      // 内容总字节数
+	ChunkSize int              `json:"chunkSize"` // 分片大小
+	SHA256    string           `json:"sha256"`    // 完整内容的 SHA-256 摘要(hex)
+	Chunks    []*ManifestChunk `json:"chunks"`
+	TxHash    string           `json:"-"` // 清单存证的交易hash
+}
+
+// ParseChunkManifest 解析分片存证清单
+func ParseChunkManifest(data string) (*ChunkManifest, error) {
+	m := new(ChunkManifest)
+
+	if err := json.Unmarshal([]byte(data), m); err != nil {
+		return nil, wrapErr(ErrDecodeFailed, err)
+	}
+
+	if m.Type != ChunkManifestType {
+		return nil, wrapErr(ErrDecodeFailed, fmt.Errorf("not a chunk manifest (type %q)", m.Type))
+	}
+
+	return m, nil
+}
+
+// DepositChunked 对超过存证大小限制的内容(如：音视频文件)分片存证：按 chunkSize 读取 r，逐片存证其 SHA-256 摘要，
+// 最后存证链接全部分片的清单(ChunkManifest)，返回的清单 TxHash 即为该内容的存证凭据；chunkSize<=0 时使用 DefaultChunkSize；
+// 分片数过多时清单本身可能超过存证大小限制，应相应增大 chunkSize(不超过 MaxChunkSize)；
+// 中途失败时同时返回错误及已存证分片的清单(TxHash 为空)，便于核对或续传
+func (c *client) DepositChunked(ctx context.Context, r io.Reader, chunkSize, gas int, options ...ChainCallOption) (*ChunkManifest, error) {
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	if chunkSize > MaxChunkSize {
+		return nil, fmt.Errorf("antchain: chunk size %d exceeds %d", chunkSize, MaxChunkSize)
+	}
+
+	m := &ChunkManifest{
+		Type:      ChunkManifestType,
+		ChunkSize: chunkSize,
+		Chunks:    make([]*ManifestChunk, 0),
+	}
+
+	total := sha256.New()
+	buf := make([]byte, chunkSize)
+
+	for {
+		n, err := io.ReadFull(r, buf)
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return m, err
+		}
+
+		total.Write(buf[:n])
+
+		h := sha256.Sum256(buf[:n])
+		digest := hex.EncodeToString(h[:])
+
+		txHash, derr := c.Deposit(ctx, digest, gas, options...)
+
+		if derr != nil {
+			return m, fmt.Errorf("antchain: deposit chunk %d: %w", len(m.Chunks), derr)
+		}
+
+		m.Chunks = append(m.Chunks, &ManifestChunk{
+			Size:   int64(n),
+			SHA256: digest,
+			TxHash: txHash,
+		})
+
+		m.Size += int64(n)
+
+		if err == io.ErrUnexpectedEOF {
+			break
+		}
+	}
+
+	m.SHA256 = hex.EncodeToString(total.Sum(nil))
+
+	b, err := json.Marshal(m)
+
+	if err != nil {
+		return m, wrapErr(ErrDecodeFailed, err)
+	}
+
+	txHash, err := c.Deposit(ctx, string(b), gas, options...)
+
+	if err != nil {
+		return m, fmt.Errorf("antchain: deposit chunk manifest: %w", err)
+	}
+
+	m.TxHash = txHash
+
+	return m, nil
+}
+
+// VerifyChunkedDeposit 校验分片存证：读取链上清单及各分片存证，并与 r 的内容逐片比对；不一致返回 ErrDepositMismatch，
+// 清单或任一分片的交易执行失败返回 ErrTxFailed(见 VerifyDeposit)
+func (c *client) VerifyChunkedDeposit(ctx context.Context, manifestTxHash string, r io.Reader) error {
+	if err := c.checkReceipt(ctx, manifestTxHash); err != nil {
+		return err
+	}
+
+	data, err := c.GetDepositContent(ctx, manifestTxHash)
+
+	if err != nil {
+		return err
+	}
+
+	m, err := ParseChunkManifest(data)
+
+	if err != nil {
+		return err
+	}
+
+	if m.ChunkSize <= 0 || m.ChunkSize > MaxChunkSize {
+		return wrapErr(ErrDecodeFailed, fmt.Errorf("invalid chunk size %d", m.ChunkSize))
+	}
+
+	total := sha256.New()
+	buf := make([]byte, m.ChunkSize)
+
+	var size int64
+
+	for i, chunk := range m.Chunks {
+		n, err := io.ReadFull(r, buf)
+
+		if err != nil && err != io.ErrUnexpectedEOF {
+			if err == io.EOF {
+				return wrapErr(ErrDepositMismatch, fmt.Errorf("content ends before chunk %d", i))
+			}
+
+			return err
+		}
+
+		total.Write(buf[:n])
+		size += int64(n)
+
+		h := sha256.Sum256(buf[:n])
+
+		if digest := hex.EncodeToString(h[:]); !strings.EqualFold(digest, chunk.SHA256) {
+			return wrapErr(ErrDepositMismatch, fmt.Errorf("chunk %d digest %s, manifest %s", i, digest, chunk.SHA256))
+		}
+
+		if err := c.VerifyDeposit(ctx, chunk.TxHash, chunk.SHA256); err != nil {
+			return fmt.Errorf("antchain: chunk %d (tx %s): %w", i, chunk.TxHash, err)
+		}
+	}
+
+	// 内容比清单更长
+	if n, _ := io.ReadFull(r, buf[:1]); n != 0 {
+		return wrapErr(ErrDepositMismatch, fmt.Errorf("content exceeds %d chunks", len(m.Chunks)))
+	}
+
+	if size != m.Size || !strings.EqualFold(hex.EncodeToString(total.Sum(nil)), m.SHA256) {
+		return wrapErr(ErrDepositMismatch, fmt.Errorf("content size %d, manifest %d", size, m.Size))
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package antchain
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// depositChain 模拟存证上链及查询，failAt 为第几笔存证(从1开始)失败，0 表示不失败；reverted 中的交易回执执行失败
+type depositChain struct {
+	mutex    sync.Mutex
+	contents map[string]string
+	failAt   int
+	reverted map[string]bool
+}
+
+func (d *depositChain) handle(params X) (interface{}, bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	switch Method(params["method"].(string)) {
+	case MethodDeposit:
+		if d.failAt == len(d.contents)+1 {
+			return "deposit failed", false
+		}
+
+		hash := fmt.Sprintf("0x%d", len(d.contents))
+		d.contents[hash] = params["content"].(string)
+
+		return hash, true
+	case MethodQueryTransaction:
+		content, ok := d.contents[params["hash"].(string)]
+
+		if !ok {
+			return nil, false
+		}
+
+		b, _ := json.Marshal(X{"txType": TxTypeDeposit, "data": base64.StdEncoding.EncodeToString([]byte(content))})
+
+		return string(b), true
+	case MethodQueryReceipt:
+		hash := params["hash"].(string)
+
+		if _, ok := d.contents[hash]; !ok {
+			return nil, false
+		}
+
+		if d.reverted[hash] {
+			return `{"result":10201}`, true
+		}
+
+		return `{"result":0}`, true
+	}
+
+	return nil, false
+}
+
+func TestDepositChunkedRoundTrip(t *testing.T) {
+	chain := &depositChain{contents: make(map[string]string)}
+
+	cli := newTestClient(t, newTestGateway(t, chain.handle))
+
+	content := bytes.Repeat([]byte("0123456789"), 25)
+
+	m, err := cli.DepositChunked(context.Background(), bytes.NewReader(content), 100, 10)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(m.Chunks) != 3 || m.Size != 250 || m.TxHash == "" {
+		t.Fatalf("manifest = %+v", m)
+	}
+
+	if err = cli.VerifyChunkedDeposit(context.Background(), m.TxHash, bytes.NewReader(content)); err != nil {
+		t.Fatal(err)
+	}
+
+	chain.mutex.Lock()
+	chain.reverted = map[string]bool{m.Chunks[1].TxHash: true}
+	chain.mutex.Unlock()
+
+	if err = cli.VerifyChunkedDeposit(context.Background(), m.TxHash, bytes.NewReader(content)); !errors.Is(err, ErrTxFailed) {
+		t.Fatalf("err = %v, want ErrTxFailed", err)
+	}
+
+	chain.mutex.Lock()
+	chain.reverted = nil
+	chain.mutex.Unlock()
+
+	content[120] = 'x'
+
+	if err = cli.VerifyChunkedDeposit(context.Background(), m.TxHash, bytes.NewReader(content)); !errors.Is(err, ErrDepositMismatch) {
+		t.Fatalf("err = %v, want ErrDepositMismatch", err)
+	}
+}
+
+func TestDepositChunkedPartialFailure(t *testing.T) {
+	chain := &depositChain{contents: make(map[string]string), failAt: 3}
+
+	cli := newTestClient(t, newTestGateway(t, chain.handle))
+
+	m, err := cli.DepositChunked(context.Background(), bytes.NewReader(make([]byte, 500)), 100, 10)
+
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if m == nil || len(m.Chunks) != 2 || m.TxHash != "" {
+		t.Fatalf("manifest = %+v", m)
+	}
+
+	if _, err = cli.DepositChunked(context.Background(), bytes.NewReader(nil), MaxChunkSize+1, 10); err == nil {
+		t.Fatal("expected chunk size error")
+	}
+}
+
+func TestVerifyChunkedDepositRejectsHugeChunkSize(t *testing.T) {
+	chain := &depositChain{contents: map[string]string{
+		"0xm": fmt.Sprintf(`{"type":%q,"chunkSize":%d,"chunks":[]}`, ChunkManifestType, 1<<40),
+	}}
+
+	cli := newTestClient(t, newTestGateway(t, chain.handle))
+
+	if err := cli.VerifyChunkedDeposit(context.Background(), "0xm", bytes.NewReader(nil)); !errors.Is(err, ErrDecodeFailed) {
+		t.Fatalf("err = %v, want ErrDecodeFailed", err)
+	}
+}
@@ -3,18 +3,19 @@ package antchain
 import (
 	"bytes"
 	"context"
-	"crypto"
-	"crypto/tls"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"io/ioutil"
-	"net"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/tidwall/gjson"
 )
 
@@ -26,39 +27,37 @@ type Config struct {
 	AccessKey  string `json:"access_key"`  // AccessKey (注意：Key文件路径)
 	Account    string `json:"account"`     // 链账户
 	MyKmsKeyID string `json:"mykmskey_id"` // 托管标识
+
+	RestAPIVersion string   `json:"rest_api_version"` // REST API 版本(restApiVersion)，为空则不指定
+	SignType       SignType `json:"sign_type"`        // shakehand 签名算法，默认 SHA256WithRSA
 }
 
-// Client 发送请求使用的客户端
+// Client 发送请求使用的客户端，由各子服务组合而成；只依赖部分能力时可使用对应的子接口
 type Client interface {
-	// CreateAccount 创建账户
-	CreateAccount(ctx context.Context, account, kmsID string, gas int) (string, error)
-
-	// Deposit 存证
-	Deposit(ctx context.Context, content string, gas int) (string, error)
-
-	// DeploySolidity 部署Solidity合约
-	DeploySolidity(ctx context.Context, name, code string, gas int) (string, error)
-
-	// AsyncCallSolidity 异步调用Solidity合约
-	AsyncCallSolidity(ctx context.Context, contractName, methodSign, inputParams, outTypes string, gas int) (string, error)
+	AccountService
+	DepositService
+	NotaryService
+	ContractService
+	QueryService
 
-	// QueryTransaction 查询交易
-	QueryTransaction(ctx context.Context, hash string) (string, error)
+	// Reload 使用新的配置替换当前凭证(AccessID、AccessKey、链账户等)
+	Reload(cfg *Config) error
 
-	// QueryReceipt 查询交易回执
-	QueryReceipt(ctx context.Context, hash string) (string, error)
+	// ChainCall 调用 SDK 尚未封装的查询类网关方法
+	ChainCall(ctx context.Context, method Method, options ...ChainCallOption) (string, error)
 
-	// QueryBlockHeader 查询块头
-	QueryBlockHeader(ctx context.Context, blockNumber int64) (string, error)
+	// ChainCallForBiz 调用 SDK 尚未封装的交易类网关方法
+	ChainCallForBiz(ctx context.Context, method Method, options ...ChainCallOption) (string, error)
 
-	// QueryBlockBody 查询块体
-	QueryBlockBody(ctx context.Context, blockNumber int64) (string, error)
+	// Probe 按顺序探测网关支持的 restApiVersion 并用于后续请求，不指定则探测 Config.RestAPIVersion(未配置则不探测)
+	Probe(ctx context.Context, versions ...string) (string, error)
 
-	// QueryLastBlock 查询最新块高
-	QueryLastBlock(ctx context.Context) (string, error)
+	// Diagnostics 返回连接池、服务地址解析及最近一次 shakehand 的状态
+	Diagnostics(ctx context.Context) *Diagnostics
 
-	// QueryAccount 查询账户
-	QueryAccount(ctx context.Context, account string) (string, error)
+	// Close 拒绝新的请求并停止后台任务(如：token保活、配置热更新)，在 ctx 结束前等待其退出及进行中的请求完成；
+	// 未能完成时返回 *ShutdownError
+	Close(ctx context.Context) error
 }
 
 type ChainCallOption func(params X)
@@ -69,91 +68,340 @@ func WithParam(key string, value interface{}) ChainCallOption {
 	}
 }
 
+// WithProperty 设置存证的扩展属性
+func WithProperty(key, value string) ChainCallOption {
+	return func(params X) {
+		props, ok := params["properties"].(map[string]string)
+
+		if !ok {
+			props = make(map[string]string)
+			params["properties"] = props
+		}
+
+		props[key] = value
+	}
+}
+
+// WithAccount 以指定的链账户及其托管密钥(mykmsKeyId)提交交易，默认使用 Config 中的 Account、MyKmsKeyID；
+// 用于托管平台代多个终端用户账户提交交易
+func WithAccount(account, kmsKeyID string) ChainCallOption {
+	return func(params X) {
+		params["account"] = account
+		params["mykmsKeyId"] = kmsKeyID
+	}
+}
+
+// WithTenant 以指定的租户提交交易，默认使用 Config 中的 TenantID
+func WithTenant(tenantID string) ChainCallOption {
+	return func(params X) {
+		params["tenantid"] = tenantID
+	}
+}
+
 type client struct {
-	cli *http.Client
-	cfg *Config
-	key *PrivateKey
+	endpoint  string
+	region    Region
+	env       Environment
+	cli       *http.Client
+	transport transportSetting
+	cred      atomic.Value
+	provider  CredentialsProvider
+	keyFS     fs.FS
+	watcher   *reloadWatcher
+
+	hooks      []Hook
+	logSetting logSetting
+	log        *slog.Logger
+	metrics    Metrics
+	pool       poolStats
+	abis       *ABIRegistry
+	budget     *GasBudget
+	version    apiVersion
+	strict     bool
+	validators map[Method][]ResponseValidator
+	policy     *CallPolicy
+	audit      AuditStore
+	errCodes   map[ErrCode]error
+
+	cache    Cache
+	cacheTTL time.Duration
+
+	dedup   bool
+	flights flightGroup
+
+	queryRetry  retrySetting
+	submitRetry retrySetting
+
+	ids             IDGenerator
+	clock           Clock
+	skew            clockSkew
+	tokenTTL        tokenSetting
+	shakehandBudget float64
+	tokens          tokenCache
+	tokenStore      TokenStore
+	locker          Locker
+	lastShakehand   shakehandRecord
+	refreshMutex    sync.Mutex
+
+	keepAliveInterval time.Duration
+	life              *lifecycle
 }
 
-func (c *client) shakehand(ctx context.Context) (string, error) {
-	timeStr := strconv.FormatInt(time.Now().UnixMilli(), 10)
+func (c *client) shakehand(ctx context.Context) (*accessToken, error) {
+	accessID, signer, signType, err := c.accessKey(ctx)
+
+	if err != nil {
+		return nil, wrapErr(ErrShakehandFailed, err)
+	}
 
-	sign, err := c.key.Sign(crypto.SHA256, []byte(c.cfg.AccessID+timeStr))
+	timeStr := strconv.FormatInt(c.serverNow().UnixMilli(), 10)
+
+	sign, err := signer.Sign(signType.Hash(), []byte(accessID+timeStr))
 
 	if err != nil {
-		return "", err
+		return nil, wrapErr(ErrShakehandFailed, err)
 	}
 
 	params := X{
-		"accessId": c.cfg.AccessID,
+		"accessId": accessID,
 		"time":     timeStr,
 		"secret":   hex.EncodeToString(sign),
 	}
 
-	return c.do(ctx, c.cfg.Endpoint+SHAKE_HAND, params)
+	ret, err := c.doRaw(ctx, c.endpoint+SHAKE_HAND, params)
+
+	if err != nil {
+		return nil, wrapErr(ErrShakehandFailed, err)
+	}
+
+	return &accessToken{
+		value:    ret.Get("data").String(),
+		accessID: accessID,
+		expireAt: parseTokenExpiry(ret, c.now(), c.skew.get()),
+		issuedAt: c.now(),
+	}, nil
 }
 
-func (c *client) chainCall(ctx context.Context, method string, options ...ChainCallOption) (string, error) {
-	token, err := c.shakehand(ctx)
+// parseTokenExpiry 解析 shakehand 响应中的 token 有效期：
+// expiresIn(有效秒数) 或 expireTime(过期时间的毫秒时间戳，按时钟偏差换算为本地时间)，均未返回则为零值
+func parseTokenExpiry(ret gjson.Result, now time.Time, skew time.Duration) time.Time {
+	if v := ret.Get("expiresIn"); v.Exists() && v.Int() > 0 {
+		return now.Add(time.Duration(v.Int()) * time.Second)
+	}
+
+	if v := ret.Get("expireTime"); v.Exists() && v.Int() > 0 {
+		return time.UnixMilli(v.Int()).Add(-skew)
+	}
+
+	return time.Time{}
+}
+
+// accessKey 返回 shakehand 使用的 AccessID、签名器及签名算法，优先使用 CredentialsProvider；
+// 凭证未指定签名算法时使用 Config.SignType
+func (c *client) accessKey(ctx context.Context) (string, Signer, SignType, error) {
+	cred := c.credential()
+
+	if c.provider == nil {
+		return cred.cfg.AccessID, cred.key, cred.cfg.SignType, nil
+	}
+
+	creds, err := c.provider.Retrieve(ctx)
 
 	if err != nil {
-		return "", err
+		return "", nil, "", err
+	}
+
+	signType := creds.SignType
+
+	if len(signType) == 0 {
+		signType = cred.cfg.SignType
 	}
 
+	return creds.AccessID, creds.Signer, signType, nil
+}
+
+func (c *client) chainCall(ctx context.Context, method Method, options ...ChainCallOption) (string, error) {
 	params := make(X)
 
 	for _, f := range options {
 		f(params)
 	}
 
-	params["bizid"] = c.cfg.BizID
-	params["accessId"] = c.cfg.AccessID
-	params["method"] = method
-	params["token"] = token
+	cfg := c.credential().cfg
 
-	return c.do(ctx, c.cfg.Endpoint+CHAIN_CALL, params)
-}
+	params["bizid"] = cfg.BizID
+	params["method"] = string(method)
 
-func (c *client) chainCallForBiz(ctx context.Context, method string, options ...ChainCallOption) (string, error) {
-	token, err := c.shakehand(ctx)
+	c.applyVersion(params)
+
+	if !c.dedup {
+		return c.call(ctx, CHAIN_CALL, params, c.queryRetry)
+	}
+
+	// 查询参数相同的并发请求合并为一次(json.Marshal 对 map 的 key 排序，可直接作为 key)
+	key, err := json.Marshal(params)
 
 	if err != nil {
-		return "", err
+		return "", wrapErr(ErrDecodeFailed, err)
 	}
 
+	return c.flights.do(string(key), func() (string, error) {
+		return c.call(ctx, CHAIN_CALL, params, c.queryRetry)
+	})
+}
+
+func (c *client) chainCallForBiz(ctx context.Context, method Method, options ...ChainCallOption) (string, error) {
 	params := make(X)
 
 	for _, f := range options {
 		f(params)
 	}
 
-	params["orderId"] = uuid.New().String()
-	params["bizid"] = c.cfg.BizID
-	params["account"] = c.cfg.Account
-	params["mykmsKeyId"] = c.cfg.MyKmsKeyID
-	params["method"] = method
-	params["accessId"] = c.cfg.AccessID
-	params["tenantid"] = c.cfg.TenantID
-	params["token"] = token
+	cfg := c.credential().cfg
+
+	params["orderId"] = c.ids.NewID()
+	params["bizid"] = cfg.BizID
+	params["method"] = string(method)
+
+	// 未通过 WithAccount、WithTenant 指定时使用 Config 中的配置
+	if _, ok := params["account"]; !ok {
+		params["account"] = cfg.Account
+		params["mykmsKeyId"] = cfg.MyKmsKeyID
+	}
+
+	if _, ok := params["tenantid"]; !ok {
+		params["tenantid"] = cfg.TenantID
+	}
+
+	c.applyVersion(params)
+
+	if c.budget == nil {
+		return c.submit(ctx, params)
+	}
+
+	account, _ := params["account"].(string)
+	gas := gasParam(params)
+
+	// 预留与提交之间不会被并发提交超支
+	if err := c.budget.reserve(account, gas); err != nil {
+		return "", err
+	}
+
+	data, err := c.submit(ctx, params)
 
-	return c.do(ctx, c.cfg.Endpoint+CHAIN_CALL_FOR_BIZ, params)
+	if err != nil {
+		c.budget.refund(account, gas)
+
+		return "", err
+	}
+
+	return data, nil
+}
+
+// submit 提交交易，重试时复用同一 orderId，网关据此去重，避免重复上链
+func (c *client) submit(ctx context.Context, params X) (string, error) {
+	return c.call(ctx, CHAIN_CALL_FOR_BIZ, params, c.submitRetry)
+}
+
+// call 携带 token 发起请求，并在请求前后执行钩子
+func (c *client) call(ctx context.Context, path string, params X, rs retrySetting) (string, error) {
+	method, _ := params["method"].(string)
+
+	// 钩子收到的是隐去凭证的副本，不影响实际发送的参数
+	for _, h := range c.hooks {
+		h.Before(ctx, method, redactParams(params))
+	}
+
+	start := time.Now()
+
+	data, err := withRetry(ctx, rs, c.log, func() (string, error) {
+		token, err := c.token(ctx)
+
+		if err != nil {
+			return "", err
+		}
+
+		params["accessId"] = token.accessID
+		params["token"] = token.value
+
+		data, err := c.do(ctx, c.endpoint+path, params)
+
+		if IsTokenExpired(err) {
+			c.log.InfoContext(ctx, "token expired, renewing", "method", method)
+
+			c.tokens.invalidate()
+		}
+
+		return data, err
+	})
+
+	// 只校验查询结果；交易已提交时丢弃结果会丢失交易hash
+	if err == nil && path == CHAIN_CALL {
+		if err = c.validate(Method(method), data); err != nil {
+			data = ""
+		}
+	}
+
+	for _, h := range c.hooks {
+		h.After(ctx, method, data, err, time.Since(start))
+	}
+
+	c.record(ctx, path, params, data, err, start)
+
+	return data, err
 }
 
 func (c *client) do(ctx context.Context, reqURL string, params X) (string, error) {
-	body, err := json.Marshal(params)
+	ret, err := c.doRaw(ctx, reqURL, params)
 
 	if err != nil {
 		return "", err
 	}
 
+	if raw := rawResponseFromContext(ctx); raw != nil {
+		if raw.claim() {
+			raw.JSON = ret.Raw
+		} else {
+			c.log.WarnContext(ctx, "raw response reused across calls, keeping the first response", "method", params["method"])
+		}
+	}
+
+	if fn := responseFuncFromContext(ctx); fn != nil {
+		method, _ := params["method"].(string)
+
+		fn(method, ret.Raw)
+	}
+
+	return ret.Get("data").String(), nil
+}
+
+// doRaw 发送请求并返回完整的响应
+func (c *client) doRaw(ctx context.Context, reqURL string, params X) (gjson.Result, error) {
+	body, err := json.Marshal(params)
+
+	if err != nil {
+		return gjson.Result{}, wrapErr(ErrDecodeFailed, err)
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, bytes.NewBuffer(body))
 
 	if err != nil {
-		return "", err
+		return gjson.Result{}, wrapErr(ErrRequestFailed, err)
 	}
 
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 
+	end, err := c.life.begin()
+
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	defer end()
+
+	done := c.requestStarted()
+	defer done()
+
 	resp, err := c.cli.Do(req)
 
 	if err != nil {
@@ -164,67 +412,325 @@ func (c *client) do(ctx context.Context, reqURL string, params X) (string, error
 		default:
 		}
 
-		return "", err
+		return gjson.Result{}, wrapErr(ErrRequestFailed, err)
 	}
 
 	defer resp.Body.Close()
 
+	if skew, changed := c.skew.observe(resp.Header, c.now()); changed {
+		c.log.InfoContext(ctx, "clock skew changed", "skew", skew)
+	}
+
 	b, err := ioutil.ReadAll(resp.Body)
 
 	if err != nil {
-		return "", err
+		return gjson.Result{}, wrapErr(ErrRequestFailed, err)
+	}
+
+	ok := resp.StatusCode >= 200 && resp.StatusCode < 300
+
+	if ok && !gjson.ValidBytes(b) {
+		return gjson.Result{}, wrapErr(ErrDecodeFailed, fmt.Errorf("invalid response (status %d): %.256s", resp.StatusCode, redactSecrets(string(b))))
 	}
 
 	ret := gjson.ParseBytes(b)
 
-	if !ret.Get("success").Bool() {
-		return "", fmt.Errorf("antchain: %s | %s", ret.Get("code").String(), ret.Get("data").String())
+	if ok && ret.Get("success").Bool() {
+		return ret, nil
 	}
 
-	return ret.Get("data").String(), nil
+	apiErr := newAPIError(resp, b)
+	apiErr.kind = c.errCodes[apiErr.Code]
+
+	if apiErr.kind == ErrThrottled || resp.StatusCode == http.StatusTooManyRequests {
+		return gjson.Result{}, &ThrottleError{
+			APIError:   *apiErr,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), c.now()),
+		}
+	}
+
+	return gjson.Result{}, apiErr
+}
+
+// newAPIError 根据响应构造 APIError：业务失败使用网关返回的错误码；
+// 非 2xx 且响应不是网关的 JSON 格式时(如：负载均衡返回的 502)，错误码为 HTTP 状态码
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Header:     relevantHeader(resp.Header),
+		Body:       truncateBody(body),
+	}
+
+	ret := gjson.ParseBytes(body)
+
+	if gjson.ValidBytes(body) && (ret.Get("code").Exists() || resp.StatusCode < 300) {
+		apiErr.Code = ErrCode(ret.Get("code").String())
+		apiErr.Message = ret.Get("data").String()
+
+		return apiErr
+	}
+
+	apiErr.Code = ErrCode(strconv.Itoa(resp.StatusCode))
+	apiErr.Message = http.StatusText(resp.StatusCode)
+
+	return apiErr
 }
 
 type ClientOption func(c *client)
 
+// WithErrCodes 登记网关业务错误码对应的哨兵错误(ErrTokenExpired、ErrThrottled、ErrUnsupportedVersion)，
+// 用于 token 刷新、限流重试及 Probe 版本协商；错误码须以所用网关版本的文档为准，其余哨兵错误会被忽略，如：
+//
+//	antchain.WithErrCodes(map[antchain.ErrCode]error{"<文档中的限流错误码>": antchain.ErrThrottled})
+func WithErrCodes(codes map[ErrCode]error) ClientOption {
+	return func(c *client) {
+		if c.errCodes == nil {
+			c.errCodes = make(map[ErrCode]error, len(codes))
+		}
+
+		for code, kind := range codes {
+			if errCodeKinds[kind] {
+				c.errCodes[code] = kind
+			}
+		}
+	}
+}
+
+// WithHTTPClient 使用自定义的 http.Client，此时代理、连接池等传输层选项不再生效
 func WithHTTPClient(cli *http.Client) ClientOption {
 	return func(c *client) {
 		c.cli = cli
 	}
 }
 
-func NewClient(cfg *Config, options ...ClientOption) (Client, error) {
-	pk, err := NewPrivateKeyFromPemFile(cfg.AccessKey)
+// WithEnvironment 指定运行环境(生产/沙箱)，服务地址与环境不匹配时 NewClient 返回错误；
+// 沙箱环境须显式指定服务地址(Config.Endpoint)
+func WithEnvironment(env Environment) ClientOption {
+	return func(c *client) {
+		c.env = env
+	}
+}
 
-	if err != nil {
-		return nil, err
+// WithProxyURL 设置代理地址(如：http://127.0.0.1:8080)，不再读取环境变量中的代理配置
+func WithProxyURL(u string) ClientOption {
+	return func(c *client) {
+		c.transport.proxyURL = u
+	}
+}
+
+// WithProxyBasicAuth 设置代理的 Basic 认证信息
+func WithProxyBasicAuth(username, password string) ClientOption {
+	return func(c *client) {
+		c.transport.proxyAuth = url.UserPassword(username, password)
+	}
+}
+
+// WithDialContext 自定义建立连接的方式(如：企业隧道、SOCKS代理、Unix Socket)
+func WithDialContext(f DialContextFunc) ClientOption {
+	return func(c *client) {
+		c.transport.dial = f
 	}
+}
+
+// WithTokenKeepAlive 开启后台 token 保活，按 interval 检查并在过期前刷新 token；通过 Close 停止
+func WithTokenKeepAlive(interval time.Duration) ClientOption {
+	return func(c *client) {
+		c.keepAliveInterval = interval
+	}
+}
+
+// WithTokenTTL 设置 token 有效期及提前刷新时长，网关在 shakehand 响应中返回有效期时以网关为准；
+// 默认有效期 30 分钟，提前 2 分钟刷新
+func WithTokenTTL(ttl, refreshAhead time.Duration) ClientOption {
+	return func(c *client) {
+		c.tokenTTL = tokenSetting{
+			ttl:          ttl,
+			refreshAhead: refreshAhead,
+		}
+	}
+}
+
+// WithShakehandBudget 设置请求带有截止时间时 shakehand 可占用剩余时间的比例(0~1，默认 0.3)，
+// 其余时间留给主请求
+func WithShakehandBudget(ratio float64) ClientOption {
+	return func(c *client) {
+		c.shakehandBudget = ratio
+	}
+}
+
+// WithQueryRetry 设置查询类请求(chainCall)的失败重试次数及初始退避时长(按指数增长)；
+// 限流时按网关返回的 Retry-After 等待
+func WithQueryRetry(attempts int, backoff time.Duration) ClientOption {
+	return func(c *client) {
+		c.queryRetry = retrySetting{
+			attempts: attempts,
+			backoff:  backoff,
+		}
+	}
+}
+
+// WithSubmitRetry 设置交易提交类请求(chainCallForBiz)的失败重试次数及初始退避时长(按指数增长)；
+// 重试使用相同的 orderId 以保证幂等，默认不重试
+func WithSubmitRetry(attempts int, backoff time.Duration) ClientOption {
+	return func(c *client) {
+		c.submitRetry = retrySetting{
+			attempts: attempts,
+			backoff:  backoff,
+		}
+	}
+}
+
+// WithHook 添加请求钩子
+func WithHook(hooks ...Hook) ClientOption {
+	return func(c *client) {
+		c.hooks = append(c.hooks, hooks...)
+	}
+}
+
+// WithCache 缓存不可变数据的查询结果(交易、回执、区块)，ttl<=0 表示永不过期
+func WithCache(cache Cache, ttl time.Duration) ClientOption {
+	return func(c *client) {
+		c.cache = cache
+		c.cacheTTL = ttl
+	}
+}
 
+// WithQueryDedup 合并并发的相同查询(如：同一交易hash、同一区块)，只向网关发起一次请求；
+// 注意：合并后的请求使用首个调用方的 context
+func WithQueryDedup() ClientOption {
+	return func(c *client) {
+		c.dedup = true
+	}
+}
+
+// WithABIRegistry 使用指定的合约ABI注册表(如：多个客户端共享)
+func WithABIRegistry(r *ABIRegistry) ClientOption {
+	return func(c *client) {
+		c.abis = r
+	}
+}
+
+// WithGasBudget 提交交易前检查链账户的gas预算，提交成功后按交易的 gas 上限记录消耗
+func WithGasBudget(b *GasBudget) ClientOption {
+	return func(c *client) {
+		c.budget = b
+	}
+}
+
+// WithHotReload 按 interval 检查配置文件(JSON)及 AccessKey 文件，变更时热更新凭证；
+// configPath 为空时只监听 AccessKey 文件，callback 接收每次更新的结果(可为空)
+func WithHotReload(configPath string, interval time.Duration, callback func(err error)) ClientOption {
+	return func(c *client) {
+		c.watcher = &reloadWatcher{
+			configPath: configPath,
+			interval:   interval,
+			callback:   callback,
+		}
+	}
+}
+
+// WithCredentialsProvider 使用 CredentialsProvider 获取 shakehand 的 AccessID 及签名器，
+// 此时 Config.AccessID 及 Config.AccessKey 不再使用
+func WithCredentialsProvider(p CredentialsProvider) ClientOption {
+	return func(c *client) {
+		c.provider = p
+	}
+}
+
+// WithKeyFS 从 fsys 中读取 Config.AccessKey 指定的 PEM 文件(如：go:embed 打包的密钥、内存文件系统)，使用 CredentialsProvider 时不生效(见 NewFileProviderFS)；
+// 开启热更新时同样从 fsys 中检查 AccessKey 文件的变更
+func WithKeyFS(fsys fs.FS) ClientOption {
+	return func(c *client) {
+		c.keyFS = fsys
+	}
+}
+
+// WithRegion 使用内置区域的REST服务地址(优先于 Config.Endpoint)
+func WithRegion(r Region) ClientOption {
+	return func(c *client) {
+		c.region = r
+	}
+}
+
+func NewClient(cfg *Config, options ...ClientOption) (Client, error) {
 	c := &client{
-		cli: &http.Client{
-			Transport: &http.Transport{
-				Proxy: http.ProxyFromEnvironment,
-				DialContext: (&net.Dialer{
-					Timeout:   30 * time.Second,
-					KeepAlive: 60 * time.Second,
-				}).DialContext,
-				TLSClientConfig: &tls.Config{
-					InsecureSkipVerify: true,
-				},
-				MaxIdleConns:          0,
-				MaxIdleConnsPerHost:   1000,
-				MaxConnsPerHost:       1000,
-				IdleConnTimeout:       60 * time.Second,
-				TLSHandshakeTimeout:   10 * time.Second,
-				ExpectContinueTimeout: 1 * time.Second,
-			},
+		endpoint: cfg.Endpoint,
+		abis:     NewABIRegistry(),
+		ids:      uuidGenerator{},
+		clock:    systemClock{},
+		life:     newLifecycle(),
+		transport: transportSetting{
+			pool: defaultPoolSetting(),
 		},
-		cfg: cfg,
-		key: pk,
 	}
 
+	c.version.set(cfg.RestAPIVersion)
+
 	for _, f := range options {
 		f(c)
 	}
 
+	c.log = c.logSetting.logger()
+
+	cred := &credential{cfg: cfg}
+
+	// 使用 CredentialsProvider 时，AccessKey 在 shakehand 时按需获取
+	if c.provider == nil {
+		signer, err := c.loadSigner(cfg)
+
+		if err != nil {
+			return nil, err
+		}
+
+		cred.key = signer
+	}
+
+	c.cred.Store(cred)
+
+	if len(c.region) != 0 {
+		endpoint, err := c.region.Endpoint()
+
+		if err != nil {
+			return nil, err
+		}
+
+		c.endpoint = endpoint
+	}
+
+	if err := checkEnvironment(c.env, c.endpoint); err != nil {
+		return nil, err
+	}
+
+	if c.cli == nil {
+		c.transport.fsys = c.keyFS
+
+		tr, err := c.transport.build()
+
+		if err != nil {
+			return nil, err
+		}
+
+		tr.DialContext = c.countingDial(tr.DialContext)
+		c.pool.tracked = true
+
+		c.cli = &http.Client{Transport: tr}
+
+		if c.transport.insecure {
+			c.log.Warn("TLS certificate verification is DISABLED (WithInsecureTLS); gateway responses can be intercepted, do not use in production", "endpoint", c.endpoint)
+		}
+	}
+
+	if c.keepAliveInterval > 0 {
+		c.life.spawn("token keepalive", c.keepAlive)
+	}
+
+	if c.watcher != nil {
+		if c.watcher.interval <= 0 {
+			c.watcher.interval = defaultReloadInterval
+		}
+
+		c.life.spawn("config watcher", c.watch)
+	}
+
 	return c, nil
 }
@@ -0,0 +1,97 @@
+package antchain
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// Clock 时间源，可注入以便测试
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc 函数形式的 Clock
+type ClockFunc func() time.Time
+
+// Now 返回当前时间
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// minClockSkew 小于该值的偏差忽略(Date 头仅精确到秒)
+const minClockSkew = 2 * time.Second
+
+// clockSkew 根据网关响应的 Date 头估算的本地时钟偏差(服务端时间 - 本地时间)
+type clockSkew struct {
+	enabled bool
+	offset  int64
+}
+
+// get 返回当前的时钟偏差
+func (cs *clockSkew) get() time.Duration {
+	if !cs.enabled {
+		return 0
+	}
+
+	return time.Duration(atomic.LoadInt64(&cs.offset))
+}
+
+// observe 根据响应的 Date 头更新时钟偏差，返回新的偏差及是否发生变化
+func (cs *clockSkew) observe(header http.Header, local time.Time) (time.Duration, bool) {
+	if !cs.enabled {
+		return 0, false
+	}
+
+	server, err := http.ParseTime(header.Get("Date"))
+
+	if err != nil {
+		return 0, false
+	}
+
+	skew := server.Sub(local)
+
+	if skew > -minClockSkew && skew < minClockSkew {
+		skew = 0
+	}
+
+	old := atomic.SwapInt64(&cs.offset, int64(skew))
+
+	return skew, old != int64(skew)
+}
+
+// now 返回本地时钟时间
+func (c *client) now() time.Time {
+	return c.clock.Now()
+}
+
+// serverNow 返回按时钟偏差修正后的时间，用于 shakehand 签名的时间戳
+func (c *client) serverNow() time.Time {
+	return c.clock.Now().Add(c.skew.get())
+}
+
+// WithClock 设置时间源(默认为系统时间)，shakehand 签名的时间戳及 token 的过期、刷新均以此为准，
+// 主要用于测试，如：WithClock(ClockFunc(func() time.Time { return fixed }))；clock 为 nil 时使用系统时间
+func WithClock(clock Clock) ClientOption {
+	return func(c *client) {
+		if clock == nil {
+			clock = systemClock{}
+		}
+
+		c.clock = clock
+	}
+}
+
+// WithClockSkewCompensation 开启时钟偏差补偿：根据网关响应的 Date 头估算本地时钟偏差，
+// 并修正 shakehand 签名中的时间戳，避免本地时钟漂移导致鉴权失败
+func WithClockSkewCompensation() ClientOption {
+	return func(c *client) {
+		c.skew.enabled = true
+	}
+}
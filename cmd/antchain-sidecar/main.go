@@ -0,0 +1,115 @@
+// antchain-sidecar 持有蚂蚁链凭证并对外提供本地 REST 接口(见 sidecar 包)，业务容器无需接触 AccessKey
+//
+//	antchain-sidecar -config /etc/antchain/config.json -listen 127.0.0.1:8080
+//	antchain-sidecar -config /etc/antchain/config.json -unix /var/run/antchain.sock
+//
+// 访问令牌通过环境变量 ANTCHAIN_SIDECAR_TOKENS 配置(多个以逗号分隔，忽略空令牌)，TCP 监听时必须至少配置一个
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/shenghui0779/antchain"
+	"github.com/shenghui0779/antchain/sidecar"
+)
+
+// envTokens 访问令牌的环境变量
+const envTokens = "ANTCHAIN_SIDECAR_TOKENS"
+
+func main() {
+	configPath := flag.String("config", "config.json", "蚂蚁链配置文件(JSON)，变更后自动热加载")
+	listen := flag.String("listen", "127.0.0.1:8080", "TCP 监听地址")
+	unixSocket := flag.String("unix", "", "Unix Socket 路径，指定后不监听 TCP")
+	flag.Parse()
+
+	cfg, err := antchain.LoadConfig(*configPath)
+
+	if err != nil {
+		log.Fatalf("load config: %v", err)
+	}
+
+	cli, err := antchain.NewClient(cfg,
+		antchain.WithTokenKeepAlive(time.Minute),
+		antchain.WithHotReload(*configPath, 0, func(err error) {
+			if err != nil {
+				log.Printf("reload config: %v", err)
+			}
+		}),
+	)
+
+	if err != nil {
+		log.Fatalf("new client: %v", err)
+	}
+
+	tokens := parseTokens(os.Getenv(envTokens))
+
+	var lis net.Listener
+
+	if len(*unixSocket) != 0 {
+		os.Remove(*unixSocket)
+
+		lis, err = net.Listen("unix", *unixSocket)
+	} else {
+		// 令牌均为空(如：","、" ")时 Handler 不做鉴权，TCP 监听必须至少有一个有效令牌
+		if len(tokens) == 0 {
+			log.Fatalf("%s is required when listening on tcp", envTokens)
+		}
+
+		lis, err = net.Listen("tcp", *listen)
+	}
+
+	if err != nil {
+		log.Fatalf("listen: %v", err)
+	}
+
+	srv := &http.Server{
+		Handler:           sidecar.NewHandler(cli, tokens...),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		if err := srv.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("serve: %v", err)
+		}
+	}()
+
+	log.Printf("antchain sidecar listening on %s", lis.Addr())
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Printf("shutdown: %v", err)
+	}
+
+	if err := cli.Close(ctx); err != nil {
+		log.Printf("close client: %v", err)
+	}
+}
+
+// parseTokens 解析逗号分隔的访问令牌，去除首尾空白并忽略空令牌
+func parseTokens(s string) []string {
+	tokens := make([]string, 0)
+
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); len(v) != 0 {
+			tokens = append(tokens, v)
+		}
+	}
+
+	return tokens
+}
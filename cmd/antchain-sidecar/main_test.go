@@ -0,0 +1,25 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTokens(t *testing.T) {
+	cases := []struct {
+		in   string
+		want []string
+	}{
+		{"", []string{}},
+		{",", []string{}},
+		{" , ,", []string{}},
+		{"a", []string{"a"}},
+		{"a, b ,,c", []string{"a", "b", "c"}},
+	}
+
+	for _, c := range cases {
+		if got := parseTokens(c.in); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("parseTokens(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Package codec 提供 hex、base64 与链上 Identity 之间一致的转换方法，antchain 及各子包的编码转换均基于此实现
+package codec
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// IdentityLength 链上 Identity 的字节长度
+const IdentityLength = 32
+
+// Has0x 判断字符串是否带有 0x(或 0X) 前缀
+func Has0x(s string) bool {
+	return len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X')
+}
+
+// Trim0x 去除 0x(或 0X) 前缀
+func Trim0x(s string) string {
+	if Has0x(s) {
+		return s[2:]
+	}
+
+	return s
+}
+
+// Add0x 添加 0x 前缀(已有前缀则不重复添加)
+func Add0x(s string) string {
+	if Has0x(s) {
+		return s
+	}
+
+	return "0x" + s
+}
+
+// HexToBytes 解码 hex 字符串，允许带 0x 前缀，不区分大小写
+func HexToBytes(s string) ([]byte, error) {
+	b, err := hex.DecodeString(Trim0x(s))
+
+	if err != nil {
+		return nil, fmt.Errorf("codec: invalid hex: %w", err)
+	}
+
+	return b, nil
+}
+
+// BytesToHex 编码为小写 hex 字符串(不带 0x 前缀)
+func BytesToHex(b []byte) string {
+	return hex.EncodeToString(b)
+}
+
+// BytesToHex0x 编码为带 0x 前缀的小写 hex 字符串
+func BytesToHex0x(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+// Base64ToBytes 解码标准 base64 字符串
+func Base64ToBytes(s string) ([]byte, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+
+	if err != nil {
+		return nil, fmt.Errorf("codec: invalid base64: %w", err)
+	}
+
+	return b, nil
+}
+
+// BytesToBase64 编码为标准 base64 字符串
+func BytesToBase64(b []byte) string {
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// HexToBase64 hex(允许带 0x 前缀) 转 base64
+func HexToBase64(s string) (string, error) {
+	b, err := HexToBytes(s)
+
+	if err != nil {
+		return "", err
+	}
+
+	return BytesToBase64(b), nil
+}
+
+// Base64ToHex base64 转 hex(不带 0x 前缀)
+func Base64ToHex(s string) (string, error) {
+	b, err := Base64ToBytes(s)
+
+	if err != nil {
+		return "", err
+	}
+
+	return BytesToHex(b), nil
+}
+
+// IdentityToHex 链上 Identity(base64) 转 hex(不带 0x 前缀)，校验长度
+func IdentityToHex(identity string) (string, error) {
+	b, err := Base64ToBytes(identity)
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(b) != IdentityLength {
+		return "", fmt.Errorf("codec: invalid identity length %d, expected %d", len(b), IdentityLength)
+	}
+
+	return BytesToHex(b), nil
+}
+
+// IdentityFromHex hex(允许带 0x 前缀) 转链上 Identity(base64)，校验长度
+func IdentityFromHex(s string) (string, error) {
+	b, err := HexToBytes(s)
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(b) != IdentityLength {
+		return "", fmt.Errorf("codec: invalid identity length %d, expected %d", len(b), IdentityLength)
+	}
+
+	return BytesToBase64(b), nil
+}
+
+// EqualHex 比较两个 hex 字符串是否表示相同的字节(忽略 0x 前缀及大小写)
+func EqualHex(a, b string) bool {
+	return strings.EqualFold(Trim0x(a), Trim0x(b))
+}
+
+// RawJSON 返回可直接嵌入 JSON 文档的原始 JSON，data 为空或不是合法 JSON 时返回 null
+func RawJSON(data string) string {
+	if len(data) == 0 || !json.Valid([]byte(data)) {
+		return "null"
+	}
+
+	return data
+}
@@ -0,0 +1,50 @@
+package codec
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTrim0x(t *testing.T) {
+	for in, want := range map[string]string{"0xab": "ab", "0Xab": "ab", "ab": "ab", "0x": "", "": ""} {
+		if got := Trim0x(in); got != want {
+			t.Errorf("Trim0x(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestIdentityRoundTrip(t *testing.T) {
+	raw := bytes.Repeat([]byte{0xab}, IdentityLength)
+
+	identity, err := IdentityFromHex("0x" + BytesToHex(raw))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if identity != BytesToBase64(raw) {
+		t.Fatalf("identity = %q", identity)
+	}
+
+	h, err := IdentityToHex(identity)
+
+	if err != nil || h != BytesToHex(raw) {
+		t.Fatalf("hex = %q, err = %v", h, err)
+	}
+
+	if _, err := IdentityFromHex("abcd"); err == nil {
+		t.Fatal("short identity accepted")
+	}
+
+	if _, err := IdentityToHex(BytesToBase64(raw[:8])); err == nil {
+		t.Fatal("short identity accepted")
+	}
+}
+
+func TestRawJSON(t *testing.T) {
+	for in, want := range map[string]string{"": "null", "{": "null", `{"a":1}`: `{"a":1}`, "[]": "[]"} {
+		if got := RawJSON(in); got != want {
+			t.Errorf("RawJSON(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+// Package copyright 版权/知识产权登记的存证模板：定义带版本的存证格式及其规范化序列化，
+// 使不同的版权保护平台可以互相解析、核验彼此的存证
+package copyright
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/shenghui0779/antchain"
+)
+
+// SchemaV1 版权登记存证格式 v1
+const SchemaV1 = "antchain.copyright.v1"
+
+var (
+	// ErrUnknownSchema 不支持的存证格式
+	ErrUnknownSchema = errors.New("copyright: unknown schema")
+	// ErrInvalidWork 登记信息不完整或格式错误
+	ErrInvalidWork = errors.New("copyright: invalid work")
+	// ErrMismatch 链上存证或作品内容与登记信息不一致
+	ErrMismatch = errors.New("copyright: mismatch")
+)
+
+// Author 作者(著作权人)
+type Author struct {
+	Name     string `json:"name"`
+	Identity string `json:"identity"` // 身份标识(如：证件号摘要、链账户Identity)
+	Role     string `json:"role,omitempty"`
+}
+
+// Work 作品登记信息(v1)
+type Work struct {
+	Schema        string            `json:"schema"`
+	Title         string            `json:"title"`
+	WorkType      string            `json:"workType"` // 作品类型(如：文字、美术、摄影、音乐、视听、软件)
+	Authors       []Author          `json:"authors"`
+	HashAlgorithm string            `json:"hashAlgorithm"` // 作品内容的摘要算法(SHA256、SM3)
+	ContentHash   string            `json:"contentHash"`   // 作品内容摘要(hex，小写)
+	ContentSize   int64             `json:"contentSize,omitempty"`
+	CreatedAt     string            `json:"createdAt"` // 创作完成时间(RFC 3339)
+	Extra         map[string]string `json:"extra,omitempty"`
+}
+
+// NewWork 返回 v1 格式的作品登记信息，内容摘要取自 digest(见 antchain.HashFile)
+func NewWork(title, workType string, digest *antchain.Digest, createdAt time.Time, authors ...Author) *Work {
+	return &Work{
+		Schema:        SchemaV1,
+		Title:         title,
+		WorkType:      workType,
+		Authors:       authors,
+		HashAlgorithm: string(digest.Algorithm),
+		ContentHash:   digest.Hex(),
+		ContentSize:   digest.Size,
+		CreatedAt:     createdAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// Validate 校验登记信息是否完整
+func (w *Work) Validate() error {
+	if w.Schema != SchemaV1 {
+		return fmt.Errorf("%w: %q", ErrUnknownSchema, w.Schema)
+	}
+
+	if len(strings.TrimSpace(w.Title)) == 0 || len(strings.TrimSpace(w.WorkType)) == 0 {
+		return fmt.Errorf("%w: title and workType are required", ErrInvalidWork)
+	}
+
+	if len(w.Authors) == 0 {
+		return fmt.Errorf("%w: at least one author is required", ErrInvalidWork)
+	}
+
+	for i, v := range w.Authors {
+		if len(v.Name) == 0 || len(v.Identity) == 0 {
+			return fmt.Errorf("%w: author %d requires name and identity", ErrInvalidWork, i)
+		}
+	}
+
+	h, err := antchain.HashAlgorithm(w.HashAlgorithm).New()
+
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidWork, err)
+	}
+
+	b, err := hex.DecodeString(w.ContentHash)
+
+	if err != nil || len(b) != h.Size() || w.ContentHash != strings.ToLower(w.ContentHash) {
+		return fmt.Errorf("%w: contentHash must be %d bytes lowercase hex", ErrInvalidWork, h.Size())
+	}
+
+	if _, err := time.Parse(time.RFC3339, w.CreatedAt); err != nil {
+		return fmt.Errorf("%w: createdAt: %v", ErrInvalidWork, err)
+	}
+
+	return nil
+}
+
+// Canonical 返回按 RFC 8785(JSON Canonicalization Scheme)规范化的序列化结果，
+// 相同的登记信息在任何平台上序列化结果一致，其它平台可使用任意 JCS 实现复现
+func (w *Work) Canonical() ([]byte, error) {
+	b, err := json.Marshal(w)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return canonicalize(b)
+}
+
+// Parse 解析链上的登记存证，按 schema 选择对应版本的格式
+func Parse(data string) (*Work, error) {
+	var head struct {
+		Schema string `json:"schema"`
+	}
+
+	if err := json.Unmarshal([]byte(data), &head); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWork, err)
+	}
+
+	switch head.Schema {
+	case SchemaV1:
+		w := new(Work)
+
+		dec := json.NewDecoder(strings.NewReader(data))
+		dec.DisallowUnknownFields()
+
+		if err := dec.Decode(w); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidWork, err)
+		}
+
+		return w, nil
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrUnknownSchema, head.Schema)
+}
+
+// Submit 校验并以规范化格式存证作品登记信息，返回交易hash
+func Submit(ctx context.Context, cli antchain.DepositService, w *Work, gas int, options ...antchain.ChainCallOption) (string, error) {
+	if err := w.Validate(); err != nil {
+		return "", err
+	}
+
+	b, err := w.Canonical()
+
+	if err != nil {
+		return "", err
+	}
+
+	return cli.Deposit(ctx, string(b), gas, options...)
+}
+
+// Verify 核验登记存证：链上内容须为 w 的规范化序列化结果；content 不为空时同时校验作品内容的摘要
+func Verify(ctx context.Context, cli antchain.DepositService, txHash string, w *Work, content io.Reader) error {
+	b, err := w.Canonical()
+
+	if err != nil {
+		return err
+	}
+
+	if err = cli.VerifyDeposit(ctx, txHash, string(b)); err != nil {
+		if errors.Is(err, antchain.ErrDepositMismatch) {
+			return fmt.Errorf("%w: deposit %s differs from work", ErrMismatch, txHash)
+		}
+
+		return err
+	}
+
+	if content == nil {
+		return nil
+	}
+
+	digest, err := antchain.HashReader(antchain.HashAlgorithm(w.HashAlgorithm), content)
+
+	if err != nil {
+		return err
+	}
+
+	if digest.Hex() != strings.ToLower(w.ContentHash) {
+		return fmt.Errorf("%w: content hash %s, registered %s", ErrMismatch, digest.Hex(), w.ContentHash)
+	}
+
+	return nil
+}
+
+// Fetch 读取并解析链上的登记存证
+func Fetch(ctx context.Context, cli antchain.DepositService, txHash string) (*Work, error) {
+	data, err := cli.GetDepositContent(ctx, txHash)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(data)
+}
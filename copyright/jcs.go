@@ -0,0 +1,180 @@
+package copyright
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// canonicalize 按 RFC 8785(JSON Canonicalization Scheme)规范化 JSON：
+// 对象的键按 UTF-16 码元排序，数字按 ECMAScript 规则序列化，字符串只转义必须转义的字符，无空白
+func canonicalize(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v interface{}
+
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	buf := new(bytes.Buffer)
+
+	if err := writeCanonical(buf, v); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeCanonical(buf *bytes.Buffer, v interface{}) error {
+	switch x := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(x))
+	case json.Number:
+		s, err := formatNumber(x)
+
+		if err != nil {
+			return err
+		}
+
+		buf.WriteString(s)
+	case string:
+		return writeString(buf, x)
+	case []interface{}:
+		buf.WriteByte('[')
+
+		for i, item := range x {
+			if i != 0 {
+				buf.WriteByte(',')
+			}
+
+			if err := writeCanonical(buf, item); err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte(']')
+	case map[string]interface{}:
+		keys := make([]string, 0, len(x))
+
+		for k := range x {
+			keys = append(keys, k)
+		}
+
+		sort.Slice(keys, func(i, j int) bool {
+			return lessUTF16(keys[i], keys[j])
+		})
+
+		buf.WriteByte('{')
+
+		for i, k := range keys {
+			if i != 0 {
+				buf.WriteByte(',')
+			}
+
+			if err := writeString(buf, k); err != nil {
+				return err
+			}
+
+			buf.WriteByte(':')
+
+			if err := writeCanonical(buf, x[k]); err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("copyright: unsupported json value %T", v)
+	}
+
+	return nil
+}
+
+// lessUTF16 按 UTF-16 码元比较(RFC 8785 3.2.3)，与按 UTF-8 字节比较在辅助平面字符上结果不同
+func lessUTF16(a, b string) bool {
+	ua, ub := utf16.Encode([]rune(a)), utf16.Encode([]rune(b))
+
+	for i := 0; i < len(ua) && i < len(ub); i++ {
+		if ua[i] != ub[i] {
+			return ua[i] < ub[i]
+		}
+	}
+
+	return len(ua) < len(ub)
+}
+
+// writeString 只转义引号、反斜杠及控制字符(RFC 8785 3.2.2.2)，其余字符原样输出
+func writeString(buf *bytes.Buffer, s string) error {
+	if !utf8.ValidString(s) {
+		return fmt.Errorf("copyright: invalid utf-8 string %q", s)
+	}
+
+	buf.WriteByte('"')
+
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(buf, `\u%04x`, r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+
+	buf.WriteByte('"')
+
+	return nil
+}
+
+// formatNumber 按 ECMAScript Number.prototype.toString 序列化 IEEE 754 双精度数(RFC 8785 3.2.2.3)
+func formatNumber(n json.Number) (string, error) {
+	f, err := strconv.ParseFloat(string(n), 64)
+
+	if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+		return "", fmt.Errorf("copyright: invalid number %s", n)
+	}
+
+	if f == 0 {
+		return "0", nil
+	}
+
+	if abs := math.Abs(f); abs >= 1e-6 && abs < 1e21 {
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	}
+
+	// Go 的指数至少两位(1e-07)，ECMAScript 不补零(1e-7)
+	s := strconv.FormatFloat(f, 'e', -1, 64)
+
+	idx := strings.IndexByte(s, 'e')
+	mantissa, exp := s[:idx], s[idx+1:]
+
+	sign := exp[:1]
+	exp = strings.TrimLeft(exp[1:], "0")
+
+	return mantissa + "e" + sign + exp, nil
+}
@@ -0,0 +1,32 @@
+package copyright
+
+import "testing"
+
+// RFC 8785 中的示例
+func TestCanonicalizeRFC8785(t *testing.T) {
+	cases := []struct {
+		in, want string
+	}{
+		{
+			`{"numbers":[333333333.33333329,1E30,4.50,2e-3,0.000000000000000000000000001],"string":"\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/","literals":[null,true,false]}`,
+			`{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`,
+		},
+		{
+			`{"\u20ac":"Euro Sign","\r":"Carriage Return","\ufb33":"Hebrew Letter Dalet With Dagesh","1":"One","\ud83d\ude00":"Emoji: Grinning Face","\u0080":"Control","\u00f6":"Latin Small Letter O With Diaeresis"}`,
+			"{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"ö\":\"Latin Small Letter O With Diaeresis\",\"€\":\"Euro Sign\",\"😀\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}",
+		},
+		{`[-0,1e21,1e-7,123e-8,9007199254740993,"<\u2028>","\b\u001f"]`, "[0,1e+21,1e-7,0.00000123,9007199254740992,\"<\u2028>\",\"\\b\\u001f\"]"},
+	}
+
+	for _, c := range cases {
+		got, err := canonicalize([]byte(c.in))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(got) != c.want {
+			t.Errorf("canonicalize(%s)\n got %s\nwant %s", c.in, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package antchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// EnvAccessID 环境变量：AccessID
+	EnvAccessID = "ANTCHAIN_ACCESS_ID"
+	// EnvAccessKey 环境变量：AccessKey 私钥(PEM内容)
+	EnvAccessKey = "ANTCHAIN_ACCESS_KEY"
+	// EnvAccessKeyFile 环境变量：AccessKey 私钥文件路径
+	EnvAccessKeyFile = "ANTCHAIN_ACCESS_KEY_FILE"
+)
+
+// ErrNoCredentials 未能获取到访问凭证
+var ErrNoCredentials = errors.New("antchain: no credentials")
+
+// Credentials shakehand 使用的访问凭证
+type Credentials struct {
+	AccessID string
+	Signer   Signer
+	SignType SignType  // 签名算法，为空时使用 Config.SignType
+	Expiry   time.Time // 凭证过期时间，零值表示不过期
+}
+
+// CredentialsProvider 访问凭证提供者，在 shakehand 时按需获取凭证
+type CredentialsProvider interface {
+	Retrieve(ctx context.Context) (*Credentials, error)
+}
+
+// CredentialsProviderFunc 函数形式的 CredentialsProvider
+type CredentialsProviderFunc func(ctx context.Context) (*Credentials, error)
+
+func (f CredentialsProviderFunc) Retrieve(ctx context.Context) (*Credentials, error) {
+	return f(ctx)
+}
+
+// NewStaticProvider 返回固定凭证的 CredentialsProvider
+func NewStaticProvider(accessID string, signer Signer) CredentialsProvider {
+	creds := &Credentials{
+		AccessID: accessID,
+		Signer:   signer,
+	}
+
+	return CredentialsProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		return creds, nil
+	})
+}
+
+// NewEnvProvider 返回从环境变量获取凭证的 CredentialsProvider：
+// AccessID 取自 ANTCHAIN_ACCESS_ID，私钥优先取 ANTCHAIN_ACCESS_KEY(PEM内容)，其次为 ANTCHAIN_ACCESS_KEY_FILE
+func NewEnvProvider() CredentialsProvider {
+	return CredentialsProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		accessID := os.Getenv(EnvAccessID)
+
+		if len(accessID) == 0 {
+			return nil, fmt.Errorf("%w: env %s is empty", ErrNoCredentials, EnvAccessID)
+		}
+
+		var (
+			pk  *PrivateKey
+			err error
+		)
+
+		if v := os.Getenv(EnvAccessKey); len(v) != 0 {
+			pk, err = NewPrivateKeyFromPem([]byte(strings.ReplaceAll(v, `\n`, "\n")))
+		} else if v := os.Getenv(EnvAccessKeyFile); len(v) != 0 {
+			pk, err = NewPrivateKeyFromPemFile(v)
+		} else {
+			return nil, fmt.Errorf("%w: env %s and %s are empty", ErrNoCredentials, EnvAccessKey, EnvAccessKeyFile)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &Credentials{
+			AccessID: accessID,
+			Signer:   pk,
+		}, nil
+	})
+}
+
+// NewFileProvider 返回从配置文件(JSON，同 Config)获取凭证的 CredentialsProvider，按配置的 SignType 读取 AccessKey
+func NewFileProvider(configPath string) CredentialsProvider {
+	return CredentialsProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		cfg, err := LoadConfig(configPath)
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrNoCredentials, err)
+		}
+
+		signer, err := LoadSigner(cfg.SignType, cfg.AccessKey)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &Credentials{
+			AccessID: cfg.AccessID,
+			Signer:   signer,
+			SignType: cfg.SignType,
+		}, nil
+	})
+}
+
+// NewFileProviderFS 同 NewFileProvider，配置文件及 AccessKey 均从 fsys 中读取(如：go:embed 打包的配置)；
+// 使用 CredentialsProvider 时 WithKeyFS 不再生效，需要从 fsys 读取密钥时使用此方法
+func NewFileProviderFS(fsys fs.FS, configPath string) CredentialsProvider {
+	return CredentialsProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		cfg, err := LoadConfigFS(fsys, configPath)
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrNoCredentials, err)
+		}
+
+		signer, err := LoadSignerFS(cfg.SignType, fsys, cfg.AccessKey)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &Credentials{
+			AccessID: cfg.AccessID,
+			Signer:   signer,
+			SignType: cfg.SignType,
+		}, nil
+	})
+}
+
+// NewChainProvider 依次尝试 providers，返回第一个成功获取的凭证；均失败时返回 ErrNoCredentials 及各自的错误(errors.Join)
+func NewChainProvider(providers ...CredentialsProvider) CredentialsProvider {
+	return CredentialsProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		errs := make([]error, 0, len(providers)+1)
+		errs = append(errs, ErrNoCredentials)
+
+		for _, p := range providers {
+			creds, err := p.Retrieve(ctx)
+
+			if err == nil {
+				return creds, nil
+			}
+
+			errs = append(errs, err)
+		}
+
+		return nil, errors.Join(errs...)
+	})
+}
+
+// cachedProvider 缓存凭证直到过期
+type cachedProvider struct {
+	provider CredentialsProvider
+	ttl      time.Duration
+
+	mutex    sync.Mutex
+	creds    *Credentials
+	expireAt time.Time
+}
+
+// NewCachedProvider 缓存 provider 获取的凭证，在凭证的 Expiry 或 ttl(取较早者)到达后重新获取；ttl<=0 表示只以 Expiry 为准
+func NewCachedProvider(provider CredentialsProvider, ttl time.Duration) CredentialsProvider {
+	return &cachedProvider{
+		provider: provider,
+		ttl:      ttl,
+	}
+}
+
+func (p *cachedProvider) Retrieve(ctx context.Context) (*Credentials, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	now := time.Now()
+
+	if p.creds != nil && (p.expireAt.IsZero() || now.Before(p.expireAt)) {
+		return p.creds, nil
+	}
+
+	creds, err := p.provider.Retrieve(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	expireAt := creds.Expiry
+
+	if p.ttl > 0 && (expireAt.IsZero() || now.Add(p.ttl).Before(expireAt)) {
+		expireAt = now.Add(p.ttl)
+	}
+
+	p.creds = creds
+	p.expireAt = expireAt
+
+	return creds, nil
+}
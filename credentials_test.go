@@ -0,0 +1,136 @@
+package antchain
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestFileProviderSignType(t *testing.T) {
+	keyFile := testKeyFile(t)
+
+	cases := []struct {
+		signType SignType
+		err      error
+	}{
+		{"", nil},
+		{SignSHA256WithRSA, nil},
+		{SignSHA1WithRSA, nil},
+		{SignSM3WithSM2, ErrInvalidKey},
+		{"MD5WithRSA", ErrInvalidKey},
+	}
+
+	for _, c := range cases {
+		t.Run(string(c.signType), func(t *testing.T) {
+			conf := fmt.Sprintf(`{"access_id":"access","access_key":%q,"sign_type":%q}`, keyFile, c.signType)
+			path := filepath.Join(t.TempDir(), "config.json")
+
+			if err := os.WriteFile(path, []byte(conf), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			creds, err := NewFileProvider(path).Retrieve(context.Background())
+
+			if c.err != nil {
+				if !errors.Is(err, c.err) {
+					t.Fatalf("err = %v, want %v", err, c.err)
+				}
+
+				return
+			}
+
+			if err != nil || creds.AccessID != "access" || creds.SignType != c.signType {
+				t.Fatalf("creds = %+v, err = %v", creds, err)
+			}
+		})
+	}
+}
+
+func TestFileProviderFS(t *testing.T) {
+	pem, err := os.ReadFile(testKeyFile(t))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fsys := fstest.MapFS{
+		"conf/config.json": {Data: []byte(`{"access_id":"access","access_key":"conf/key.pem","sign_type":"SHA1WithRSA"}`)},
+		"conf/key.pem":     {Data: pem},
+	}
+
+	creds, err := NewFileProviderFS(fsys, "conf/config.json").Retrieve(context.Background())
+
+	if err != nil || creds.AccessID != "access" || creds.SignType != SignSHA1WithRSA {
+		t.Fatalf("creds = %+v, err = %v", creds, err)
+	}
+
+	if _, err = NewFileProviderFS(fsys, "missing.json").Retrieve(context.Background()); !errors.Is(err, ErrNoCredentials) {
+		t.Fatalf("err = %v, want ErrNoCredentials", err)
+	}
+}
+
+func TestChainProviderJoinsErrors(t *testing.T) {
+	errA := errors.New("provider a")
+	errB := wrapErr(ErrInvalidKey, errors.New("provider b"))
+
+	failing := func(err error) CredentialsProvider {
+		return CredentialsProviderFunc(func(ctx context.Context) (*Credentials, error) {
+			return nil, err
+		})
+	}
+
+	_, err := NewChainProvider(failing(errA), failing(errB)).Retrieve(context.Background())
+
+	for _, target := range []error{ErrNoCredentials, errA, ErrInvalidKey} {
+		if !errors.Is(err, target) {
+			t.Errorf("errors.Is(%v, %v) = false", err, target)
+		}
+	}
+
+	creds, err := NewChainProvider(failing(errA), NewStaticProvider("access", nil)).Retrieve(context.Background())
+
+	if err != nil || creds.AccessID != "access" {
+		t.Fatalf("creds = %+v, err = %v", creds, err)
+	}
+}
+
+func TestShakehandUsesCredentialsSignType(t *testing.T) {
+	gw := newTestGateway(t, func(params X) (interface{}, bool) { return "", true })
+
+	cases := []struct {
+		signType SignType
+		want     crypto.Hash
+	}{
+		{"", crypto.SHA256},
+		{SignSHA1WithRSA, crypto.SHA1},
+	}
+
+	for _, c := range cases {
+		var got crypto.Hash
+
+		signer := SignerFunc(func(hash crypto.Hash, data []byte) ([]byte, error) {
+			got = hash
+
+			return []byte("sign"), nil
+		})
+
+		provider := CredentialsProviderFunc(func(ctx context.Context) (*Credentials, error) {
+			return &Credentials{AccessID: "access", Signer: signer, SignType: c.signType}, nil
+		})
+
+		cli := newTestClient(t, gw, WithCredentialsProvider(provider))
+
+		if _, err := cli.shakehand(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		if got != c.want {
+			t.Errorf("sign type %q: hash = %v, want %v", c.signType, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package antchain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shenghui0779/antchain/codec"
+	"github.com/tidwall/gjson"
+)
+
+// TxTypeDeposit 存证交易的类型
+const TxTypeDeposit = "TX_DEPOSIT_DATA"
+
+// ErrDepositMismatch 链上存证内容与预期内容不一致
+var ErrDepositMismatch = errors.New("antchain: deposit content mismatch")
+
+// depositContent 从交易查询结果中解析存证内容(transactionDO.data，base64编码)
+func depositContent(data string) ([]byte, error) {
+	ret := gjson.Parse(data)
+
+	tx := ret.Get("transactionDO")
+
+	if !tx.Exists() {
+		tx = ret
+	}
+
+	if txType := tx.Get("txType").String(); len(txType) != 0 && txType != TxTypeDeposit {
+		return nil, fmt.Errorf("antchain: transaction is not a deposit (%s)", txType)
+	}
+
+	payload := tx.Get("data")
+
+	if !payload.Exists() {
+		return nil, wrapErr(ErrDecodeFailed, errors.New("transaction data not found"))
+	}
+
+	b, err := base64.StdEncoding.DecodeString(payload.String())
+
+	if err != nil {
+		return nil, wrapErr(ErrDecodeFailed, err)
+	}
+
+	return b, nil
+}
+
+// GetDepositContent 查询交易并返回链上的存证内容
+func (c *client) GetDepositContent(ctx context.Context, txHash string) (string, error) {
+	data, err := c.QueryTransaction(ctx, txHash)
+
+	if err != nil {
+		return "", err
+	}
+
+	content, err := depositContent(data)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
+
+// VerifyDeposit 查询交易回执及交易并校验链上存证内容与 expectedContent 是否一致；
+// 交易执行失败(回执 result 非 0)返回 ErrTxFailed；
+// expectedContent 可以是原始内容，也可以是内容的 SHA-256 摘要(hex，可带0x前缀)；不一致返回 ErrDepositMismatch
+func (c *client) VerifyDeposit(ctx context.Context, txHash, expectedContent string) error {
+	if err := c.checkReceipt(ctx, txHash); err != nil {
+		return err
+	}
+
+	content, err := c.GetDepositContent(ctx, txHash)
+
+	if err != nil {
+		return err
+	}
+
+	if content == expectedContent {
+		return nil
+	}
+
+	h := sha256.Sum256([]byte(content))
+
+	if strings.EqualFold(hex.EncodeToString(h[:]), codec.Trim0x(expectedContent)) {
+		return nil
+	}
+
+	return ErrDepositMismatch
+}
+
+// checkReceipt 查询交易回执，交易尚无回执或执行失败时返回错误
+func (c *client) checkReceipt(ctx context.Context, txHash string) error {
+	receipt, err := c.QueryReceipt(ctx, txHash)
+
+	if err != nil {
+		return err
+	}
+
+	if len(receipt) == 0 {
+		return fmt.Errorf("antchain: receipt of %s not found", txHash)
+	}
+
+	return receiptError(txHash, receipt)
+}
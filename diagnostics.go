@@ -0,0 +1,175 @@
+package antchain
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Diagnostics 客户端的运行状态，可用于运维管理接口
+type Diagnostics struct {
+	Endpoint   string           `json:"endpoint"`
+	Pool       PoolDiagnostics  `json:"pool"`
+	Resolution DNSDiagnostics   `json:"resolution"`
+	Shakehand  *ShakehandStatus `json:"shakehand,omitempty"` // 尚未 shakehand 时为空
+	Token      TokenDiagnostics `json:"token"`
+}
+
+// PoolDiagnostics 连接池状态
+type PoolDiagnostics struct {
+	Tracked             bool  `json:"tracked"` // 是否统计连接数(使用默认 http.Client 时)
+	Open                int64 `json:"open"`
+	Idle                int64 `json:"idle"` // 按 已建立连接数-进行中请求数 估算
+	InFlight            int64 `json:"in_flight"`
+	MaxConnsPerHost     int   `json:"max_conns_per_host"`
+	MaxIdleConnsPerHost int   `json:"max_idle_conns_per_host"`
+}
+
+// DNSDiagnostics 服务地址的域名解析结果
+type DNSDiagnostics struct {
+	Host     string        `json:"host"`
+	Addrs    []string      `json:"addrs,omitempty"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// ShakehandStatus 最近一次 shakehand 的结果
+type ShakehandStatus struct {
+	At       time.Time     `json:"at"`
+	Duration time.Duration `json:"duration"`
+	AccessID string        `json:"access_id,omitempty"` // 仅保留前4个字符
+	Error    string        `json:"error,omitempty"`
+}
+
+// TokenDiagnostics 当前缓存的 token 状态
+type TokenDiagnostics struct {
+	Cached    bool      `json:"cached"`
+	RefreshAt time.Time `json:"refresh_at,omitempty"`
+	ExpireAt  time.Time `json:"expire_at,omitempty"`
+}
+
+// shakehandRecord 记录最近一次 shakehand 的结果
+type shakehandRecord struct {
+	mutex  sync.Mutex
+	status *ShakehandStatus
+}
+
+func (r *shakehandRecord) set(status *ShakehandStatus) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.status = status
+}
+
+func (r *shakehandRecord) get() *ShakehandStatus {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.status == nil {
+		return nil
+	}
+
+	status := *r.status
+
+	return &status
+}
+
+func (c *client) recordShakehand(start time.Time, token *accessToken, err error) {
+	status := &ShakehandStatus{
+		At:       start,
+		Duration: c.now().Sub(start),
+	}
+
+	if token != nil {
+		status.AccessID = maskSecret(token.accessID)
+	}
+
+	if err != nil {
+		status.Error = err.Error()
+	}
+
+	c.lastShakehand.set(status)
+}
+
+func (c *client) Diagnostics(ctx context.Context) *Diagnostics {
+	d := &Diagnostics{
+		Endpoint:   c.endpoint,
+		Pool:       c.poolDiagnostics(),
+		Resolution: c.resolve(ctx),
+		Shakehand:  c.lastShakehand.get(),
+	}
+
+	c.tokens.mutex.Lock()
+	d.Token = TokenDiagnostics{
+		Cached:    c.tokens.token != nil,
+		RefreshAt: c.tokens.refreshAt,
+		ExpireAt:  c.tokens.expireAt,
+	}
+	c.tokens.mutex.Unlock()
+
+	return d
+}
+
+func (c *client) poolDiagnostics() PoolDiagnostics {
+	open := atomic.LoadInt64(&c.pool.open)
+	inflight := atomic.LoadInt64(&c.pool.inflight)
+
+	pd := PoolDiagnostics{
+		Tracked:  c.pool.tracked,
+		Open:     open,
+		InFlight: inflight,
+	}
+
+	if c.pool.tracked {
+		pd.Idle = open - inflight
+
+		if pd.Idle < 0 {
+			pd.Idle = 0
+		}
+
+		// 使用默认 http.Client 时，配置已在 NewClient 中校验
+		pd.MaxConnsPerHost = c.transport.pool.maxConnsPerHost
+		pd.MaxIdleConnsPerHost, _ = c.transport.pool.validate()
+	}
+
+	return pd
+}
+
+// resolve 解析服务地址的域名
+func (c *client) resolve(ctx context.Context) DNSDiagnostics {
+	u, err := url.Parse(c.endpoint)
+
+	if err != nil {
+		return DNSDiagnostics{Error: err.Error()}
+	}
+
+	dd := DNSDiagnostics{Host: u.Hostname()}
+
+	if ip := net.ParseIP(dd.Host); ip != nil {
+		dd.Addrs = []string{ip.String()}
+
+		return dd
+	}
+
+	var resolver HostResolver = net.DefaultResolver
+
+	if c.transport.resolver != nil {
+		resolver = c.transport.resolver
+	}
+
+	start := time.Now()
+
+	addrs, err := resolver.LookupHost(ctx, dd.Host)
+
+	dd.Duration = time.Since(start)
+	dd.Addrs = addrs
+
+	if err != nil {
+		dd.Error = err.Error()
+	}
+
+	return dd
+}
@@ -0,0 +1,107 @@
+package antchain
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+
+	"github.com/shenghui0779/antchain/sm3"
+)
+
+// HashAlgorithm 存证内容的摘要算法
+type HashAlgorithm string
+
+const (
+	// HashSHA256 SHA-256(VerifyDeposit 支持校验的摘要格式)
+	HashSHA256 HashAlgorithm = "SHA256"
+	// HashSM3 国密 SM3
+	HashSM3 HashAlgorithm = "SM3"
+)
+
+// New 返回摘要算法的 hash.Hash
+func (ha HashAlgorithm) New() (hash.Hash, error) {
+	switch ha {
+	case HashSHA256:
+		return sha256.New(), nil
+	case HashSM3:
+		return sm3.New(), nil
+	}
+
+	return nil, fmt.Errorf("antchain: unsupported hash algorithm %q", string(ha))
+}
+
+// Digest 内容摘要
+type Digest struct {
+	Algorithm HashAlgorithm
+	Sum       []byte
+	Size      int64 // 内容字节数
+}
+
+// Hex 返回摘要的 hex 格式(小写，不带0x前缀)，即存证摘要时使用的格式
+func (d *Digest) Hex() string {
+	return hex.EncodeToString(d.Sum)
+}
+
+// Base64 返回摘要的 base64 格式
+func (d *Digest) Base64() string {
+	return base64.StdEncoding.EncodeToString(d.Sum)
+}
+
+func (d *Digest) String() string {
+	return d.Hex()
+}
+
+// HashReader 流式计算 r 的摘要，无需将内容全部读入内存
+func HashReader(algo HashAlgorithm, r io.Reader) (*Digest, error) {
+	h, err := algo.New()
+
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := io.Copy(h, r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Digest{
+		Algorithm: algo,
+		Sum:       h.Sum(nil),
+		Size:      n,
+	}, nil
+}
+
+// HashFile 流式计算文件的摘要(适用于大文件)
+func HashFile(algo HashAlgorithm, path string) (*Digest, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return HashReader(algo, f)
+}
+
+// HashBytes 计算 data 的摘要
+func HashBytes(algo HashAlgorithm, data []byte) (*Digest, error) {
+	h, err := algo.New()
+
+	if err != nil {
+		return nil, err
+	}
+
+	h.Write(data)
+
+	return &Digest{
+		Algorithm: algo,
+		Sum:       h.Sum(nil),
+		Size:      int64(len(data)),
+	}, nil
+}
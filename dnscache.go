@@ -0,0 +1,154 @@
+package antchain
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultDNSCacheTTL 域名解析结果的默认缓存时间
+	defaultDNSCacheTTL = time.Minute
+	// defaultDNSStaleTTL 解析失败时，过期结果默认可继续使用的时间
+	defaultDNSStaleTTL = 10 * time.Minute
+)
+
+// HostResolver 域名解析，*net.Resolver 即实现了该接口
+type HostResolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+type dnsEntry struct {
+	addrs    []string
+	expireAt time.Time
+	staleAt  time.Time
+}
+
+// CachingResolver 缓存域名解析结果，避免高并发时频繁解析网关域名；
+// 解析失败时在 staleTTL 内继续使用过期的结果
+type CachingResolver struct {
+	resolver HostResolver
+	ttl      time.Duration
+	staleTTL time.Duration
+
+	mutex   sync.Mutex
+	entries map[string]*dnsEntry
+	flights flightGroup
+}
+
+// NewCachingResolver 返回缓存解析结果的 CachingResolver，resolver 为空时使用 net.DefaultResolver；
+// 标准库不返回 DNS 记录的 TTL，ttl 应不大于网关域名记录的 TTL(默认：1m)，staleTTL 默认为 10m
+func NewCachingResolver(resolver HostResolver, ttl, staleTTL time.Duration) *CachingResolver {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	if ttl <= 0 {
+		ttl = defaultDNSCacheTTL
+	}
+
+	if staleTTL <= 0 {
+		staleTTL = defaultDNSStaleTTL
+	}
+
+	return &CachingResolver{
+		resolver: resolver,
+		ttl:      ttl,
+		staleTTL: staleTTL,
+		entries:  make(map[string]*dnsEntry),
+	}
+}
+
+// LookupHost 返回域名的解析结果，优先使用未过期的缓存
+func (r *CachingResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	now := time.Now()
+
+	r.mutex.Lock()
+	entry, ok := r.entries[host]
+	r.mutex.Unlock()
+
+	if ok && now.Before(entry.expireAt) {
+		return entry.addrs, nil
+	}
+
+	// 合并同一域名的并发解析
+	data, err := r.flights.do(host, func() (string, error) {
+		addrs, err := r.resolver.LookupHost(ctx, host)
+
+		if err != nil {
+			return "", err
+		}
+
+		if len(addrs) == 0 {
+			return "", &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		}
+
+		now := time.Now()
+
+		r.mutex.Lock()
+		r.entries[host] = &dnsEntry{
+			addrs:    addrs,
+			expireAt: now.Add(r.ttl),
+			staleAt:  now.Add(r.ttl + r.staleTTL),
+		}
+		r.mutex.Unlock()
+
+		return strings.Join(addrs, ","), nil
+	})
+
+	if err != nil {
+		if ok && now.Before(entry.staleAt) {
+			return entry.addrs, nil
+		}
+
+		return nil, err
+	}
+
+	return strings.Split(data, ","), nil
+}
+
+// DialContext 包装 dial：使用缓存的解析结果，并按顺序尝试各个地址直至连接成功
+func (r *CachingResolver) DialContext(dial DialContextFunc) DialContextFunc {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+
+		if err != nil || net.ParseIP(host) != nil {
+			return dial(ctx, network, addr)
+		}
+
+		addrs, err := r.LookupHost(ctx, host)
+
+		if err != nil {
+			return nil, err
+		}
+
+		var errs []error
+
+		for _, ip := range addrs {
+			conn, err := dial(ctx, network, net.JoinHostPort(ip, port))
+
+			if err == nil {
+				return conn, nil
+			}
+
+			errs = append(errs, err)
+
+			if ctx.Err() != nil {
+				break
+			}
+		}
+
+		return nil, errors.Join(errs...)
+	}
+}
+
+// WithDNSCache 使用 CachingResolver 解析网关(及代理)的域名，多个 Client 可共用同一 CachingResolver；
+// 同时设置了 WithDialContext 时，自定义的 dial 收到的是解析后的 IP 地址
+func WithDNSCache(r *CachingResolver) ClientOption {
+	return func(c *client) {
+		c.transport.resolver = r
+	}
+}
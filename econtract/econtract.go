@@ -0,0 +1,262 @@
+// Package econtract 电子合同签署场景的存证辅助：存证合同文件摘要、记录各签署方的签署事件，
+// 并生成串联全部相关交易的核验包(Bundle)
+package econtract
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/shenghui0779/antchain"
+)
+
+const (
+	// RecordDocument 合同文件存证记录的类型
+	RecordDocument = "econtract.document.v1"
+	// RecordSign 签署事件存证记录的类型
+	RecordSign = "econtract.sign.v1"
+)
+
+// ErrBundleMismatch 核验包与链上存证或合同文件不一致
+var ErrBundleMismatch = errors.New("econtract: bundle mismatch")
+
+// Document 合同文件存证记录
+type Document struct {
+	Type       string `json:"type"`
+	ContractID string `json:"contractId"` // 业务侧合同编号
+	Title      string `json:"title"`
+	Algorithm  string `json:"algorithm"` // 摘要算法(SHA256、SM3)
+	Digest     string `json:"digest"`    // 合同文件摘要(hex)
+	Size       int64  `json:"size"`
+	CreatedAt  int64  `json:"createdAt"` // 毫秒时间戳
+}
+
+// Signature 签署事件存证记录
+type Signature struct {
+	Type        string `json:"type"`
+	ContractID  string `json:"contractId"`
+	DocumentTx  string `json:"documentTx"` // 合同文件存证的交易hash
+	Digest      string `json:"digest"`     // 签署的合同文件摘要(hex)
+	Signer      string `json:"signer"`     // 签署方名称
+	SignerID    string `json:"signerId"`   // 签署方证件号或账户标识
+	SignMethod  string `json:"signMethod"` // 签署方式(如：CA证书、人脸、短信验证码)
+	SignValue   string `json:"signature"`  // 签署方对摘要的签名(可为空)
+	SignedAt    int64  `json:"signedAt"`   // 毫秒时间戳
+	PrevTx      string `json:"prevTx"`     // 上一签署事件的交易hash，首个签署事件为合同文件存证的交易hash
+	Description string `json:"description,omitempty"`
+}
+
+// Entry 核验包中的一笔存证
+type Entry struct {
+	TxHash  string `json:"txHash"`
+	Content string `json:"content"` // 存证内容(记录的 JSON)
+}
+
+// Bundle 核验包：合同文件存证及按顺序排列的签署事件存证
+type Bundle struct {
+	ContractID string   `json:"contractId"`
+	Document   *Entry   `json:"document"`
+	Signatures []*Entry `json:"signatures"`
+}
+
+// Flow 一份电子合同的签署流程，并发安全
+type Flow struct {
+	cli        antchain.DepositService
+	gas        int
+	contractID string
+
+	mutex  sync.Mutex
+	bundle *Bundle
+}
+
+// NewFlow 返回合同 contractID 的签署流程，gas 为每笔存证的 gas
+func NewFlow(cli antchain.DepositService, contractID string, gas int) *Flow {
+	return &Flow{
+		cli:        cli,
+		gas:        gas,
+		contractID: contractID,
+		bundle: &Bundle{
+			ContractID: contractID,
+			Signatures: make([]*Entry, 0),
+		},
+	}
+}
+
+// DepositDocument 存证合同文件摘要(见 antchain.HashFile)，createdAt 为毫秒时间戳，返回交易hash
+func (f *Flow) DepositDocument(ctx context.Context, title string, digest *antchain.Digest, createdAt int64) (string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.bundle.Document != nil {
+		return "", errors.New("econtract: document already deposited")
+	}
+
+	doc := &Document{
+		Type:       RecordDocument,
+		ContractID: f.contractID,
+		Title:      title,
+		Algorithm:  string(digest.Algorithm),
+		Digest:     digest.Hex(),
+		Size:       digest.Size,
+		CreatedAt:  createdAt,
+	}
+
+	entry, err := f.deposit(ctx, doc)
+
+	if err != nil {
+		return "", err
+	}
+
+	f.bundle.Document = entry
+
+	return entry.TxHash, nil
+}
+
+// RecordSignature 存证签署事件，自动填写类型、合同编号、文件摘要及前一事件的交易hash，返回交易hash
+func (f *Flow) RecordSignature(ctx context.Context, sig *Signature) (string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.bundle.Document == nil {
+		return "", errors.New("econtract: document not deposited")
+	}
+
+	doc := new(Document)
+
+	if err := json.Unmarshal([]byte(f.bundle.Document.Content), doc); err != nil {
+		return "", err
+	}
+
+	record := *sig
+	record.Type = RecordSign
+	record.ContractID = f.contractID
+	record.DocumentTx = f.bundle.Document.TxHash
+	record.Digest = doc.Digest
+	record.PrevTx = f.bundle.Document.TxHash
+
+	if n := len(f.bundle.Signatures); n != 0 {
+		record.PrevTx = f.bundle.Signatures[n-1].TxHash
+	}
+
+	entry, err := f.deposit(ctx, &record)
+
+	if err != nil {
+		return "", err
+	}
+
+	f.bundle.Signatures = append(f.bundle.Signatures, entry)
+
+	return entry.TxHash, nil
+}
+
+// Bundle 返回当前的核验包(副本)
+func (f *Flow) Bundle() *Bundle {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	b := &Bundle{
+		ContractID: f.bundle.ContractID,
+		Signatures: make([]*Entry, 0, len(f.bundle.Signatures)),
+	}
+
+	if f.bundle.Document != nil {
+		doc := *f.bundle.Document
+		b.Document = &doc
+	}
+
+	for _, v := range f.bundle.Signatures {
+		entry := *v
+		b.Signatures = append(b.Signatures, &entry)
+	}
+
+	return b
+}
+
+func (f *Flow) deposit(ctx context.Context, record interface{}) (*Entry, error) {
+	b, err := json.Marshal(record)
+
+	if err != nil {
+		return nil, err
+	}
+
+	txHash, err := f.cli.Deposit(ctx, string(b), f.gas)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Entry{
+		TxHash:  txHash,
+		Content: string(b),
+	}, nil
+}
+
+// Verify 核验合同签署：校验各笔存证与链上内容一致、签署事件依次链接，
+// 且 document(合同文件，可为空表示不校验文件)的摘要与存证一致；不一致返回 ErrBundleMismatch
+func Verify(ctx context.Context, cli antchain.DepositService, b *Bundle, document io.Reader) error {
+	if b == nil || b.Document == nil {
+		return fmt.Errorf("%w: document entry missing", ErrBundleMismatch)
+	}
+
+	doc := new(Document)
+
+	if err := json.Unmarshal([]byte(b.Document.Content), doc); err != nil || doc.Type != RecordDocument || doc.ContractID != b.ContractID {
+		return fmt.Errorf("%w: invalid document record", ErrBundleMismatch)
+	}
+
+	if err := verifyEntry(ctx, cli, b.Document); err != nil {
+		return err
+	}
+
+	if document != nil {
+		digest, err := antchain.HashReader(antchain.HashAlgorithm(doc.Algorithm), document)
+
+		if err != nil {
+			return err
+		}
+
+		if !strings.EqualFold(digest.Hex(), doc.Digest) {
+			return fmt.Errorf("%w: document digest %s, deposited %s", ErrBundleMismatch, digest.Hex(), doc.Digest)
+		}
+	}
+
+	prev := b.Document.TxHash
+
+	for i, entry := range b.Signatures {
+		sig := new(Signature)
+
+		if err := json.Unmarshal([]byte(entry.Content), sig); err != nil || sig.Type != RecordSign {
+			return fmt.Errorf("%w: invalid signature record %d", ErrBundleMismatch, i)
+		}
+
+		if sig.ContractID != b.ContractID || sig.DocumentTx != b.Document.TxHash || !strings.EqualFold(sig.Digest, doc.Digest) {
+			return fmt.Errorf("%w: signature %d belongs to another document", ErrBundleMismatch, i)
+		}
+
+		if sig.PrevTx != prev {
+			return fmt.Errorf("%w: signature %d links to %s, expected %s", ErrBundleMismatch, i, sig.PrevTx, prev)
+		}
+
+		if err := verifyEntry(ctx, cli, entry); err != nil {
+			return err
+		}
+
+		prev = entry.TxHash
+	}
+
+	return nil
+}
+
+func verifyEntry(ctx context.Context, cli antchain.DepositService, entry *Entry) error {
+	err := cli.VerifyDeposit(ctx, entry.TxHash, entry.Content)
+
+	if errors.Is(err, antchain.ErrDepositMismatch) {
+		return fmt.Errorf("%w: tx %s content differs from chain", ErrBundleMismatch, entry.TxHash)
+	}
+
+	return err
+}
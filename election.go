@@ -0,0 +1,137 @@
+package antchain
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// defaultLeaseTTL 主节点锁的默认有效期
+	defaultLeaseTTL = 15 * time.Second
+	// defaultCampaignInterval 备用节点默认的竞选间隔
+	defaultCampaignInterval = 5 * time.Second
+)
+
+// ElectorOption Elector 的可选配置
+type ElectorOption func(e *Elector)
+
+// WithLeaseTTL 主节点锁的有效期(默认：15s)，主节点失效后最长经过 ttl+竞选间隔 由备用节点接管
+func WithLeaseTTL(ttl time.Duration) ElectorOption {
+	return func(e *Elector) {
+		e.ttl = ttl
+	}
+}
+
+// WithCampaignInterval 备用节点的竞选间隔(默认：5s)
+func WithCampaignInterval(interval time.Duration) ElectorOption {
+	return func(e *Elector) {
+		e.interval = interval
+	}
+}
+
+// WithLeaderCallbacks 成为主节点及失去主节点身份时的回调
+func WithLeaderCallbacks(onElected, onRevoked func()) ElectorOption {
+	return func(e *Elector) {
+		e.onElected = onElected
+		e.onRevoked = onRevoked
+	}
+}
+
+// WithElectionErrorHandler 主节点任务出错或竞选出错时的回调，之后释放主节点身份并重新竞选
+func WithElectionErrorHandler(fn func(err error)) ElectorOption {
+	return func(e *Elector) {
+		e.onError = fn
+	}
+}
+
+// Elector 基于 Locker 的主节点选举：多个副本中只有一个作为主节点执行任务，其余副本作为备用节点定期竞选，
+// 主节点退出或失联后由备用节点自动接管
+type Elector struct {
+	locker   Locker
+	key      string
+	ttl      time.Duration
+	interval time.Duration
+
+	onElected func()
+	onRevoked func()
+	onError   func(err error)
+
+	leader int32
+}
+
+// NewElector 返回以 key 为锁键的 Elector
+func NewElector(locker Locker, key string, options ...ElectorOption) *Elector {
+	e := &Elector{
+		locker:   locker,
+		key:      key,
+		ttl:      defaultLeaseTTL,
+		interval: defaultCampaignInterval,
+	}
+
+	for _, f := range options {
+		f(e)
+	}
+
+	if e.ttl <= 0 {
+		e.ttl = defaultLeaseTTL
+	}
+
+	if e.interval <= 0 {
+		e.interval = defaultCampaignInterval
+	}
+
+	return e
+}
+
+// IsLeader 当前是否为主节点
+func (e *Elector) IsLeader() bool {
+	return atomic.LoadInt32(&e.leader) == 1
+}
+
+// Run 持续竞选，成为主节点后执行 fn；失去主节点身份时取消 fn 的 ctx 并重新竞选，直到 ctx 结束
+func (e *Elector) Run(ctx context.Context, fn func(ctx context.Context) error) error {
+	for {
+		err := RunExclusive(ctx, e.locker, e.key, e.ttl, func(ctx context.Context) error {
+			e.elected()
+			defer e.revoked()
+
+			return fn(ctx)
+		})
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if err != nil && !errors.Is(err, ErrLockHeld) && !errors.Is(err, ErrLockNotHeld) && e.onError != nil {
+			e.onError(err)
+		}
+
+		timer := time.NewTimer(e.interval)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (e *Elector) elected() {
+	atomic.StoreInt32(&e.leader, 1)
+
+	if e.onElected != nil {
+		e.onElected()
+	}
+}
+
+func (e *Elector) revoked() {
+	atomic.StoreInt32(&e.leader, 0)
+
+	if e.onRevoked != nil {
+		e.onRevoked()
+	}
+}
@@ -0,0 +1,237 @@
+package antchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrInvalidKey AccessKey 私钥无效(文件不存在、格式错误等)
+	ErrInvalidKey = errors.New("antchain: invalid access key")
+	// ErrSignFailed 签名失败
+	ErrSignFailed = errors.New("antchain: sign failed")
+	// ErrShakehandFailed shakehand 获取 token 失败
+	ErrShakehandFailed = errors.New("antchain: shakehand failed")
+	// ErrRequestFailed HTTP 请求失败(网络错误等)
+	ErrRequestFailed = errors.New("antchain: request failed")
+	// ErrDecodeFailed 数据编解码失败
+	ErrDecodeFailed = errors.New("antchain: decode failed")
+	// ErrTokenExpired shakehand token 已失效
+	ErrTokenExpired = errors.New("antchain: token expired")
+	// ErrThrottled 请求被网关限流
+	ErrThrottled = errors.New("antchain: throttled")
+	// ErrUnsupportedVersion 网关不支持请求的 restApiVersion
+	ErrUnsupportedVersion = errors.New("antchain: unsupported rest api version")
+)
+
+// wrapError 同时包装哨兵错误与底层错误，使 errors.Is/As 对两者均有效
+type wrapError struct {
+	sentinel error
+	err      error
+}
+
+func wrapErr(sentinel, err error) error {
+	return &wrapError{
+		sentinel: sentinel,
+		err:      err,
+	}
+}
+
+func (e *wrapError) Error() string {
+	return e.sentinel.Error() + ": " + e.err.Error()
+}
+
+func (e *wrapError) Unwrap() error {
+	return e.err
+}
+
+func (e *wrapError) Is(target error) bool {
+	return target == e.sentinel
+}
+
+// ErrCode 网关返回的错误码
+type ErrCode string
+
+// ErrCodeTooManyRequests HTTP 429 状态码(响应不是网关的 JSON 格式时，错误码取 HTTP 状态码)；
+// 网关的业务错误码以所用网关版本的文档为准，SDK 不内置未经文档确认的错误码，需要时通过 WithErrCodes 登记
+const ErrCodeTooManyRequests ErrCode = "429"
+
+var errCodeLabels = map[ErrCode]string{
+	ErrCodeTooManyRequests: "too_many_requests",
+}
+
+// Label 返回错误码对应的标签(可用于监控告警)，未知错误码返回 "unknown"
+func (c ErrCode) Label() string {
+	if v, ok := errCodeLabels[c]; ok {
+		return v
+	}
+
+	return "unknown"
+}
+
+// APIError 网关返回的错误
+type APIError struct {
+	Code    ErrCode
+	Message string
+
+	StatusCode int         // HTTP 状态码
+	Header     http.Header // 排查问题相关的响应头(如：X-Request-Id、Retry-After)
+	Body       string      // 响应内容(超出 maxErrorBodyLength 时截断)
+
+	kind error // 通过 WithErrCodes 登记的错误码对应的哨兵错误
+}
+
+func (e *APIError) Error() string {
+	if e.StatusCode != 0 && (e.StatusCode < 200 || e.StatusCode >= 300) {
+		return fmt.Sprintf("antchain: %s | %s (http %d)", e.Code, e.Message, e.StatusCode)
+	}
+
+	return fmt.Sprintf("antchain: %s | %s", e.Code, e.Message)
+}
+
+// maxErrorBodyLength APIError 保留的响应内容的最大长度
+const maxErrorBodyLength = 1024
+
+// errorHeaders APIError 保留的响应头
+var errorHeaders = []string{
+	"Content-Type",
+	"Date",
+	"Retry-After",
+	"Server",
+	"Www-Authenticate",
+	"X-Request-Id",
+}
+
+func relevantHeader(h http.Header) http.Header {
+	ret := make(http.Header)
+
+	for _, k := range errorHeaders {
+		if v := h.Values(k); len(v) != 0 {
+			ret[k] = v
+		}
+	}
+
+	return ret
+}
+
+// truncateBody 截断响应内容，并隐去其中的 token、签名等敏感字段
+func truncateBody(b []byte) string {
+	s := redactSecrets(string(b))
+
+	if len(s) > maxErrorBodyLength {
+		return s[:maxErrorBodyLength] + "...(truncated)"
+	}
+
+	return s
+}
+
+// gatewayUnavailable 网关层面的临时故障，可以重试
+var gatewayUnavailable = map[int]bool{
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// Is 支持 errors.Is(err, ErrTokenExpired) 及 errors.Is(err, ErrUnsupportedVersion)，
+// 仅对通过 WithErrCodes 登记过的错误码生效
+func (e *APIError) Is(target error) bool {
+	return e.kind != nil && target == e.kind
+}
+
+// ThrottleError 网关限流错误，RetryAfter 为网关建议的等待时长(未返回则为0)
+type ThrottleError struct {
+	APIError
+	RetryAfter time.Duration
+}
+
+func (e *ThrottleError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("antchain: throttled %s | %s (retry after %s)", e.Code, e.Message, e.RetryAfter)
+	}
+
+	return fmt.Sprintf("antchain: throttled %s | %s", e.Code, e.Message)
+}
+
+// Is 支持 errors.Is(err, ErrThrottled)
+func (e *ThrottleError) Is(target error) bool {
+	return target == ErrThrottled
+}
+
+// Unwrap 返回内嵌的 APIError，使 errors.As(err, &*APIError) 对限流错误同样有效
+func (e *ThrottleError) Unwrap() error {
+	return &e.APIError
+}
+
+// IsThrottled 判断是否为网关限流错误
+func IsThrottled(err error) bool {
+	return errors.Is(err, ErrThrottled)
+}
+
+// IsTokenExpired 判断是否为 token 失效错误
+func IsTokenExpired(err error) bool {
+	return errors.Is(err, ErrTokenExpired)
+}
+
+// IsRetryable 判断请求是否可以重试：限流、token失效、网络错误及网关 502/503/504 可重试，其余业务错误不可重试
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if IsThrottled(err) || IsTokenExpired(err) {
+		return true
+	}
+
+	// 配置问题、客户端已关闭、调用策略及响应校验失败，重试无意义
+	if errors.Is(err, ErrInvalidKey) || errors.Is(err, ErrSignFailed) || errors.Is(err, ErrNoCredentials) || errors.Is(err, ErrClosed) ||
+		errors.Is(err, ErrSchemaViolation) || errors.Is(err, ErrMethodNotAllowed) || errors.Is(err, ErrInvalidParams) {
+		return false
+	}
+
+	var ae *APIError
+
+	if errors.As(err, &ae) {
+		return gatewayUnavailable[ae.StatusCode]
+	}
+
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// errCodeKinds WithErrCodes 支持登记的哨兵错误
+var errCodeKinds = map[error]bool{
+	ErrTokenExpired:       true,
+	ErrThrottled:          true,
+	ErrUnsupportedVersion: true,
+}
+
+// maxRetryAfter Retry-After 的上限，避免异常的响应头使调用方长时间等待
+const maxRetryAfter = time.Minute
+
+// parseRetryAfter 解析 Retry-After，支持秒数与 HTTP 时间两种格式，超过 1 分钟按 1 分钟计
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	if len(v) == 0 {
+		return 0
+	}
+
+	var d time.Duration
+
+	if sec, err := strconv.ParseInt(v, 10, 64); err == nil {
+		if sec <= 0 {
+			return 0
+		}
+
+		if sec >= int64(maxRetryAfter/time.Second) {
+			return maxRetryAfter
+		}
+
+		d = time.Duration(sec) * time.Second
+	} else if t, err := http.ParseTime(v); err == nil && t.After(now) {
+		d = t.Sub(now)
+	}
+
+	return min(d, maxRetryAfter)
+}
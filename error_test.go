@@ -0,0 +1,103 @@
+package antchain
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"", 0},
+		{"abc", 0},
+		{"-5", 0},
+		{"0", 0},
+		{"3", 3 * time.Second},
+		{"86400", maxRetryAfter},
+		{"99999999999999999", maxRetryAfter},
+		{now.Add(10 * time.Second).Format(http.TimeFormat), 10 * time.Second},
+		{now.Add(24 * time.Hour).Format(http.TimeFormat), maxRetryAfter},
+		{now.Add(-time.Hour).Format(http.TimeFormat), 0},
+	}
+
+	for _, c := range cases {
+		if got := parseRetryAfter(c.in, now); got != c.want {
+			t.Errorf("parseRetryAfter(%q) = %s, want %s", c.in, got, c.want)
+		}
+	}
+}
+
+func TestThrottleErrorExposesAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.Header().Set("X-Request-Id", "req-1")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"success":false,"code":"429","data":"slow down"}`))
+	}))
+	defer srv.Close()
+
+	cli := newTestClient(t, &testGateway{Server: srv})
+
+	_, err := cli.shakehand(context.Background())
+
+	var te *ThrottleError
+
+	if !errors.As(err, &te) || te.RetryAfter != maxRetryAfter {
+		t.Fatalf("err = %v", err)
+	}
+
+	var ae *APIError
+
+	if !errors.As(err, &ae) || ae.StatusCode != http.StatusTooManyRequests || ae.Header.Get("X-Request-Id") != "req-1" {
+		t.Fatalf("APIError not exposed: %v", err)
+	}
+}
+
+func TestErrCodesClassification(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success":false,"code":"GW_CODE","data":"failed"}`))
+	}))
+	defer srv.Close()
+
+	cases := []struct {
+		name      string
+		codes     map[ErrCode]error
+		throttled bool
+		expired   bool
+	}{
+		{"unregistered", nil, false, false},
+		{"throttled", map[ErrCode]error{"GW_CODE": ErrThrottled}, true, false},
+		{"token expired", map[ErrCode]error{"GW_CODE": ErrTokenExpired}, false, true},
+		{"unsupported kind", map[ErrCode]error{"GW_CODE": ErrInvalidKey}, false, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cli := newTestClient(t, &testGateway{Server: srv}, WithErrCodes(c.codes))
+
+			_, err := cli.shakehand(context.Background())
+
+			var ae *APIError
+
+			if !errors.As(err, &ae) || ae.Code != "GW_CODE" {
+				t.Fatalf("err = %v", err)
+			}
+
+			if IsThrottled(err) != c.throttled || IsTokenExpired(err) != c.expired || errors.Is(err, ErrInvalidKey) {
+				t.Fatalf("throttled = %v, expired = %v, err = %v", IsThrottled(err), IsTokenExpired(err), err)
+			}
+
+			if !c.throttled && !c.expired && IsRetryable(err) {
+				t.Fatalf("unregistered code is retryable: %v", err)
+			}
+		})
+	}
+}
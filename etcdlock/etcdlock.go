@@ -0,0 +1,253 @@
+// Package etcdlock 通过 etcd v3 的 HTTP/JSON 网关实现 antchain.Locker：
+// 锁键绑定租约，键不存在时以事务写入，续期即续约租约，释放即撤销租约；
+// 开启认证时访问令牌会过期(默认 300s)，令牌失效后自动重新认证。
+//
+// 进程暂停(如：GC、网络分区)超过租约有效期时锁可能已被其它持有者获取，持有者本身无法感知；
+// 需要严格互斥时使用 Revision 作为 fencing token：后获取的锁 Revision 更大，受保护的资源拒绝较小的 Revision
+package etcdlock
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shenghui0779/antchain"
+	"github.com/tidwall/gjson"
+)
+
+// Config etcd 配置
+type Config struct {
+	Endpoint string // 网关地址，如：http://127.0.0.1:2379
+	Username string // 开启认证时的用户名(可选)
+	Password string
+
+	HTTPClient *http.Client // 默认 http.DefaultClient
+}
+
+// errInvalidToken 访问令牌无效或已过期
+var errInvalidToken = errors.New("etcdlock: invalid auth token")
+
+// Locker 基于 etcd 的 antchain.Locker
+type Locker struct {
+	cfg *Config
+	cli *http.Client
+
+	mutex sync.Mutex
+	token string
+}
+
+// NewLocker 返回基于 etcd 的 Locker
+func NewLocker(cfg *Config) *Locker {
+	cli := cfg.HTTPClient
+
+	if cli == nil {
+		cli = http.DefaultClient
+	}
+
+	return &Locker{
+		cfg: cfg,
+		cli: cli,
+	}
+}
+
+// TryLock 尝试获取锁，已被其它持有者持有时返回 antchain.ErrLockHeld；
+// 租约有效期按秒向上取整，续期时沿用获取时的有效期
+func (l *Locker) TryLock(ctx context.Context, key string, ttl time.Duration) (antchain.Lock, error) {
+	secs := int64((ttl + time.Second - 1) / time.Second)
+
+	if secs < 1 {
+		secs = 1
+	}
+
+	lease, err := l.call(ctx, "/v3/lease/grant", map[string]interface{}{"TTL": secs})
+
+	if err != nil {
+		return nil, err
+	}
+
+	id := lease.Get("ID").String()
+
+	k := base64.StdEncoding.EncodeToString([]byte(key))
+
+	txn, err := l.call(ctx, "/v3/kv/txn", map[string]interface{}{
+		"compare": []map[string]interface{}{
+			{"key": k, "result": "EQUAL", "target": "CREATE", "create_revision": "0"},
+		},
+		"success": []map[string]interface{}{
+			{"request_put": map[string]interface{}{"key": k, "value": base64.StdEncoding.EncodeToString([]byte(id)), "lease": id}},
+		},
+	})
+
+	if err == nil && !txn.Get("succeeded").Bool() {
+		err = antchain.ErrLockHeld
+	}
+
+	if err != nil {
+		l.call(ctx, "/v3/lease/revoke", map[string]interface{}{"ID": id})
+
+		return nil, err
+	}
+
+	return &lock{
+		locker:   l,
+		lease:    id,
+		revision: txn.Get("header.revision").Int(),
+	}, nil
+}
+
+// call 携带访问令牌发起请求，令牌失效时重新认证并重试一次
+func (l *Locker) call(ctx context.Context, path string, body interface{}) (gjson.Result, error) {
+	if len(l.cfg.Username) == 0 {
+		return l.post(ctx, "", path, body)
+	}
+
+	token, err := l.authToken(ctx, "")
+
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	ret, err := l.post(ctx, token, path, body)
+
+	if !errors.Is(err, errInvalidToken) {
+		return ret, err
+	}
+
+	if token, err = l.authToken(ctx, token); err != nil {
+		return gjson.Result{}, err
+	}
+
+	return l.post(ctx, token, path, body)
+}
+
+// authToken 返回缓存的访问令牌，缓存为空或等于已失效的 stale 时重新认证
+func (l *Locker) authToken(ctx context.Context, stale string) (string, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if len(l.token) != 0 && l.token != stale {
+		return l.token, nil
+	}
+
+	token, err := l.authenticate(ctx)
+
+	if err != nil {
+		return "", err
+	}
+
+	l.token = token
+
+	return token, nil
+}
+
+// authenticate 获取访问令牌
+func (l *Locker) authenticate(ctx context.Context) (string, error) {
+	ret, err := l.post(ctx, "", "/v3/auth/authenticate", map[string]string{
+		"name":     l.cfg.Username,
+		"password": l.cfg.Password,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return ret.Get("token").String(), nil
+}
+
+func (l *Locker) post(ctx context.Context, token, path string, body interface{}) (gjson.Result, error) {
+	b, err := json.Marshal(body)
+
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(l.cfg.Endpoint, "/")+path, bytes.NewReader(b))
+
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if len(token) != 0 {
+		req.Header.Set("Authorization", token)
+	}
+
+	resp, err := l.cli.Do(req)
+
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized || bytes.Contains(data, []byte("invalid auth token")) {
+		return gjson.Result{}, fmt.Errorf("%w: %s: %.256s", errInvalidToken, path, data)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return gjson.Result{}, fmt.Errorf("etcdlock: %s: unexpected status %d: %.256s", path, resp.StatusCode, data)
+	}
+
+	ret := gjson.ParseBytes(data)
+
+	if msg := ret.Get("error"); msg.Exists() {
+		return gjson.Result{}, fmt.Errorf("etcdlock: %s: %s", path, msg.String())
+	}
+
+	return ret, nil
+}
+
+type lock struct {
+	locker   *Locker
+	lease    string
+	revision int64
+}
+
+// Revision 获取锁时写入锁键的 revision，可作为 fencing token(etcd 的 revision 全局单调递增)，
+// 通过类型断言获取：
+//
+//	if f, ok := lk.(interface{ Revision() int64 }); ok {
+//		fence := f.Revision()
+//	}
+func (l *lock) Revision() int64 {
+	return l.revision
+}
+
+// Refresh 续约租约，etcd 不支持修改租约有效期，ttl 不生效
+func (l *lock) Refresh(ctx context.Context, ttl time.Duration) error {
+	ret, err := l.locker.call(ctx, "/v3/lease/keepalive", map[string]interface{}{"ID": l.lease})
+
+	if err != nil {
+		return err
+	}
+
+	// 租约已过期时 TTL 为空或不大于 0
+	if n, _ := strconv.ParseInt(ret.Get("result.TTL").String(), 10, 64); n <= 0 {
+		return antchain.ErrLockNotHeld
+	}
+
+	return nil
+}
+
+// Unlock 撤销租约，锁键随之删除
+func (l *lock) Unlock(ctx context.Context) error {
+	_, err := l.locker.call(ctx, "/v3/lease/revoke", map[string]interface{}{"ID": l.lease})
+
+	return err
+}
@@ -0,0 +1,97 @@
+package etcdlock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeEtcd 模拟 etcd 网关：每次认证签发新令牌，expire 使当前令牌失效
+type fakeEtcd struct {
+	mutex  sync.Mutex
+	tokens int
+	valid  string
+	auths  int
+}
+
+func (f *fakeEtcd) expire() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.valid = ""
+}
+
+func (f *fakeEtcd) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if r.URL.Path == "/v3/auth/authenticate" {
+		f.tokens++
+		f.auths++
+		f.valid = fmt.Sprintf("token-%d", f.tokens)
+
+		json.NewEncoder(w).Encode(map[string]string{"token": f.valid})
+
+		return
+	}
+
+	if r.Header.Get("Authorization") != f.valid || f.valid == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"etcdserver: invalid auth token","code":16}`))
+
+		return
+	}
+
+	switch r.URL.Path {
+	case "/v3/lease/grant":
+		w.Write([]byte(`{"ID":"7","TTL":"10"}`))
+	case "/v3/kv/txn":
+		w.Write([]byte(`{"header":{"revision":"42"},"succeeded":true}`))
+	case "/v3/lease/keepalive":
+		w.Write([]byte(`{"result":{"ID":"7","TTL":"10"}}`))
+	case "/v3/lease/revoke":
+		w.Write([]byte(`{}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestReauthenticateOnInvalidToken(t *testing.T) {
+	etcd := new(fakeEtcd)
+
+	srv := httptest.NewServer(etcd)
+	defer srv.Close()
+
+	locker := NewLocker(&Config{Endpoint: srv.URL, Username: "root", Password: "secret"})
+
+	lk, err := locker.TryLock(context.Background(), "k", time.Second)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rev := lk.(interface{ Revision() int64 }).Revision(); rev != 42 {
+		t.Fatalf("revision = %d", rev)
+	}
+
+	etcd.expire()
+
+	if err = lk.Refresh(context.Background(), time.Second); err != nil {
+		t.Fatalf("refresh after token expiry: %v", err)
+	}
+
+	etcd.expire()
+
+	if err = lk.Unlock(context.Background()); err != nil {
+		t.Fatalf("unlock after token expiry: %v", err)
+	}
+
+	if etcd.auths != 3 {
+		t.Fatalf("auths = %d, want 3", etcd.auths)
+	}
+}
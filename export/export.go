@@ -0,0 +1,280 @@
+// Package export 将区块、交易数据导出为 JSON Lines 或 CSV，便于导入表格或数据湖分析
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/shenghui0779/antchain"
+	"github.com/shenghui0779/antchain/codec"
+	"github.com/tidwall/gjson"
+)
+
+// Format 导出格式
+type Format int
+
+const (
+	// FormatJSONL JSON Lines，每行一个 JSON 对象
+	FormatJSONL Format = iota
+	// FormatCSV CSV，首行为字段名
+	FormatCSV
+)
+
+// Level 导出粒度
+type Level int
+
+const (
+	// LevelBlock 每个区块一条记录
+	LevelBlock Level = iota
+	// LevelTransaction 每笔交易一条记录
+	LevelTransaction
+)
+
+// Field 导出字段，Path 为 gjson 路径：
+// 区块记录为 {"number":块高,"header":块头,"body":块体}；
+// 交易记录为 {"block_number":块高,"index":区块内序号,"tx":交易}
+type Field struct {
+	Name string
+	Path string
+}
+
+// DefaultBlockFields 区块记录的默认导出字段
+var DefaultBlockFields = []Field{
+	{Name: "number", Path: "number"},
+	{Name: "hash", Path: "header.hash"},
+	{Name: "parent_hash", Path: "header.parentHash"},
+	{Name: "timestamp", Path: "header.timestamp"},
+	{Name: "tx_count", Path: "body.transactionList.#"},
+}
+
+// DefaultTransactionFields 交易记录的默认导出字段
+var DefaultTransactionFields = []Field{
+	{Name: "block_number", Path: "block_number"},
+	{Name: "index", Path: "index"},
+	{Name: "hash", Path: "tx.hash"},
+	{Name: "from", Path: "tx.from"},
+	{Name: "to", Path: "tx.to"},
+	{Name: "tx_type", Path: "tx.txType"},
+	{Name: "timestamp", Path: "tx.timestamp"},
+}
+
+// Config 导出配置
+type Config struct {
+	From        int64   // 起始块高(包含)
+	To          int64   // 结束块高(包含)
+	Concurrency int     // 并发获取区块数，默认 1
+	Format      Format  // 导出格式
+	Level       Level   // 导出粒度
+	Fields      []Field // 导出字段，为空则使用默认字段
+}
+
+func (cfg *Config) fields() []Field {
+	if len(cfg.Fields) != 0 {
+		return cfg.Fields
+	}
+
+	if cfg.Level == LevelTransaction {
+		return DefaultTransactionFields
+	}
+
+	return DefaultBlockFields
+}
+
+// writer 按格式写出记录
+type writer interface {
+	write(doc string) error
+	flush() error
+}
+
+type jsonlWriter struct {
+	w      io.Writer
+	fields []Field
+}
+
+// write 按字段顺序写出 JSON 对象，字段值保留原始 JSON 类型
+func (jw *jsonlWriter) write(doc string) error {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for i, f := range jw.fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		name, err := json.Marshal(f.Name)
+
+		if err != nil {
+			return err
+		}
+
+		buf.Write(name)
+		buf.WriteByte(':')
+
+		if v := gjson.Get(doc, f.Path); v.Exists() {
+			buf.WriteString(v.Raw)
+		} else {
+			buf.WriteString("null")
+		}
+	}
+
+	buf.WriteString("}\n")
+
+	_, err := jw.w.Write(buf.Bytes())
+
+	return err
+}
+
+func (jw *jsonlWriter) flush() error {
+	return nil
+}
+
+type csvWriter struct {
+	w      *csv.Writer
+	fields []Field
+	header bool
+}
+
+func (cw *csvWriter) write(doc string) error {
+	if !cw.header {
+		names := make([]string, 0, len(cw.fields))
+
+		for _, f := range cw.fields {
+			names = append(names, f.Name)
+		}
+
+		if err := cw.w.Write(names); err != nil {
+			return err
+		}
+
+		cw.header = true
+	}
+
+	row := make([]string, 0, len(cw.fields))
+
+	for _, f := range cw.fields {
+		row = append(row, gjson.Get(doc, f.Path).String())
+	}
+
+	return cw.w.Write(row)
+}
+
+func (cw *csvWriter) flush() error {
+	cw.w.Flush()
+
+	return cw.w.Error()
+}
+
+// unwrap 去除块头、交易外层的包裹(blockHeader、transactionDO)
+func unwrap(data string, paths ...string) string {
+	for _, p := range paths {
+		if v := gjson.Get(data, p); v.Exists() && v.IsObject() {
+			return v.Raw
+		}
+	}
+
+	return data
+}
+
+// Export 获取 [From, To] 区间的区块并按顺序写出到 w，返回写出的记录数；任一区块获取失败则中止
+func Export(ctx context.Context, cli antchain.QueryService, w io.Writer, cfg *Config) (int, error) {
+	if cfg.To < cfg.From {
+		return 0, fmt.Errorf("export: invalid block range [%d, %d]", cfg.From, cfg.To)
+	}
+
+	var out writer
+
+	switch cfg.Format {
+	case FormatJSONL:
+		out = &jsonlWriter{w: w, fields: cfg.fields()}
+	case FormatCSV:
+		out = &csvWriter{w: csv.NewWriter(w), fields: cfg.fields()}
+	default:
+		return 0, fmt.Errorf("export: unknown format %d", cfg.Format)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	count := 0
+
+	for ret := range cli.FetchBlocks(ctx, cfg.From, cfg.To, cfg.Concurrency) {
+		if ret.Err != nil {
+			out.flush()
+
+			return count, fmt.Errorf("export: block %d: %w", ret.Number, ret.Err)
+		}
+
+		n, err := writeBlock(out, cfg.Level, ret)
+
+		count += n
+
+		if err != nil {
+			out.flush()
+
+			return count, err
+		}
+	}
+
+	if err := out.flush(); err != nil {
+		return count, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return count, err
+	}
+
+	return count, nil
+}
+
+func writeBlock(out writer, level Level, ret *antchain.BlockResult) (int, error) {
+	number := strconv.FormatInt(ret.Number, 10)
+
+	if level == LevelBlock {
+		header := unwrap(ret.Header, "block.blockHeader", "blockHeader")
+		doc := `{"number":` + number + `,"header":` + codec.RawJSON(header) + `,"body":` + codec.RawJSON(ret.Body) + `}`
+
+		if err := out.write(doc); err != nil {
+			return 0, err
+		}
+
+		return 1, nil
+	}
+
+	count := 0
+
+	for i, tx := range gjson.Get(ret.Body, "transactionList").Array() {
+		doc := `{"block_number":` + number + `,"index":` + strconv.Itoa(i) + `,"tx":` + unwrap(tx.Raw, "transactionDO") + `}`
+
+		if err := out.write(doc); err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+// ExportFile 导出到文件(已存在则覆盖)
+func ExportFile(ctx context.Context, cli antchain.QueryService, path string, cfg *Config) (int, error) {
+	f, err := os.Create(path)
+
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := Export(ctx, cli, f, cfg)
+
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+
+	return n, err
+}
@@ -0,0 +1,122 @@
+package antchain
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/tidwall/gjson"
+)
+
+// MetricGasUsed 合约方法消耗gas的计数器名称，labels 为 contract 与 method
+const MetricGasUsed = "antchain_gas_used"
+
+// GasEntry 合约方法的gas消耗统计
+type GasEntry struct {
+	Contract string `json:"contract"`
+	Method   string `json:"method"`
+	Count    int64  `json:"count"`    // 交易数
+	GasUsed  int64  `json:"gas_used"` // 累计消耗gas
+}
+
+type gasKey struct {
+	contract string
+	method   string
+}
+
+// GasReport 按合约及方法汇总交易回执中的 gasUsed
+type GasReport struct {
+	metrics Metrics
+	mutex   sync.Mutex
+	entries map[gasKey]*GasEntry
+}
+
+// NewGasReport 返回 GasReport，metrics 不为空时同步上报 MetricGasUsed
+func NewGasReport(metrics Metrics) *GasReport {
+	return &GasReport{
+		metrics: metrics,
+		entries: make(map[gasKey]*GasEntry),
+	}
+}
+
+// Record 记录交易回执(QueryReceipt 的返回)中的 gasUsed
+func (r *GasReport) Record(contract, method, receipt string) {
+	r.Add(contract, method, gjson.Get(receipt, "gasUsed").Int())
+}
+
+// Add 累加合约方法消耗的gas
+func (r *GasReport) Add(contract, method string, gasUsed int64) {
+	r.mutex.Lock()
+
+	key := gasKey{contract: contract, method: method}
+
+	entry, ok := r.entries[key]
+
+	if !ok {
+		entry = &GasEntry{
+			Contract: contract,
+			Method:   method,
+		}
+
+		r.entries[key] = entry
+	}
+
+	entry.Count++
+	entry.GasUsed += gasUsed
+
+	r.mutex.Unlock()
+
+	if r.metrics != nil {
+		r.metrics.IncCounter(MetricGasUsed, float64(gasUsed), map[string]string{
+			"contract": contract,
+			"method":   method,
+		})
+	}
+}
+
+// Entries 返回按消耗gas降序排列的统计
+func (r *GasReport) Entries() []GasEntry {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	list := make([]GasEntry, 0, len(r.entries))
+
+	for _, v := range r.entries {
+		list = append(list, *v)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].GasUsed != list[j].GasUsed {
+			return list[i].GasUsed > list[j].GasUsed
+		}
+
+		if list[i].Contract != list[j].Contract {
+			return list[i].Contract < list[j].Contract
+		}
+
+		return list[i].Method < list[j].Method
+	})
+
+	return list
+}
+
+// Total 返回累计消耗的gas
+func (r *GasReport) Total() int64 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var total int64
+
+	for _, v := range r.entries {
+		total += v.GasUsed
+	}
+
+	return total
+}
+
+// Reset 清空统计
+func (r *GasReport) Reset() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.entries = make(map[gasKey]*GasEntry)
+}
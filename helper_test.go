@@ -0,0 +1,136 @@
+package antchain
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+var (
+	testKeyOnce sync.Once
+	testKeyPEM  []byte
+)
+
+// testKeyFile 生成测试用的 RSA 私钥文件(各测试共用同一私钥)
+func testKeyFile(t *testing.T) string {
+	t.Helper()
+
+	testKeyOnce.Do(func() {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+
+		if err != nil {
+			panic(err)
+		}
+
+		testKeyPEM = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	})
+
+	path := filepath.Join(t.TempDir(), "key.pem")
+
+	if err := os.WriteFile(path, testKeyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+// testGateway 模拟网关：shakehand 返回固定 token，其它请求交由 handler 处理并记录请求体
+type testGateway struct {
+	*httptest.Server
+
+	mutex    sync.Mutex
+	requests []X
+	paths    []string
+}
+
+func newTestGateway(t *testing.T, handler func(params X) (interface{}, bool)) *testGateway {
+	t.Helper()
+
+	gw := new(testGateway)
+
+	gw.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == SHAKE_HAND {
+			w.Write([]byte(`{"success":true,"data":"access-token"}`))
+
+			return
+		}
+
+		params := X{}
+
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		gw.mutex.Lock()
+		gw.requests = append(gw.requests, params)
+		gw.paths = append(gw.paths, r.URL.Path)
+		gw.mutex.Unlock()
+
+		data, ok := handler(params)
+
+		json.NewEncoder(w).Encode(X{"success": ok, "data": data})
+	}))
+
+	t.Cleanup(gw.Close)
+
+	return gw
+}
+
+// last 返回最后一个业务请求
+func (gw *testGateway) last() X {
+	gw.mutex.Lock()
+	defer gw.mutex.Unlock()
+
+	if len(gw.requests) == 0 {
+		return nil
+	}
+
+	return gw.requests[len(gw.requests)-1]
+}
+
+func newTestClient(t *testing.T, gw *testGateway, options ...ClientOption) *client {
+	t.Helper()
+
+	cli, err := NewClient(&Config{
+		BizID:      "biz",
+		TenantID:   "tenant",
+		AccessID:   "access-id",
+		AccessKey:  testKeyFile(t),
+		Account:    "account",
+		MyKmsKeyID: "kms",
+		Endpoint:   gw.URL,
+	}, options...)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		cli.Close(context.Background())
+	})
+
+	return cli.(*client)
+}
+
+// lastPath 返回最后一个业务请求的路径
+func (gw *testGateway) lastPath() string {
+	gw.mutex.Lock()
+	defer gw.mutex.Unlock()
+
+	if len(gw.paths) == 0 {
+		return ""
+	}
+
+	return gw.paths[len(gw.paths)-1]
+}
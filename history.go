@@ -0,0 +1,244 @@
+package antchain
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shenghui0779/antchain/codec"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	// defaultHistoryLimit 账户交易记录每页的默认数量
+	defaultHistoryLimit = 20
+	// defaultHistoryMaxBlocks 单次查询默认最多扫描的区块数
+	defaultHistoryMaxBlocks = 1000
+)
+
+// ErrInvalidCursor 分页游标无效(格式错误或块高、序号为负数)
+var ErrInvalidCursor = errors.New("antchain: invalid cursor")
+
+// HistoryRequest 账户交易记录查询
+type HistoryRequest struct {
+	Account   string // 链账户
+	Cursor    string // 上一页返回的 NextCursor，为空则从最新区块开始
+	Limit     int    // 每页数量，默认 20
+	MaxBlocks int64  // 单次查询最多扫描的区块数，默认 1000，避免一次查询耗时过长
+	StopBlock int64  // 扫描的最小块高(包含)，默认 0
+}
+
+// AccountTx 账户相关的交易
+type AccountTx struct {
+	BlockNumber int64
+	Index       int    // 交易在区块内的序号
+	Hash        string // 交易hash
+	From        string
+	To          string
+	Data        string // 交易的原始数据(JSON)
+}
+
+// HistoryPage 一页账户交易记录，NextCursor 为空表示已扫描到 StopBlock
+type HistoryPage struct {
+	Transactions []*AccountTx
+	NextCursor   string
+}
+
+// historyCursor 扫描位置：下一个待扫描的区块及区块内的交易序号
+type historyCursor struct {
+	block int64
+	index int
+}
+
+func (hc historyCursor) encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d", hc.block, hc.index)))
+}
+
+func parseHistoryCursor(s string) (historyCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+
+	if err != nil {
+		return historyCursor{}, wrapErr(ErrInvalidCursor, err)
+	}
+
+	parts := strings.SplitN(string(b), ":", 2)
+
+	if len(parts) != 2 {
+		return historyCursor{}, fmt.Errorf("%w: %q", ErrInvalidCursor, s)
+	}
+
+	block, err := strconv.ParseInt(parts[0], 10, 64)
+
+	if err != nil || block < 0 {
+		return historyCursor{}, fmt.Errorf("%w: %q", ErrInvalidCursor, s)
+	}
+
+	index, err := strconv.Atoi(parts[1])
+
+	if err != nil || index < 0 {
+		return historyCursor{}, fmt.Errorf("%w: %q", ErrInvalidCursor, s)
+	}
+
+	return historyCursor{block: block, index: index}, nil
+}
+
+// ParseBlockNumber 解析最新块高(QueryLastBlock)的查询结果，兼容直接返回数字及返回块头的情况
+func ParseBlockNumber(data string) (int64, error) {
+	if n, err := strconv.ParseInt(strings.TrimSpace(data), 10, 64); err == nil {
+		return n, nil
+	}
+
+	if v := headerField(data, "number"); v.Exists() {
+		return v.Int(), nil
+	}
+
+	return 0, wrapErr(ErrDecodeFailed, fmt.Errorf("invalid block number: %.256s", data))
+}
+
+// accountMatcher 判断交易的 from/to 是否为指定账户(兼容账户名、Identity的hex及base64形式)
+type accountMatcher map[string]bool
+
+func newAccountMatcher(account string) accountMatcher {
+	identity := GetIdentityByName(account)
+
+	m := accountMatcher{
+		strings.ToLower(account): true,
+		identity.Data:            true,
+	}
+
+	if h, err := identity.Hex(); err == nil {
+		m[h] = true
+	}
+
+	return m
+}
+
+func (m accountMatcher) match(v string) bool {
+	if len(v) == 0 {
+		return false
+	}
+
+	return m[v] || m[strings.ToLower(codec.Trim0x(v))]
+}
+
+// IdentitySet 账户(合约)集合，判断时兼容账户名、Identity 的 hex 及 base64 形式
+type IdentitySet struct {
+	m accountMatcher
+}
+
+// NewIdentitySet 返回由 accounts 组成的 IdentitySet
+func NewIdentitySet(accounts ...string) *IdentitySet {
+	s := &IdentitySet{m: make(accountMatcher)}
+
+	for _, account := range accounts {
+		for k := range newAccountMatcher(account) {
+			s.m[k] = true
+		}
+	}
+
+	return s
+}
+
+// Contains 判断账户名或 Identity 是否在集合中
+func (s *IdentitySet) Contains(v string) bool {
+	return s.m.match(v)
+}
+
+// AccountHistory 按块高从新到旧扫描区块，返回与账户相关(from 或 to 为该账户)的交易，通过 Cursor 分页
+func (c *client) AccountHistory(ctx context.Context, req *HistoryRequest) (*HistoryPage, error) {
+	limit := req.Limit
+
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+
+	maxBlocks := req.MaxBlocks
+
+	if maxBlocks <= 0 {
+		maxBlocks = defaultHistoryMaxBlocks
+	}
+
+	var cursor historyCursor
+
+	if len(req.Cursor) != 0 {
+		var err error
+
+		cursor, err = parseHistoryCursor(req.Cursor)
+
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		data, err := c.QueryLastBlock(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		last, err := ParseBlockNumber(data)
+
+		if err != nil {
+			return nil, err
+		}
+
+		cursor = historyCursor{block: last}
+	}
+
+	matcher := newAccountMatcher(req.Account)
+
+	page := &HistoryPage{
+		Transactions: make([]*AccountTx, 0, limit),
+	}
+
+	for scanned := int64(0); cursor.block >= req.StopBlock; scanned++ {
+		if scanned >= maxBlocks {
+			page.NextCursor = cursor.encode()
+
+			return page, nil
+		}
+
+		body, err := c.QueryBlockBody(ctx, cursor.block)
+
+		if err != nil {
+			return nil, err
+		}
+
+		txs := gjson.Get(body, "transactionList").Array()
+
+		for ; cursor.index < len(txs); cursor.index++ {
+			tx := txs[cursor.index]
+
+			if v := tx.Get("transactionDO"); v.Exists() {
+				tx = v
+			}
+
+			from, to := tx.Get("from").String(), tx.Get("to").String()
+
+			if !matcher.match(from) && !matcher.match(to) {
+				continue
+			}
+
+			if len(page.Transactions) == limit {
+				page.NextCursor = cursor.encode()
+
+				return page, nil
+			}
+
+			page.Transactions = append(page.Transactions, &AccountTx{
+				BlockNumber: cursor.block,
+				Index:       cursor.index,
+				Hash:        tx.Get("hash").String(),
+				From:        from,
+				To:          to,
+				Data:        tx.Raw,
+			})
+		}
+
+		cursor = historyCursor{block: cursor.block - 1}
+	}
+
+	return page, nil
+}
@@ -0,0 +1,83 @@
+package antchain
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestHistoryCursor(t *testing.T) {
+	hc := historyCursor{block: 42, index: 3}
+
+	got, err := parseHistoryCursor(hc.encode())
+
+	if err != nil || got != hc {
+		t.Fatalf("round trip = %+v, %v", got, err)
+	}
+
+	for _, raw := range []string{"-1:0", "1:-1", "1", "a:0", "1:b", ""} {
+		s := base64.RawURLEncoding.EncodeToString([]byte(raw))
+
+		if _, err := parseHistoryCursor(s); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("cursor %q: err = %v, want ErrInvalidCursor", raw, err)
+		}
+	}
+
+	if _, err := parseHistoryCursor("!!"); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("bad base64: err = %v", err)
+	}
+}
+
+func TestAccountHistoryPaging(t *testing.T) {
+	// 块 0-2 各两笔交易，其中 from 为 alice 的交易各一笔
+	gw := newTestGateway(t, func(params X) (interface{}, bool) {
+		switch Method(params["method"].(string)) {
+		case MethodQueryLastBlock:
+			return "2", true
+		case MethodQueryBlockBody:
+			n := int64(params["requestStr"].(float64))
+
+			return fmt.Sprintf(`{"transactionList":[{"hash":"0x%d-0","from":"alice","to":"bob"},{"hash":"0x%d-1","from":"carol","to":"bob"}]}`, n, n), true
+		}
+
+		return nil, false
+	})
+
+	cli := newTestClient(t, gw)
+
+	var hashes []string
+
+	req := &HistoryRequest{Account: "alice", Limit: 2}
+
+	for {
+		page, err := cli.AccountHistory(context.Background(), req)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, tx := range page.Transactions {
+			hashes = append(hashes, tx.Hash)
+		}
+
+		if page.NextCursor == "" {
+			break
+		}
+
+		req.Cursor = page.NextCursor
+	}
+
+	want := []string{"0x2-0", "0x1-0", "0x0-0"}
+
+	if fmt.Sprint(hashes) != fmt.Sprint(want) {
+		t.Fatalf("hashes = %v, want %v", hashes, want)
+	}
+
+	req.Cursor = base64.RawURLEncoding.EncodeToString([]byte("1:-5"))
+
+	if _, err := cli.AccountHistory(context.Background(), req); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("err = %v, want ErrInvalidCursor", err)
+	}
+}
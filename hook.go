@@ -0,0 +1,83 @@
+package antchain
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Hook 请求钩子，可用于日志、监控、审计等横切逻辑；
+// 可通过 MetadataFromContext 读取调用方附加的元数据
+type Hook interface {
+	// Before 请求发起前调用；params 为隐去签名、私钥等敏感字段的副本(不包含 token)，修改不影响实际请求
+	Before(ctx context.Context, method string, params X)
+
+	// After 请求结束后调用(包含重试耗时)
+	After(ctx context.Context, method string, data string, err error, duration time.Duration)
+}
+
+// Metadata 调用方附加到请求上下文的元数据
+type Metadata struct {
+	Caller   string            // 调用方名称
+	BizID    string            // 业务ID
+	Priority int               // 优先级
+	Extra    map[string]string // 其它自定义数据
+}
+
+type metadataKey struct{}
+
+// ContextWithMetadata 返回附加了元数据的 context
+func ContextWithMetadata(ctx context.Context, md *Metadata) context.Context {
+	return context.WithValue(ctx, metadataKey{}, md)
+}
+
+// MetadataFromContext 返回 context 中附加的元数据，没有则返回 nil
+func MetadataFromContext(ctx context.Context) *Metadata {
+	md, _ := ctx.Value(metadataKey{}).(*Metadata)
+
+	return md
+}
+
+// RawResponse 网关返回的完整响应，用于读取 SDK 尚未解析的字段；
+// 只能用于单次调用：同一 RawResponse 被多次或并发调用复用时只写入第一次的响应，多次调用请使用 ContextWithResponseFunc
+type RawResponse struct {
+	JSON string // 完整的响应 JSON
+
+	written atomic.Bool
+}
+
+// claim 占用 RawResponse，已被占用返回 false
+func (raw *RawResponse) claim() bool {
+	return raw.written.CompareAndSwap(false, true)
+}
+
+type rawResponseKey struct{}
+
+// ContextWithRawResponse 返回附加了 RawResponse 的 context，请求成功后写入网关返回的完整响应；
+// 结果来自缓存(WithCache)或合并的并发请求(WithQueryDedup)时不写入
+func ContextWithRawResponse(ctx context.Context, raw *RawResponse) context.Context {
+	return context.WithValue(ctx, rawResponseKey{}, raw)
+}
+
+func rawResponseFromContext(ctx context.Context) *RawResponse {
+	raw, _ := ctx.Value(rawResponseKey{}).(*RawResponse)
+
+	return raw
+}
+
+// ResponseFunc 接收网关返回的完整响应，同一 context 的并发调用会并发回调
+type ResponseFunc func(method string, json string)
+
+type responseFuncKey struct{}
+
+// ContextWithResponseFunc 返回附加了 ResponseFunc 的 context，每次请求成功后回调，适用于一个 context 发起多次调用；
+// 结果来自缓存(WithCache)或合并的并发请求(WithQueryDedup)时不回调
+func ContextWithResponseFunc(ctx context.Context, fn ResponseFunc) context.Context {
+	return context.WithValue(ctx, responseFuncKey{}, fn)
+}
+
+func responseFuncFromContext(ctx context.Context) ResponseFunc {
+	fn, _ := ctx.Value(responseFuncKey{}).(ResponseFunc)
+
+	return fn
+}
@@ -0,0 +1,114 @@
+package antchain
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRawResponseSingleCall(t *testing.T) {
+	gw := newTestGateway(t, func(params X) (interface{}, bool) { return params["hash"], true })
+
+	cli := newTestClient(t, gw)
+
+	raw := new(RawResponse)
+	ctx := ContextWithRawResponse(context.Background(), raw)
+
+	var wg sync.WaitGroup
+
+	for _, hash := range []string{"0x1", "0x2", "0x3"} {
+		wg.Add(1)
+
+		go func(hash string) {
+			defer wg.Done()
+
+			cli.QueryTransaction(ctx, hash)
+		}(hash)
+	}
+
+	wg.Wait()
+
+	if !strings.Contains(raw.JSON, `"data":"0x`) {
+		t.Fatalf("raw = %q", raw.JSON)
+	}
+}
+
+func TestResponseFunc(t *testing.T) {
+	gw := newTestGateway(t, func(params X) (interface{}, bool) { return params["hash"], true })
+
+	cli := newTestClient(t, gw)
+
+	var (
+		mutex sync.Mutex
+		got   = make(map[string]string)
+	)
+
+	ctx := ContextWithResponseFunc(context.Background(), func(method, json string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		got[json] = method
+	})
+
+	for _, hash := range []string{"0x1", "0x2"} {
+		if _, err := cli.QueryTransaction(ctx, hash); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("responses = %v", got)
+	}
+
+	for _, method := range got {
+		if method != string(MethodQueryTransaction) {
+			t.Fatalf("method = %s", method)
+		}
+	}
+}
+
+// mutatingHook 记录并篡改 Before 收到的参数
+type mutatingHook struct {
+	params X
+}
+
+func (h *mutatingHook) Before(ctx context.Context, method string, params X) {
+	h.params = params
+
+	params["hash"] = "0xevil"
+}
+
+func (h *mutatingHook) After(ctx context.Context, method string, data string, err error, duration time.Duration) {
+}
+
+func TestHookReceivesRedactedCopy(t *testing.T) {
+	gw := newTestGateway(t, func(params X) (interface{}, bool) { return params["hash"], true })
+
+	hook := new(mutatingHook)
+
+	cli := newTestClient(t, gw, WithHook(hook))
+
+	data, err := cli.chainCall(context.Background(), MethodQueryTransaction,
+		WithParam("hash", "0x1"),
+		WithParam("signature", "secret-sign"),
+		WithParam("extra", X{"privateKey": "pk"}),
+	)
+
+	if err != nil || data != "0x1" {
+		t.Fatalf("hook mutated request: data = %q, err = %v", data, err)
+	}
+
+	if v := hook.params["signature"]; v != redacted {
+		t.Fatalf("signature = %v", v)
+	}
+
+	if v := hook.params["extra"].(X)["privateKey"]; v != redacted {
+		t.Fatalf("nested privateKey = %v", v)
+	}
+
+	if v := gw.last()["signature"]; v != "secret-sign" {
+		t.Fatalf("sent signature = %v", v)
+	}
+}
@@ -0,0 +1,519 @@
+// Package importer 将历史记录批量迁移上链：读取 -> 编码 -> 提交 -> 确认回执，各阶段之间为有界队列；
+// 提交并发数按限流及可重试错误的比例自适应调整(AIMD)，链或网关承压时自动降速；
+// 只有被限流的提交会重试，网关 502/503/504 及网络错误时交易可能已上链，记录为失败由调用方核对，避免重复上链
+package importer
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/shenghui0779/antchain"
+)
+
+const (
+	defaultQueueSize      = 256
+	defaultMinConcurrency = 1
+	defaultMaxConcurrency = 32
+	defaultWindow         = 20
+	defaultCongestion     = 0.1
+	defaultAttempts       = 5
+	defaultBackoff        = time.Second
+	defaultPollInterval   = time.Second
+)
+
+// Record 待导入的记录
+type Record struct {
+	Seq  int    // 从 0 开始的序号
+	Data []byte // 原始数据
+}
+
+// Reader 读取待导入的记录，读完返回 io.EOF
+type Reader interface {
+	Next(ctx context.Context) (*Record, error)
+}
+
+// lineReader 按行读取记录，跳过空行
+type lineReader struct {
+	scanner *bufio.Scanner
+	seq     int
+}
+
+// NewLineReader 返回按行读取记录的 Reader(如：JSON Lines、CSV 导出文件)
+func NewLineReader(r io.Reader) Reader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64<<10), 16<<20)
+
+	return &lineReader{scanner: scanner}
+}
+
+func (lr *lineReader) Next(ctx context.Context) (*Record, error) {
+	for lr.scanner.Scan() {
+		line := lr.scanner.Bytes()
+
+		if len(line) == 0 {
+			continue
+		}
+
+		rec := &Record{
+			Seq:  lr.seq,
+			Data: append([]byte(nil), line...),
+		}
+
+		lr.seq++
+
+		return rec, nil
+	}
+
+	if err := lr.scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, io.EOF
+}
+
+// Encoder 将记录编码为上链内容
+type Encoder func(rec *Record) (string, error)
+
+// Submitter 提交上链内容，返回交易哈希
+type Submitter func(ctx context.Context, payload string) (string, error)
+
+// DepositSubmitter 返回以存证方式上链的 Submitter
+func DepositSubmitter(cli antchain.DepositService, gas int, options ...antchain.ChainCallOption) Submitter {
+	return func(ctx context.Context, payload string) (string, error) {
+		return cli.Deposit(ctx, payload, gas, options...)
+	}
+}
+
+// Stage 记录所处的阶段
+type Stage string
+
+const (
+	// StageEncode 编码
+	StageEncode Stage = "encode"
+	// StageSubmit 提交
+	StageSubmit Stage = "submit"
+	// StageConfirm 确认回执
+	StageConfirm Stage = "confirm"
+	// StageDone 已完成
+	StageDone Stage = "done"
+)
+
+// Result 单条记录的导入结果
+type Result struct {
+	Record   *Record
+	Hash     string
+	Stage    Stage // 成功为 StageDone，失败为失败所在阶段
+	Attempts int   // 提交次数
+	Err      error
+}
+
+// Config 导入配置
+type Config struct {
+	Encoder   Encoder   // 为空则直接使用原始数据
+	Submitter Submitter // 必填
+
+	QueueSize      int     // 各阶段之间队列的容量，默认 256
+	MinConcurrency int     // 最小提交并发数，默认 1
+	MaxConcurrency int     // 最大提交并发数，默认 32
+	Window         int     // 每完成多少次提交调整一次并发数，默认 20
+	Congestion     float64 // 窗口内限流及可重试错误的比例超过该值时并发数减半，否则加一，默认 0.1
+
+	Attempts int           // 被限流时的最大提交次数，默认 5
+	Backoff  time.Duration // 重试前的等待时长(按指数增长)，默认 1 秒
+
+	Confirm        antchain.QueryService // 不为空时等待交易回执
+	PollInterval   time.Duration         // 查询回执的间隔，默认 1 秒
+	ConfirmTimeout time.Duration         // 等待回执的超时，为 0 则不超时
+
+	OnResult func(ret *Result) // 每条记录完成(成功或失败)时的回调，可能并发调用
+}
+
+// Stats 导入统计
+type Stats struct {
+	Read        int64 `json:"read"`        // 已读取
+	Submitted   int64 `json:"submitted"`   // 已提交
+	Confirmed   int64 `json:"confirmed"`   // 已确认回执
+	Failed      int64 `json:"failed"`      // 失败
+	Throttled   int64 `json:"throttled"`   // 提交被限流(并重试)的次数
+	Concurrency int   `json:"concurrency"` // 当前提交并发数
+}
+
+// Importer 批量导入
+type Importer struct {
+	cfg     *Config
+	limiter *limiter
+
+	read      int64
+	submitted int64
+	confirmed int64
+	failed    int64
+	throttled int64
+}
+
+// New 返回批量导入
+func New(cfg *Config) *Importer {
+	lower, upper := cfg.MinConcurrency, cfg.MaxConcurrency
+
+	if lower <= 0 {
+		lower = defaultMinConcurrency
+	}
+
+	if upper <= 0 {
+		upper = defaultMaxConcurrency
+	}
+
+	if upper < lower {
+		upper = lower
+	}
+
+	window := cfg.Window
+
+	if window <= 0 {
+		window = defaultWindow
+	}
+
+	congestion := cfg.Congestion
+
+	if congestion <= 0 {
+		congestion = defaultCongestion
+	}
+
+	return &Importer{
+		cfg:     cfg,
+		limiter: newLimiter(lower, upper, window, congestion),
+	}
+}
+
+// Stats 返回导入统计
+func (im *Importer) Stats() Stats {
+	return Stats{
+		Read:        atomic.LoadInt64(&im.read),
+		Submitted:   atomic.LoadInt64(&im.submitted),
+		Confirmed:   atomic.LoadInt64(&im.confirmed),
+		Failed:      atomic.LoadInt64(&im.failed),
+		Throttled:   atomic.LoadInt64(&im.throttled),
+		Concurrency: im.limiter.current(),
+	}
+}
+
+type job struct {
+	rec      *Record
+	payload  string
+	hash     string
+	attempts int
+}
+
+// Run 读取并导入全部记录，直到读完或 ctx 结束；单条记录失败不影响其它记录(通过 OnResult 获取)，
+// 返回读取记录的错误或 ctx 的错误
+func (im *Importer) Run(ctx context.Context, r Reader) (Stats, error) {
+	if im.cfg.Submitter == nil {
+		return im.Stats(), errors.New("importer: submitter required")
+	}
+
+	size := im.cfg.QueueSize
+
+	if size <= 0 {
+		size = defaultQueueSize
+	}
+
+	encoded := make(chan *job, size)
+	submitted := make(chan *job, size)
+
+	var readErr error
+
+	go func() {
+		defer close(encoded)
+
+		readErr = im.produce(ctx, r, encoded)
+	}()
+
+	var submitters, confirmers sync.WaitGroup
+
+	for i := 0; i < im.limiter.max; i++ {
+		submitters.Add(1)
+
+		go func() {
+			defer submitters.Done()
+
+			for j := range encoded {
+				if im.submit(ctx, j) {
+					submitted <- j
+				}
+			}
+		}()
+
+		confirmers.Add(1)
+
+		go func() {
+			defer confirmers.Done()
+
+			for j := range submitted {
+				im.confirm(ctx, j)
+			}
+		}()
+	}
+
+	submitters.Wait()
+	close(submitted)
+	confirmers.Wait()
+
+	if readErr == nil {
+		readErr = ctx.Err()
+	}
+
+	return im.Stats(), readErr
+}
+
+// produce 读取并编码记录
+func (im *Importer) produce(ctx context.Context, r Reader, out chan<- *job) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rec, err := r.Next(ctx)
+
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return fmt.Errorf("importer: read: %w", err)
+		}
+
+		atomic.AddInt64(&im.read, 1)
+
+		payload := string(rec.Data)
+
+		if im.cfg.Encoder != nil {
+			if payload, err = im.cfg.Encoder(rec); err != nil {
+				im.done(&Result{Record: rec, Stage: StageEncode, Err: err})
+
+				continue
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case out <- &job{rec: rec, payload: payload}:
+		}
+	}
+}
+
+// submit 在并发限制内提交，限流及可重试错误按退避重试，返回是否提交成功
+func (im *Importer) submit(ctx context.Context, j *job) bool {
+	attempts := im.cfg.Attempts
+
+	if attempts <= 0 {
+		attempts = defaultAttempts
+	}
+
+	backoff := im.cfg.Backoff
+
+	if backoff <= 0 {
+		backoff = defaultBackoff
+	}
+
+	var err error
+
+	for j.attempts < attempts {
+		if j.attempts > 0 {
+			timer := time.NewTimer(backoff << uint(j.attempts-1))
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+			case <-timer.C:
+			}
+		}
+
+		if ctx.Err() != nil {
+			err = ctx.Err()
+
+			break
+		}
+
+		if err = im.limiter.acquire(ctx); err != nil {
+			break
+		}
+
+		j.attempts++
+
+		j.hash, err = im.cfg.Submitter(ctx, j.payload)
+
+		congested := err != nil && (antchain.IsThrottled(err) || antchain.IsRetryable(err))
+
+		im.limiter.release(congested)
+
+		if err == nil {
+			atomic.AddInt64(&im.submitted, 1)
+
+			return true
+		}
+
+		// 限流的请求未被处理，可以安全重试；其它错误(如：网关 502/503/504)时交易可能已上链，重试会重复上链
+		if !antchain.IsThrottled(err) {
+			break
+		}
+
+		atomic.AddInt64(&im.throttled, 1)
+	}
+
+	im.done(&Result{Record: j.rec, Stage: StageSubmit, Attempts: j.attempts, Err: err})
+
+	return false
+}
+
+// confirm 等待交易回执
+func (im *Importer) confirm(ctx context.Context, j *job) {
+	ret := &Result{
+		Record:   j.rec,
+		Hash:     j.hash,
+		Stage:    StageDone,
+		Attempts: j.attempts,
+	}
+
+	if im.cfg.Confirm == nil {
+		im.done(ret)
+
+		return
+	}
+
+	if im.cfg.ConfirmTimeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, im.cfg.ConfirmTimeout)
+		defer cancel()
+	}
+
+	interval := im.cfg.PollInterval
+
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	if _, err := antchain.WaitReceipt(ctx, im.cfg.Confirm, j.hash, interval); err != nil {
+		ret.Stage = StageConfirm
+		ret.Err = err
+
+		im.done(ret)
+
+		return
+	}
+
+	atomic.AddInt64(&im.confirmed, 1)
+
+	im.done(ret)
+}
+
+func (im *Importer) done(ret *Result) {
+	if ret.Err != nil {
+		atomic.AddInt64(&im.failed, 1)
+	}
+
+	if im.cfg.OnResult != nil {
+		im.cfg.OnResult(ret)
+	}
+}
+
+// limiter 自适应并发限制：窗口内拥塞比例超过阈值时并发数减半，否则加一
+type limiter struct {
+	min, max   int
+	window     int
+	congestion float64
+
+	mutex     sync.Mutex
+	cond      *sync.Cond
+	limit     int
+	inflight  int
+	completed int
+	congested int
+}
+
+func newLimiter(min, max, window int, congestion float64) *limiter {
+	l := &limiter{
+		min:        min,
+		max:        max,
+		window:     window,
+		congestion: congestion,
+		limit:      min,
+	}
+
+	l.cond = sync.NewCond(&l.mutex)
+
+	return l
+}
+
+func (l *limiter) acquire(ctx context.Context) error {
+	stop := context.AfterFunc(ctx, func() {
+		l.mutex.Lock()
+		l.cond.Broadcast()
+		l.mutex.Unlock()
+	})
+	defer stop()
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	for l.inflight >= l.limit {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		l.cond.Wait()
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.inflight++
+
+	return nil
+}
+
+func (l *limiter) release(congested bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.inflight--
+	l.completed++
+
+	if congested {
+		l.congested++
+	}
+
+	if l.completed >= l.window {
+		if float64(l.congested)/float64(l.completed) > l.congestion {
+			l.limit /= 2
+		} else {
+			l.limit++
+		}
+
+		if l.limit < l.min {
+			l.limit = l.min
+		}
+
+		if l.limit > l.max {
+			l.limit = l.max
+		}
+
+		l.completed, l.congested = 0, 0
+	}
+
+	l.cond.Broadcast()
+}
+
+func (l *limiter) current() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.limit
+}
@@ -0,0 +1,95 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shenghui0779/antchain"
+)
+
+type fakeQuery struct {
+	antchain.QueryService
+
+	receipt string
+}
+
+func (q *fakeQuery) QueryReceipt(ctx context.Context, hash string) (string, error) {
+	return q.receipt, nil
+}
+
+func run(t *testing.T, cfg *Config, input string) []*Result {
+	t.Helper()
+
+	var (
+		mutex   sync.Mutex
+		results []*Result
+	)
+
+	cfg.Backoff = time.Millisecond
+	cfg.PollInterval = time.Millisecond
+	cfg.OnResult = func(ret *Result) {
+		mutex.Lock()
+		results = append(results, ret)
+		mutex.Unlock()
+	}
+
+	if _, err := New(cfg).Run(context.Background(), NewLineReader(strings.NewReader(input))); err != nil {
+		t.Fatal(err)
+	}
+
+	return results
+}
+
+func TestSubmitRetriesOnlyThrottled(t *testing.T) {
+	var calls int32
+
+	results := run(t, &Config{
+		Submitter: func(ctx context.Context, payload string) (string, error) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				return "", &antchain.ThrottleError{APIError: antchain.APIError{Code: antchain.ErrCodeTooManyRequests, StatusCode: 429}}
+			}
+
+			return "0xhash", nil
+		},
+	}, "a\n")
+
+	if calls != 2 || len(results) != 1 || results[0].Err != nil || results[0].Attempts != 2 {
+		t.Fatalf("calls = %d, results = %+v", calls, results[0])
+	}
+
+	calls = 0
+
+	results = run(t, &Config{
+		Submitter: func(ctx context.Context, payload string) (string, error) {
+			atomic.AddInt32(&calls, 1)
+
+			return "", &antchain.APIError{Code: "503", StatusCode: http.StatusServiceUnavailable}
+		},
+	}, "a\n")
+
+	if calls != 1 || len(results) != 1 || results[0].Stage != StageSubmit || results[0].Err == nil {
+		t.Fatalf("gateway error retried: calls = %d, result = %+v", calls, results[0])
+	}
+}
+
+func TestConfirmFailedReceipt(t *testing.T) {
+	submit := func(ctx context.Context, payload string) (string, error) { return "0xhash", nil }
+
+	results := run(t, &Config{Submitter: submit, Confirm: &fakeQuery{receipt: `{"result":10}`}}, "a\n")
+
+	if len(results) != 1 || results[0].Stage != StageConfirm || !errors.Is(results[0].Err, antchain.ErrTxFailed) {
+		t.Fatalf("result = %+v", results[0])
+	}
+
+	results = run(t, &Config{Submitter: submit, Confirm: &fakeQuery{receipt: `{"result":0}`}}, "a\n")
+
+	if len(results) != 1 || results[0].Stage != StageDone || results[0].Err != nil {
+		t.Fatalf("result = %+v", results[0])
+	}
+}
@@ -0,0 +1,32 @@
+// Package keccak 提供 Keccak-256 杂凑算法(以太坊/Solidity 使用的原始 Keccak 填充，非 FIPS 202 SHA3-256)，
+// 用于计算合约事件签名的 topic 及日志布隆过滤器；实现使用 golang.org/x/crypto/sha3
+package keccak
+
+import (
+	"hash"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// Size Keccak-256 摘要的字节长度
+const Size = 32
+
+// BlockSize Keccak-256 的吸收速率(rate)字节长度
+const BlockSize = 136
+
+// New256 返回 Keccak-256 的 hash.Hash
+func New256() hash.Hash {
+	return sha3.NewLegacyKeccak256()
+}
+
+// Sum256 返回 data 的 Keccak-256 摘要
+func Sum256(data []byte) [Size]byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+
+	var out [Size]byte
+
+	copy(out[:], h.Sum(nil))
+
+	return out
+}
@@ -0,0 +1,62 @@
+package keccak
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+var vectors = []struct {
+	in  string
+	out string
+}{
+	{"", "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+	{"abc", "4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45"},
+	{"The quick brown fox jumps over the lazy dog", "4d741b6f1eb29cb2a9b9911c82f56fa8d73b04959d3d9d222895df6c0b28aa15"},
+	// ERC-20 Transfer 事件的 topic
+	{"Transfer(address,address,uint256)", "ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"},
+}
+
+func TestVectors(t *testing.T) {
+	for _, v := range vectors {
+		sum := Sum256([]byte(v.in))
+
+		if got := hex.EncodeToString(sum[:]); got != v.out {
+			t.Errorf("Sum256(%q) = %s, want %s", v.in, got, v.out)
+		}
+	}
+}
+
+func TestStreaming(t *testing.T) {
+	// 覆盖吸收速率(136字节)边界前后的长度
+	for _, n := range []int{135, 136, 137, 272, 1000} {
+		data := bytes.Repeat([]byte{0xa3}, n)
+		want := Sum256(data)
+
+		for _, chunk := range []int{1, 17, 136, 200} {
+			h := New256()
+
+			for i := 0; i < n; i += chunk {
+				end := i + chunk
+
+				if end > n {
+					end = n
+				}
+
+				h.Write(data[i:end])
+			}
+
+			if got := h.Sum(nil); !bytes.Equal(got, want[:]) {
+				t.Fatalf("len %d chunk %d: %x, want %x", n, chunk, got, want)
+			}
+		}
+	}
+}
+
+func TestSizes(t *testing.T) {
+	h := New256()
+
+	if h.Size() != Size || h.BlockSize() != BlockSize {
+		t.Fatalf("size = %d, block size = %d", h.Size(), h.BlockSize())
+	}
+}
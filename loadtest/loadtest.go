@@ -0,0 +1,309 @@
+// Package loadtest 按目标速率向链上发起存证、合约调用等合成负载，统计端到端延迟分位数并生成报告，
+// 用于在新的链环境上进行可复现的容量测试
+package loadtest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shenghui0779/antchain"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	defaultConcurrency  = 64
+	defaultPollInterval = 500 * time.Millisecond
+)
+
+// Workload 一次负载操作，seq 为从 0 开始的序号，返回交易哈希
+type Workload func(ctx context.Context, seq int) (string, error)
+
+// DepositWorkload 返回存证负载，每次存证 size 字节的随机内容(hex 编码)
+func DepositWorkload(cli antchain.DepositService, size, gas int) Workload {
+	return func(ctx context.Context, seq int) (string, error) {
+		b := make([]byte, (size+1)/2)
+
+		if _, err := rand.Read(b); err != nil {
+			return "", err
+		}
+
+		content := hex.EncodeToString(b)
+
+		if len(content) > size {
+			content = content[:size]
+		}
+
+		return cli.Deposit(ctx, content, gas)
+	}
+}
+
+// ContractWorkload 返回合约调用负载，args 根据序号生成调用参数(inputParams)
+func ContractWorkload(cli antchain.ContractService, contract, methodSign, outTypes string, gas int, args func(seq int) string) Workload {
+	return func(ctx context.Context, seq int) (string, error) {
+		inputParams := "[]"
+
+		if args != nil {
+			inputParams = args(seq)
+		}
+
+		return cli.AsyncCallSolidity(ctx, contract, methodSign, inputParams, outTypes, gas)
+	}
+}
+
+// Config 压测配置
+type Config struct {
+	Rate        float64       // 目标速率(次/秒)
+	Duration    time.Duration // 持续时长，与 Requests 至少设置一个
+	Requests    int           // 请求总数，为 0 则由 Duration 决定
+	Concurrency int           // 最大并发数，默认 64
+	Timeout     time.Duration // 单次操作超时(含等待回执)，为 0 则不超时
+
+	// Confirm 不为空时等待交易回执，延迟按提交到查询到回执计算
+	Confirm      antchain.QueryService
+	PollInterval time.Duration // 查询回执的间隔，默认 500ms
+}
+
+// Latency 延迟统计
+type Latency struct {
+	Min  time.Duration `json:"min"`
+	Mean time.Duration `json:"mean"`
+	P50  time.Duration `json:"p50"`
+	P90  time.Duration `json:"p90"`
+	P95  time.Duration `json:"p95"`
+	P99  time.Duration `json:"p99"`
+	Max  time.Duration `json:"max"`
+}
+
+// Report 压测报告
+type Report struct {
+	Requests   int            `json:"requests"`   // 发起的请求数
+	Succeeded  int            `json:"succeeded"`  // 成功数
+	Failed     int            `json:"failed"`     // 失败数
+	Duration   time.Duration  `json:"duration"`   // 实际耗时
+	TargetRate float64        `json:"targetRate"` // 目标速率
+	Throughput float64        `json:"throughput"` // 实际成功速率(次/秒)
+	Latency    Latency        `json:"latency"`    // 成功请求的延迟
+	Errors     map[string]int `json:"errors"`     // 错误 => 次数
+}
+
+// WriteText 以文本形式输出报告
+func (r *Report) WriteText(w io.Writer) error {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "requests:    %d (succeeded %d, failed %d)\n", r.Requests, r.Succeeded, r.Failed)
+	fmt.Fprintf(&sb, "duration:    %s\n", r.Duration.Round(time.Millisecond))
+	fmt.Fprintf(&sb, "rate:        %.2f/s (target %.2f/s)\n", r.Throughput, r.TargetRate)
+	fmt.Fprintf(&sb, "latency:     min %s, mean %s, max %s\n", r.Latency.Min, r.Latency.Mean, r.Latency.Max)
+	fmt.Fprintf(&sb, "percentiles: p50 %s, p90 %s, p95 %s, p99 %s\n", r.Latency.P50, r.Latency.P90, r.Latency.P95, r.Latency.P99)
+
+	if len(r.Errors) != 0 {
+		keys := make([]string, 0, len(r.Errors))
+
+		for k := range r.Errors {
+			keys = append(keys, k)
+		}
+
+		sort.Slice(keys, func(i, j int) bool {
+			return r.Errors[keys[i]] > r.Errors[keys[j]]
+		})
+
+		sb.WriteString("errors:\n")
+
+		for _, k := range keys {
+			fmt.Fprintf(&sb, "  %6d  %s\n", r.Errors[k], k)
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
+
+type result struct {
+	latency time.Duration
+	err     error
+}
+
+// Run 按 cfg 发起负载并返回报告；请求按固定间隔调度，并发已满时延迟从计划发起时间算起，避免低估排队造成的延迟
+func Run(ctx context.Context, cfg *Config, w Workload) (*Report, error) {
+	if cfg.Rate <= 0 {
+		return nil, errors.New("loadtest: rate must be positive")
+	}
+
+	if cfg.Duration <= 0 && cfg.Requests <= 0 {
+		return nil, errors.New("loadtest: duration or requests required")
+	}
+
+	concurrency := cfg.Concurrency
+
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	interval := time.Duration(float64(time.Second) / cfg.Rate)
+
+	var (
+		wg      sync.WaitGroup
+		mutex   sync.Mutex
+		results []result
+	)
+
+	sem := make(chan struct{}, concurrency)
+	start := time.Now()
+
+	for seq := 0; cfg.Requests <= 0 || seq < cfg.Requests; seq++ {
+		scheduled := start.Add(time.Duration(seq) * interval)
+
+		if cfg.Duration > 0 && scheduled.Sub(start) >= cfg.Duration {
+			break
+		}
+
+		if d := time.Until(scheduled); d > 0 {
+			timer := time.NewTimer(d)
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+			case <-timer.C:
+			}
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+
+		go func(seq int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			err := execute(ctx, cfg, w, seq)
+
+			mutex.Lock()
+			results = append(results, result{latency: time.Since(scheduled), err: err})
+			mutex.Unlock()
+		}(seq)
+	}
+
+	wg.Wait()
+
+	return newReport(cfg.Rate, time.Since(start), results), nil
+}
+
+// execute 执行一次负载操作，需要时等待交易回执
+func execute(ctx context.Context, cfg *Config, w Workload, seq int) error {
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+		defer cancel()
+	}
+
+	hash, err := w(ctx, seq)
+
+	if err != nil || cfg.Confirm == nil {
+		return err
+	}
+
+	interval := cfg.PollInterval
+
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	receipt, err := antchain.WaitReceipt(ctx, cfg.Confirm, hash, interval)
+
+	// 执行失败(回滚)的交易计为失败，错误信息不含交易哈希以便报告按结果码汇总
+	if errors.Is(err, antchain.ErrTxFailed) {
+		return fmt.Errorf("%w: result %d", antchain.ErrTxFailed, gjson.Get(receipt, "result").Int())
+	}
+
+	return err
+}
+
+func newReport(rate float64, elapsed time.Duration, results []result) *Report {
+	r := &Report{
+		Requests:   len(results),
+		Duration:   elapsed,
+		TargetRate: rate,
+		Errors:     make(map[string]int),
+	}
+
+	latencies := make([]time.Duration, 0, len(results))
+
+	var total time.Duration
+
+	for _, v := range results {
+		if v.err != nil {
+			r.Failed++
+			r.Errors[v.err.Error()]++
+
+			continue
+		}
+
+		r.Succeeded++
+
+		latencies = append(latencies, v.latency)
+		total += v.latency
+	}
+
+	if elapsed > 0 {
+		r.Throughput = float64(r.Succeeded) / elapsed.Seconds()
+	}
+
+	if len(latencies) == 0 {
+		return r
+	}
+
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+
+	r.Latency = Latency{
+		Min:  latencies[0],
+		Mean: total / time.Duration(len(latencies)),
+		P50:  percentile(latencies, 0.50),
+		P90:  percentile(latencies, 0.90),
+		P95:  percentile(latencies, 0.95),
+		P99:  percentile(latencies, 0.99),
+		Max:  latencies[len(latencies)-1],
+	}
+
+	return r
+}
+
+// percentile 返回已排序延迟的 p 分位数(nearest-rank)
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	idx := int(math.Ceil(float64(len(sorted))*p)) - 1
+
+	if idx < 0 {
+		idx = 0
+	}
+
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+
+	return sorted[idx]
+}
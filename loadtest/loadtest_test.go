@@ -0,0 +1,40 @@
+package loadtest
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/shenghui0779/antchain"
+)
+
+// receiptChain 按交易哈希返回回执：奇数序号的交易执行失败
+type receiptChain struct {
+	antchain.QueryService
+}
+
+func (receiptChain) QueryReceipt(ctx context.Context, hash string) (string, error) {
+	seq, _ := strconv.Atoi(hash)
+
+	return `{"result":` + strconv.Itoa(seq%2) + `}`, nil
+}
+
+func TestRunCountsFailedReceipts(t *testing.T) {
+	w := func(ctx context.Context, seq int) (string, error) {
+		return strconv.Itoa(seq), nil
+	}
+
+	report, err := Run(context.Background(), &Config{Rate: 1000, Requests: 4, Confirm: receiptChain{}}, w)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Succeeded != 2 || report.Failed != 2 {
+		t.Fatalf("succeeded = %d, failed = %d", report.Succeeded, report.Failed)
+	}
+
+	if n := report.Errors[antchain.ErrTxFailed.Error()+": result 1"]; n != 2 {
+		t.Fatalf("errors = %v", report.Errors)
+	}
+}
@@ -0,0 +1,149 @@
+package antchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrLockHeld 锁已被其它持有者持有
+	ErrLockHeld = errors.New("antchain: lock held by another owner")
+	// ErrLockNotHeld 锁已失效(过期或已被其它持有者获取)
+	ErrLockNotHeld = errors.New("antchain: lock not held")
+	// ErrInvalidLockTTL 锁的有效期过短
+	ErrInvalidLockTTL = errors.New("antchain: invalid lock ttl")
+)
+
+// minLockTTL 锁的最短有效期，续期间隔为 ttl/3
+const minLockTTL = 3 * time.Millisecond
+
+// Locker 分布式锁(如：Redis、etcd，参考 redisstore、etcdlock)，
+// 用于多副本部署时保证区块扫描、token 后台刷新等任务只在一个副本上运行；
+// 锁基于过期时间，持有者暂停(如：GC、网络分区)超过 ttl 时可能与新持有者同时运行，受保护的任务须可重入
+type Locker interface {
+	// TryLock 尝试获取锁，锁在 ttl 后自动过期；已被其它持有者持有时返回 ErrLockHeld
+	TryLock(ctx context.Context, key string, ttl time.Duration) (Lock, error)
+}
+
+// Lock 已获取的锁
+type Lock interface {
+	// Refresh 续期，锁已失效时返回 ErrLockNotHeld
+	Refresh(ctx context.Context, ttl time.Duration) error
+
+	// Unlock 释放锁
+	Unlock(ctx context.Context) error
+}
+
+// RunExclusive 获取锁后执行 fn，执行期间每 ttl/3 续期一次；续期失败时取消 fn 的 ctx 并返回 ErrLockNotHeld，
+// 锁已被其它持有者持有时返回 ErrLockHeld，ttl 小于 3ms 时返回 ErrInvalidLockTTL
+func RunExclusive(ctx context.Context, locker Locker, key string, ttl time.Duration, fn func(ctx context.Context) error) error {
+	if ttl < minLockTTL {
+		return fmt.Errorf("%w: %s, minimum %s", ErrInvalidLockTTL, ttl, minLockTTL)
+	}
+
+	lock, err := locker.TryLock(ctx, key, ttl)
+
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	lost := make(chan error, 1)
+
+	go func() {
+		lost <- keepLock(ctx, lock, ttl)
+
+		cancel()
+	}()
+
+	err = fn(ctx)
+
+	cancel()
+
+	if lerr := <-lost; lerr != nil && (err == nil || errors.Is(err, context.Canceled)) {
+		err = lerr
+	}
+
+	unlockCtx, unlockCancel := context.WithTimeout(context.Background(), ttl)
+	defer unlockCancel()
+
+	lock.Unlock(unlockCtx)
+
+	return err
+}
+
+// keepLock 每 ttl/3 续期一次，直到 ctx 结束或续期失败
+func keepLock(ctx context.Context, lock Lock, ttl time.Duration) error {
+	ticker := time.NewTicker(ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		if err := lock.Refresh(ctx, ttl); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return ErrLockNotHeld
+		}
+	}
+}
+
+// WithLocker 多副本部署时，仅持有锁的副本执行 token 后台刷新(配合 WithTokenStore 共享 token)，
+// 锁的键为 "antchain:keepalive:" + AccessID
+func WithLocker(locker Locker) ClientOption {
+	return func(c *client) {
+		c.locker = locker
+	}
+}
+
+// keepAliveLock 后台刷新使用的锁
+type keepAliveLock struct {
+	locker Locker
+	key    string
+	ttl    time.Duration
+	held   Lock
+}
+
+// hold 获取或续期锁，返回当前是否持有锁
+func (kl *keepAliveLock) hold(ctx context.Context) bool {
+	if kl.held != nil {
+		if err := kl.held.Refresh(ctx, kl.ttl); err == nil {
+			return true
+		}
+
+		kl.held = nil
+	}
+
+	lock, err := kl.locker.TryLock(ctx, kl.key, kl.ttl)
+
+	if err != nil {
+		return false
+	}
+
+	kl.held = lock
+
+	return true
+}
+
+func (kl *keepAliveLock) release() {
+	if kl.held == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), kl.ttl)
+	defer cancel()
+
+	kl.held.Unlock(ctx)
+
+	kl.held = nil
+}
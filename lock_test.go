@@ -0,0 +1,59 @@
+package antchain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// memLock 只记录续期次数的锁
+type memLock struct {
+	refreshed int
+}
+
+func (l *memLock) Refresh(ctx context.Context, ttl time.Duration) error {
+	l.refreshed++
+
+	return nil
+}
+
+func (l *memLock) Unlock(ctx context.Context) error {
+	return nil
+}
+
+type memLocker struct {
+	lock *memLock
+}
+
+func (m *memLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (Lock, error) {
+	m.lock = new(memLock)
+
+	return m.lock, nil
+}
+
+func TestRunExclusiveTTL(t *testing.T) {
+	cases := []struct {
+		ttl time.Duration
+		err error
+	}{
+		{0, ErrInvalidLockTTL},
+		{-time.Second, ErrInvalidLockTTL},
+		{2 * time.Nanosecond, ErrInvalidLockTTL},
+		{time.Second, nil},
+	}
+
+	for _, c := range cases {
+		var ran bool
+
+		err := RunExclusive(context.Background(), new(memLocker), "k", c.ttl, func(ctx context.Context) error {
+			ran = true
+
+			return nil
+		})
+
+		if !errors.Is(err, c.err) || ran != (c.err == nil) {
+			t.Errorf("ttl %s: err = %v, ran = %v", c.ttl, err, ran)
+		}
+	}
+}
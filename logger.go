@@ -0,0 +1,115 @@
+package antchain
+
+import (
+	"context"
+	"time"
+)
+
+// LogLevel 日志级别
+type LogLevel int
+
+const (
+	// LevelDebug 调试
+	LevelDebug LogLevel = iota - 1
+	// LevelInfo 信息
+	LevelInfo
+	// LevelWarn 警告
+	LevelWarn
+	// LevelError 错误
+	LevelError
+)
+
+// String 返回日志级别名称
+func (l LogLevel) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	}
+
+	return "unknown"
+}
+
+// Logger 日志接口，keyvals 为成对的键值(如："method", "DEPOSIT", "duration", d)
+type Logger interface {
+	Log(ctx context.Context, level LogLevel, msg string, keyvals ...interface{})
+}
+
+// LoggerFunc 函数形式的 Logger
+type LoggerFunc func(ctx context.Context, level LogLevel, msg string, keyvals ...interface{})
+
+// Log 输出日志
+func (f LoggerFunc) Log(ctx context.Context, level LogLevel, msg string, keyvals ...interface{}) {
+	f(ctx, level, msg, keyvals...)
+}
+
+type loggerKey struct{}
+
+// ContextWithLogger 返回附加了 Logger 的 context，用于按请求输出日志(如：附带租户、请求ID)
+func ContextWithLogger(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// LoggerFromContext 返回 context 中附加的 Logger，没有则返回 nil
+func LoggerFromContext(ctx context.Context) Logger {
+	l, _ := ctx.Value(loggerKey{}).(Logger)
+
+	return l
+}
+
+// LogHook 输出请求日志的钩子，优先使用 context 中附加的 Logger
+type LogHook struct {
+	logger Logger
+}
+
+// NewLogHook 返回请求日志钩子，logger 为 context 中未附加 Logger 时使用的默认 Logger(可为 nil)
+func NewLogHook(logger Logger) *LogHook {
+	return &LogHook{logger: logger}
+}
+
+func (h *LogHook) loggerFor(ctx context.Context) Logger {
+	if l := LoggerFromContext(ctx); l != nil {
+		return l
+	}
+
+	return h.logger
+}
+
+// Before 输出请求发起日志(不包含请求参数，避免泄露业务数据)
+func (h *LogHook) Before(ctx context.Context, method string, params X) {
+	l := h.loggerFor(ctx)
+
+	if l == nil {
+		return
+	}
+
+	l.Log(ctx, LevelDebug, "antchain request", "method", method)
+}
+
+// After 输出请求结果日志，失败时为 error 级别
+func (h *LogHook) After(ctx context.Context, method string, data string, err error, duration time.Duration) {
+	l := h.loggerFor(ctx)
+
+	if l == nil {
+		return
+	}
+
+	keyvals := []interface{}{"method", method, "duration", duration}
+
+	if md := MetadataFromContext(ctx); md != nil && len(md.Caller) != 0 {
+		keyvals = append(keyvals, "caller", md.Caller)
+	}
+
+	if err != nil {
+		l.Log(ctx, LevelError, "antchain request failed", append(keyvals, "error", err)...)
+
+		return
+	}
+
+	l.Log(ctx, LevelInfo, "antchain request done", keyvals...)
+}
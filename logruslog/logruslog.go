@@ -0,0 +1,47 @@
+// Package logruslog 将 logrus 日志接入 antchain.Logger
+package logruslog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shenghui0779/antchain"
+	"github.com/sirupsen/logrus"
+)
+
+type logger struct {
+	l logrus.FieldLogger
+}
+
+// New 返回基于 logrus 的 antchain.Logger，如：antchain.WithHook(antchain.NewLogHook(logruslog.New(logrus.StandardLogger())))
+func New(l logrus.FieldLogger) antchain.Logger {
+	return &logger{l: l}
+}
+
+// Log 按级别输出日志，keyvals 转换为 logrus.Fields
+func (lr *logger) Log(ctx context.Context, level antchain.LogLevel, msg string, keyvals ...interface{}) {
+	fields := make(logrus.Fields, len(keyvals)/2)
+
+	for i := 0; i < len(keyvals); i += 2 {
+		key := fmt.Sprint(keyvals[i])
+
+		if i+1 < len(keyvals) {
+			fields[key] = keyvals[i+1]
+		} else {
+			fields[key] = nil
+		}
+	}
+
+	entry := lr.l.WithFields(fields).WithContext(ctx)
+
+	switch level {
+	case antchain.LevelDebug:
+		entry.Debug(msg)
+	case antchain.LevelWarn:
+		entry.Warn(msg)
+	case antchain.LevelError:
+		entry.Error(msg)
+	default:
+		entry.Info(msg)
+	}
+}
@@ -0,0 +1,99 @@
+package antchain
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"sync"
+)
+
+// defaultMemoSize Identity、TokenID 计算结果缓存的默认容量
+const defaultMemoSize = 4096
+
+// memo 缓存 Identity、TokenID 的计算结果，避免高频资产处理中重复对相同账户名、token 进行哈希和解析
+type memo struct {
+	mutex      sync.RWMutex
+	identities Cache
+	tokenIDs   Cache
+}
+
+var defaultMemo = newMemo(defaultMemoSize)
+
+func newMemo(size int) *memo {
+	m := new(memo)
+	m.resize(size)
+
+	return m
+}
+
+func (m *memo) resize(size int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if size <= 0 {
+		m.identities, m.tokenIDs = nil, nil
+
+		return
+	}
+
+	m.identities = NewLRUCache(size)
+	m.tokenIDs = NewLRUCache(size)
+}
+
+func (m *memo) caches() (identity, tokenID Cache) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.identities, m.tokenIDs
+}
+
+// identityByName 返回账户名称对应的 Identity(base64)
+func (m *memo) identityByName(name string) string {
+	cache, _ := m.caches()
+
+	if cache != nil {
+		if data, ok := cache.Get(name); ok {
+			return data
+		}
+	}
+
+	sum := sha256.Sum256([]byte(name))
+	data := base64.StdEncoding.EncodeToString(sum[:])
+
+	if cache != nil {
+		cache.Set(name, data, 0)
+	}
+
+	return data
+}
+
+// tokenID 返回 token 对应的 TokenID，缓存其大端字节
+func (m *memo) tokenID(token string) (*TokenID, error) {
+	_, cache := m.caches()
+
+	if cache != nil {
+		if b, ok := cache.Get(token); ok {
+			id := new(TokenID)
+			id.v.SetBytes([]byte(b))
+
+			return id, nil
+		}
+	}
+
+	id, err := ParseUint256Hex(token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if cache != nil {
+		cache.Set(token, string(id.v.Bytes()), 0)
+	}
+
+	return id, nil
+}
+
+// SetMemoSize 设置 GetIdentityByName、GetTokenID 结果缓存的容量(默认：4096)，size<=0 表示不缓存；
+// 调整容量会清空已缓存的结果
+func SetMemoSize(size int) {
+	defaultMemo.resize(size)
+}
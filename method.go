@@ -0,0 +1,65 @@
+package antchain
+
+import "context"
+
+// Method 网关接口(chainCall/chainCallForBiz)的方法名
+type Method string
+
+const (
+	// MethodCreateAccount 创建账户(网关方法名即为 TENANTCREATEACCUNT)
+	MethodCreateAccount Method = "TENANTCREATEACCUNT"
+	// MethodTransfer 转账
+	MethodTransfer Method = "TRANSFERBALANCE"
+	// MethodDeposit 存证
+	MethodDeposit Method = "DEPOSIT"
+	// MethodDeployContract 部署合约
+	MethodDeployContract Method = "DEPLOYCONTRACTFORBIZ"
+	// MethodCallContract 异步调用合约
+	MethodCallContract Method = "CALLCONTRACTBIZASYNC"
+	// MethodLocalCallContract 模拟执行合约调用
+	MethodLocalCallContract Method = "LOCALCALLCONTRACT"
+	// MethodPreResetAccount 账户恢复：预重置
+	MethodPreResetAccount Method = "PRERESETACCOUNT"
+	// MethodResetAccount 账户恢复：重置
+	MethodResetAccount Method = "RESETACCOUNT"
+	// MethodQueryTransaction 查询交易
+	MethodQueryTransaction Method = "QUERYTRANSACTION"
+	// MethodQueryReceipt 查询交易回执
+	MethodQueryReceipt Method = "QUERYRECEIPT"
+	// MethodQueryBlock 查询块头
+	MethodQueryBlock Method = "QUERYBLOCK"
+	// MethodQueryBlockBody 查询块体
+	MethodQueryBlockBody Method = "QUERYBLOCKBODY"
+	// MethodQueryLastBlock 查询最新块高
+	MethodQueryLastBlock Method = "QUERYLASTBLOCK"
+	// MethodQueryAccount 查询账户
+	MethodQueryAccount Method = "QUERYACCOUNT"
+	// MethodQueryDepositCert 查询存证证书
+	MethodQueryDepositCert Method = "QUERYDEPOSITCERT"
+	// MethodCreateNotaryToken 司法存证：创建存证事务(全流程 token)
+	MethodCreateNotaryToken Method = "CREATENOTARYTOKEN"
+	// MethodNotaryText 司法存证：文本存证
+	MethodNotaryText Method = "TEXTNOTARY"
+	// MethodNotaryHash 司法存证：摘要(文件)存证
+	MethodNotaryHash Method = "HASHNOTARY"
+	// MethodQueryNotary 司法存证：查询存证
+	MethodQueryNotary Method = "QUERYNOTARY"
+)
+
+// ChainCall 以 chainCall 方式调用任意网关方法(查询类)，用于 SDK 尚未封装的方法；设置了 WithCallPolicy 时先校验方法及参数
+func (c *client) ChainCall(ctx context.Context, method Method, options ...ChainCallOption) (string, error) {
+	if err := c.checkPolicy(method, options); err != nil {
+		return "", err
+	}
+
+	return c.chainCall(ctx, method, options...)
+}
+
+// ChainCallForBiz 以 chainCallForBiz 方式调用任意网关方法(交易类)，用于 SDK 尚未封装的方法；设置了 WithCallPolicy 时先校验方法及参数
+func (c *client) ChainCallForBiz(ctx context.Context, method Method, options ...ChainCallOption) (string, error) {
+	if err := c.checkPolicy(method, options); err != nil {
+		return "", err
+	}
+
+	return c.chainCallForBiz(ctx, method, options...)
+}
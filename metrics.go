@@ -0,0 +1,133 @@
+package antchain
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+)
+
+// Metrics 监控指标上报，可对接 Prometheus 等监控系统
+type Metrics interface {
+	// IncCounter 累加计数器
+	IncCounter(name string, value float64, labels map[string]string)
+
+	// SetGauge 设置仪表盘的值
+	SetGauge(name string, value float64, labels map[string]string)
+}
+
+const (
+	// MetricTokenCacheHits token 缓存命中次数(计数器)
+	MetricTokenCacheHits = "antchain_token_cache_hits"
+	// MetricTokenCacheMisses token 缓存未命中(需要 shakehand)次数(计数器)
+	MetricTokenCacheMisses = "antchain_token_cache_misses"
+	// MetricTokenAge 当前使用的 token 已签发的秒数(仪表盘)
+	MetricTokenAge = "antchain_token_age_seconds"
+	// MetricRequestsInFlight 正在进行的 HTTP 请求数(仪表盘)
+	MetricRequestsInFlight = "antchain_requests_in_flight"
+	// MetricConnsOpen 已建立的连接数(仪表盘，仅默认 http.Client 上报)
+	MetricConnsOpen = "antchain_conns_open"
+	// MetricConnsIdle 空闲连接数(仪表盘，按 已建立连接数-进行中请求数 估算，仅默认 http.Client 上报)
+	MetricConnsIdle = "antchain_conns_idle"
+)
+
+// WithMetrics 设置监控指标上报，上报 token 缓存及连接池相关指标
+func WithMetrics(m Metrics) ClientOption {
+	return func(c *client) {
+		c.metrics = m
+	}
+}
+
+// poolStats 连接池统计
+type poolStats struct {
+	open     int64
+	inflight int64
+	tracked  bool // 是否统计连接数(使用默认 http.Client 时)
+}
+
+// countingConn 关闭时更新连接数的连接
+type countingConn struct {
+	net.Conn
+
+	once    sync.Once
+	onClose func()
+}
+
+func (cc *countingConn) Close() error {
+	cc.once.Do(cc.onClose)
+
+	return cc.Conn.Close()
+}
+
+// countingDial 包装 DialContext 以统计已建立的连接数
+func (c *client) countingDial(dial DialContextFunc) DialContextFunc {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+
+		if err != nil {
+			return nil, err
+		}
+
+		atomic.AddInt64(&c.pool.open, 1)
+		c.reportPool()
+
+		return &countingConn{
+			Conn: conn,
+			onClose: func() {
+				atomic.AddInt64(&c.pool.open, -1)
+				c.reportPool()
+			},
+		}, nil
+	}
+}
+
+// requestStarted 标记 HTTP 请求开始，返回请求结束时调用的函数
+func (c *client) requestStarted() func() {
+	atomic.AddInt64(&c.pool.inflight, 1)
+	c.reportPool()
+
+	return func() {
+		atomic.AddInt64(&c.pool.inflight, -1)
+		c.reportPool()
+	}
+}
+
+func (c *client) reportPool() {
+	if c.metrics == nil {
+		return
+	}
+
+	open := atomic.LoadInt64(&c.pool.open)
+	inflight := atomic.LoadInt64(&c.pool.inflight)
+
+	c.metrics.SetGauge(MetricRequestsInFlight, float64(inflight), nil)
+
+	if !c.pool.tracked {
+		return
+	}
+
+	idle := open - inflight
+
+	if idle < 0 {
+		idle = 0
+	}
+
+	c.metrics.SetGauge(MetricConnsOpen, float64(open), nil)
+	c.metrics.SetGauge(MetricConnsIdle, float64(idle), nil)
+}
+
+// reportToken 上报 token 缓存命中情况
+func (c *client) reportToken(token *accessToken, hit bool) {
+	if c.metrics == nil {
+		return
+	}
+
+	if !hit {
+		c.metrics.IncCounter(MetricTokenCacheMisses, 1, nil)
+
+		return
+	}
+
+	c.metrics.IncCounter(MetricTokenCacheHits, 1, nil)
+	c.metrics.SetGauge(MetricTokenAge, c.now().Sub(token.issuedAt).Seconds(), nil)
+}
@@ -0,0 +1,221 @@
+package antchain
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultMultiSigTTL 多签交易默认的有效期
+	defaultMultiSigTTL = 10 * time.Minute
+)
+
+var (
+	// ErrNotEnoughSignatures 多签交易的签名数量未达到门限
+	ErrNotEnoughSignatures = errors.New("antchain: not enough signatures")
+	// ErrUnauthorizedSigner 签名方不在多签交易允许的签名方之列
+	ErrUnauthorizedSigner = errors.New("antchain: unauthorized signer")
+	// ErrMultiSigExpired 多签交易已过期
+	ErrMultiSigExpired = errors.New("antchain: multisig transaction expired")
+)
+
+// Signature 签名方公钥及其签名(均为hex)
+type Signature struct {
+	PublicKey string `json:"publicKey"`
+	Signature string `json:"signature"`
+}
+
+// MultiSigRequest 多签交易的参数
+type MultiSigRequest struct {
+	Method    Method
+	Threshold int               // 所需签名数(m)
+	Signers   []string          // 允许签名的公钥(n，hex，见 TxSigner.PublicKey)
+	TTL       time.Duration     // 有效期，默认 10 分钟，过期后不能再签名及提交
+	Options   []ChainCallOption // 交易参数
+	Clock     Clock             // 时间源，用于计算及判断过期时间，默认系统时间
+}
+
+// MultiSigTx 需要 m-of-n 多方签名的交易：
+// 发起方构造交易并分发 Payload，各签名方签名后通过 AddSignature 收集，达到门限后提交；
+// Payload 包含随机 nonce 及过期时间，签名不能被重放到其它交易；
+// 注意：签名的收集及门限校验仅在客户端完成，网关及链上不会校验这些签名，提交时只发送原交易参数，
+// 签名可通过 Signatures 留存以备审计
+type MultiSigTx struct {
+	method    Method
+	params    X
+	threshold int
+	signers   map[string]bool
+	nonce     string
+	expireAt  time.Time
+	clock     Clock
+
+	mutex      sync.Mutex
+	signatures map[string]string
+}
+
+// NewMultiSigTx 返回多签交易
+func NewMultiSigTx(req *MultiSigRequest) (*MultiSigTx, error) {
+	if req.Threshold <= 0 || req.Threshold > len(req.Signers) {
+		return nil, fmt.Errorf("antchain: invalid multisig threshold %d of %d signers", req.Threshold, len(req.Signers))
+	}
+
+	params := make(X)
+
+	for _, f := range req.Options {
+		f(params)
+	}
+
+	signers := make(map[string]bool, len(req.Signers))
+
+	for _, v := range req.Signers {
+		signers[strings.ToLower(v)] = true
+	}
+
+	b := make([]byte, 16)
+
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+
+	ttl := req.TTL
+
+	if ttl <= 0 {
+		ttl = defaultMultiSigTTL
+	}
+
+	clock := req.Clock
+
+	if clock == nil {
+		clock = systemClock{}
+	}
+
+	return &MultiSigTx{
+		method:     req.Method,
+		params:     params,
+		threshold:  req.Threshold,
+		signers:    signers,
+		nonce:      hex.EncodeToString(b),
+		expireAt:   clock.Now().Add(ttl).Truncate(time.Millisecond),
+		clock:      clock,
+		signatures: make(map[string]string),
+	}, nil
+}
+
+// Payload 返回待签名的规范化交易数据(JSON，key 有序)
+func (tx *MultiSigTx) Payload() ([]byte, error) {
+	b, err := json.Marshal(X{
+		"method":   tx.method,
+		"params":   tx.params,
+		"nonce":    tx.nonce,
+		"expireAt": tx.expireAt.UnixMilli(),
+	})
+
+	if err != nil {
+		return nil, wrapErr(ErrDecodeFailed, err)
+	}
+
+	return b, nil
+}
+
+// ExpireAt 返回多签交易的过期时间
+func (tx *MultiSigTx) ExpireAt() time.Time {
+	return tx.expireAt
+}
+
+// AddSignature 校验并添加签名方对 Payload 的签名，签名方须在 Signers 之列；同一公钥重复添加时覆盖
+func (tx *MultiSigTx) AddSignature(pubKey, signature string) error {
+	if len(pubKey) == 0 || len(signature) == 0 {
+		return errors.New("antchain: empty public key or signature")
+	}
+
+	pubKey = strings.ToLower(pubKey)
+
+	if !tx.signers[pubKey] {
+		return fmt.Errorf("%w: %.16s...", ErrUnauthorizedSigner, pubKey)
+	}
+
+	if !tx.clock.Now().Before(tx.expireAt) {
+		return ErrMultiSigExpired
+	}
+
+	payload, err := tx.Payload()
+
+	if err != nil {
+		return err
+	}
+
+	if err = VerifyTxSignature(pubKey, signature, payload); err != nil {
+		return err
+	}
+
+	tx.mutex.Lock()
+	defer tx.mutex.Unlock()
+
+	tx.signatures[pubKey] = signature
+
+	return nil
+}
+
+// Ready 未过期且已校验的签名数量达到门限
+func (tx *MultiSigTx) Ready() bool {
+	if !tx.clock.Now().Before(tx.expireAt) {
+		return false
+	}
+
+	tx.mutex.Lock()
+	defer tx.mutex.Unlock()
+
+	return len(tx.signatures) >= tx.threshold
+}
+
+// Signatures 返回已收集的签名(按公钥排序)
+func (tx *MultiSigTx) Signatures() []Signature {
+	tx.mutex.Lock()
+	defer tx.mutex.Unlock()
+
+	list := make([]Signature, 0, len(tx.signatures))
+
+	for k, v := range tx.signatures {
+		list = append(list, Signature{
+			PublicKey: k,
+			Signature: v,
+		})
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].PublicKey < list[j].PublicKey
+	})
+
+	return list
+}
+
+func (c *client) SubmitMultiSig(ctx context.Context, tx *MultiSigTx) (string, error) {
+	if !tx.clock.Now().Before(tx.expireAt) {
+		return "", ErrMultiSigExpired
+	}
+
+	if !tx.Ready() {
+		return "", fmt.Errorf("%w: %d/%d", ErrNotEnoughSignatures, len(tx.Signatures()), tx.threshold)
+	}
+
+	options := make([]ChainCallOption, 0, len(tx.params))
+
+	for k, v := range tx.params {
+		options = append(options, WithParam(k, v))
+	}
+
+	// 多签交易的方法及参数由发起方指定，与 ChainCallForBiz 一样受 CallPolicy 约束
+	if err := c.checkPolicy(tx.method, options); err != nil {
+		return "", err
+	}
+
+	return c.chainCallForBiz(ctx, tx.method, options...)
+}
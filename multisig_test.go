@@ -0,0 +1,235 @@
+package antchain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// newSignedMultiSig 返回 2-of-3 多签交易及三个签名方
+func newSignedMultiSig(t *testing.T, method Method, options ...ChainCallOption) (*MultiSigTx, []TxSigner) {
+	t.Helper()
+
+	signers := []TxSigner{testECDSASigner(t), testECDSASigner(t), NewRSATxSigner(testPrivateKey(t))}
+	pubKeys := make([]string, 0, len(signers))
+
+	for _, s := range signers {
+		pub, err := s.PublicKey()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pubKeys = append(pubKeys, pub)
+	}
+
+	tx, err := NewMultiSigTx(&MultiSigRequest{
+		Method:    method,
+		Threshold: 2,
+		Signers:   pubKeys,
+		Options:   options,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return tx, signers
+}
+
+func testPrivateKey(t *testing.T) *PrivateKey {
+	t.Helper()
+
+	pk, err := NewPrivateKeyFromPemFile(testKeyFile(t))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pk
+}
+
+func sign(t *testing.T, tx *MultiSigTx, s TxSigner) (string, string) {
+	t.Helper()
+
+	payload, err := tx.Payload()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pub, _ := s.PublicKey()
+
+	sig, err := s.SignTx(payload)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pub, sig
+}
+
+func TestMultiSigVerifiesSignatures(t *testing.T) {
+	tx, signers := newSignedMultiSig(t, MethodCallContract)
+
+	pub0, sig0 := sign(t, tx, signers[0])
+	pub1, _ := sign(t, tx, signers[1])
+
+	// 签名与公钥不匹配
+	if err := tx.AddSignature(pub1, sig0); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("err = %v, want ErrInvalidSignature", err)
+	}
+
+	// 不在签名方之列
+	outsider := testECDSASigner(t)
+	pubX, sigX := sign(t, tx, outsider)
+
+	if err := tx.AddSignature(pubX, sigX); !errors.Is(err, ErrUnauthorizedSigner) {
+		t.Fatalf("err = %v, want ErrUnauthorizedSigner", err)
+	}
+
+	if err := tx.AddSignature(pub0, sig0); err != nil {
+		t.Fatal(err)
+	}
+
+	if tx.Ready() {
+		t.Fatal("ready with 1 of 2 signatures")
+	}
+
+	if err := tx.AddSignature(sign(t, tx, signers[2])); err != nil {
+		t.Fatal(err)
+	}
+
+	if !tx.Ready() {
+		t.Fatal("not ready with 2 of 2 signatures")
+	}
+}
+
+func TestMultiSigNonceAndExpiry(t *testing.T) {
+	tx1, signers := newSignedMultiSig(t, MethodCallContract)
+
+	// 相同交易参数的签名不能用于另一笔多签交易
+	pub, sig := sign(t, tx1, signers[0])
+
+	tx2, err := NewMultiSigTx(&MultiSigRequest{Method: MethodCallContract, Threshold: 1, Signers: []string{pub}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = tx2.AddSignature(pub, sig); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("replayed signature: err = %v", err)
+	}
+
+	if err = tx1.AddSignature(pub, sig); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = NewMultiSigTx(&MultiSigRequest{Threshold: 2, Signers: []string{pub}}); err == nil {
+		t.Fatal("expected threshold error")
+	}
+}
+
+func TestSubmitMultiSig(t *testing.T) {
+	gw := newTestGateway(t, func(params X) (interface{}, bool) { return "0xhash", true })
+
+	cli := newTestClient(t, gw)
+
+	tx, signers := newSignedMultiSig(t, MethodCallContract, WithParam("contractName", "c"))
+
+	for _, s := range signers[:2] {
+		if err := tx.AddSignature(sign(t, tx, s)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := cli.SubmitMultiSig(context.Background(), tx); err != nil {
+		t.Fatal(err)
+	}
+
+	req := gw.last()
+
+	if req["contractName"] != "c" {
+		t.Fatalf("request = %v", req)
+	}
+
+	// 签名仅在客户端校验，不发送网关未定义的参数
+	for _, k := range []string{"multiSigNonce", "multiSigExpireAt", "signatureList"} {
+		if _, ok := req[k]; ok {
+			t.Fatalf("unexpected param %s: %v", k, req)
+		}
+	}
+}
+
+func TestSubmitMultiSigChecksPolicy(t *testing.T) {
+	gw := newTestGateway(t, func(params X) (interface{}, bool) { return "0xhash", true })
+
+	cli := newTestClient(t, gw, WithCallPolicy(&CallPolicy{Deny: []Method{MethodCallContract}}))
+
+	tx, signers := newSignedMultiSig(t, MethodCallContract, WithParam("contractName", "c"))
+
+	for _, s := range signers[:2] {
+		if err := tx.AddSignature(sign(t, tx, s)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := cli.SubmitMultiSig(context.Background(), tx); !errors.Is(err, ErrMethodNotAllowed) {
+		t.Fatalf("err = %v, want ErrMethodNotAllowed", err)
+	}
+
+	if gw.last() != nil {
+		t.Fatal("denied multisig transaction was sent")
+	}
+}
+
+func TestMultiSigClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name    string
+		elapsed time.Duration
+		expired bool
+	}{
+		{"fresh", 0, false},
+		{"before expiry", defaultMultiSigTTL - time.Millisecond, false},
+		{"at expiry", defaultMultiSigTTL, true},
+		{"after expiry", time.Hour, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			signer := testECDSASigner(t)
+			pub, _ := signer.PublicKey()
+			now := start
+
+			tx, err := NewMultiSigTx(&MultiSigRequest{
+				Method:    MethodCallContract,
+				Threshold: 1,
+				Signers:   []string{pub},
+				Clock:     ClockFunc(func() time.Time { return now }),
+			})
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !tx.ExpireAt().Equal(start.Add(defaultMultiSigTTL)) {
+				t.Fatalf("expireAt = %s", tx.ExpireAt())
+			}
+
+			now = start.Add(c.elapsed)
+
+			err = tx.AddSignature(sign(t, tx, signer))
+
+			if got := errors.Is(err, ErrMultiSigExpired); got != c.expired {
+				t.Fatalf("err = %v, expired = %v", err, c.expired)
+			}
+
+			if tx.Ready() == c.expired {
+				t.Fatalf("ready = %v", tx.Ready())
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package antchain
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// NonceFetcher 查询账户在链上的下一个可用 nonce
+type NonceFetcher func(ctx context.Context, account string) (uint64, error)
+
+// NonceManager 为自行构造并签名交易的调用方分配 nonce，使同一账户的并发提交不冲突；
+// 提交被网关明确拒绝(如：nonce不匹配)时重置，下次分配前重新从链上获取；
+// 各账户分别加锁，某个账户从链上获取 nonce 时不阻塞其它账户
+type NonceManager struct {
+	fetch    NonceFetcher
+	mutex    sync.Mutex
+	accounts map[string]*accountNonce
+}
+
+// accountNonce 账户本地记录的 nonce，valid 为 false 时需从链上获取
+type accountNonce struct {
+	mutex sync.Mutex
+	next  uint64
+	valid bool
+}
+
+// NewNonceManager 返回 NonceManager
+func NewNonceManager(fetch NonceFetcher) *NonceManager {
+	return &NonceManager{
+		fetch:    fetch,
+		accounts: make(map[string]*accountNonce),
+	}
+}
+
+func (m *NonceManager) account(account string) *accountNonce {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	a, ok := m.accounts[account]
+
+	if !ok {
+		a = new(accountNonce)
+		m.accounts[account] = a
+	}
+
+	return a
+}
+
+// Next 返回账户的下一个 nonce
+func (m *NonceManager) Next(ctx context.Context, account string) (uint64, error) {
+	a := m.account(account)
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if !a.valid {
+		v, err := m.fetch(ctx, account)
+
+		if err != nil {
+			return 0, err
+		}
+
+		a.next = v
+		a.valid = true
+	}
+
+	nonce := a.next
+	a.next++
+
+	return nonce, nil
+}
+
+// Reset 丢弃本地记录的 nonce
+func (m *NonceManager) Reset(account string) {
+	a := m.account(account)
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.valid = false
+}
+
+// Done 在提交结束后调用：仅当提交被网关明确拒绝时重置；
+// 超时、网络错误及网关 5xx 等结果未知的情况下交易可能已被接受，此时重置会导致 nonce 被重复分配，因此保留本地记录
+func (m *NonceManager) Done(account string, err error) {
+	if rejected(err) {
+		m.Reset(account)
+	}
+}
+
+// rejected 判断提交是否被网关明确拒绝(交易未被接受)：网关返回了业务错误或 4xx 响应
+func rejected(err error) bool {
+	var ae *APIError
+
+	if !errors.As(err, &ae) {
+		return false
+	}
+
+	return ae.StatusCode < 500
+}
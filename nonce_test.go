@@ -0,0 +1,89 @@
+package antchain
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNonceManagerDone(t *testing.T) {
+	cases := []struct {
+		name  string
+		err   error
+		reset bool
+	}{
+		{"success", nil, false},
+		{"business rejection", &APIError{Code: "GW_NONCE", StatusCode: 200}, true},
+		{"throttled", &ThrottleError{APIError: APIError{Code: ErrCodeTooManyRequests, StatusCode: 429}}, true},
+		{"gateway unavailable", &APIError{Code: "502", StatusCode: 502}, false},
+		{"timeout", context.DeadlineExceeded, false},
+		{"network", wrapErr(ErrRequestFailed, errors.New("connection reset")), false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var fetches int32
+
+			m := NewNonceManager(func(ctx context.Context, account string) (uint64, error) {
+				atomic.AddInt32(&fetches, 1)
+
+				return 10, nil
+			})
+
+			if n, err := m.Next(context.Background(), "a"); err != nil || n != 10 {
+				t.Fatalf("nonce = %d, err = %v", n, err)
+			}
+
+			m.Done("a", c.err)
+
+			n, err := m.Next(context.Background(), "a")
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := uint64(11)
+
+			if c.reset {
+				want = 10
+			}
+
+			if n != want || (fetches == 2) != c.reset {
+				t.Fatalf("nonce = %d, fetches = %d", n, fetches)
+			}
+		})
+	}
+}
+
+func TestNonceManagerFetchDoesNotBlockOtherAccounts(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+
+	m := NewNonceManager(func(ctx context.Context, account string) (uint64, error) {
+		if account == "slow" {
+			<-block
+		}
+
+		return 1, nil
+	})
+
+	go m.Next(context.Background(), "slow")
+
+	// 等待慢账户进入 fetch
+	time.Sleep(10 * time.Millisecond)
+
+	done := make(chan struct{})
+
+	go func() {
+		m.Next(context.Background(), "fast")
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("fetch for one account blocked another")
+	}
+}
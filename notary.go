@@ -0,0 +1,163 @@
+package antchain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/tidwall/gjson"
+)
+
+// NotaryCustomerType 司法存证的用户类型
+type NotaryCustomerType string
+
+const (
+	// NotaryPerson 个人
+	NotaryPerson NotaryCustomerType = "PERSON"
+	// NotaryEnterprise 企业
+	NotaryEnterprise NotaryCustomerType = "ENTERPRISE"
+)
+
+// NotaryCustomer 司法存证的实名用户
+type NotaryCustomer struct {
+	Type     NotaryCustomerType `json:"userType"`
+	Name     string             `json:"certName"`           // 姓名或企业名称
+	CertType string             `json:"certType,omitempty"` // 证件类型(如：IDENTITY_CARD、UNIFIED_SOCIAL_CREDIT_CODE)
+	CertNo   string             `json:"certNo"`             // 证件号码
+	Mobile   string             `json:"mobileNo,omitempty"`
+	LegalRep string             `json:"legalPerson,omitempty"` // 企业法定代表人
+}
+
+// NotaryEvidence 司法存证结果
+type NotaryEvidence struct {
+	EvidenceID string `json:"evidenceId"` // 存证编号(出证、核验时使用)
+	TxHash     string `json:"txHash"`     // 存证交易hash
+	Token      string `json:"token"`      // 所属存证事务的 token
+	Phase      string `json:"phase"`      // 存证环节
+	Timestamp  int64  `json:"timestamp"`  // 存证时间(毫秒时间戳)
+}
+
+// NotaryTokenRequest 创建司法存证事务的参数
+type NotaryTokenRequest struct {
+	BizType    string          // 业务类型(如：合同签署、版权登记)
+	SubBizType string          // 子业务类型
+	Customer   *NotaryCustomer // 存证实名用户
+	Properties string          // 扩展信息(JSON)
+}
+
+// CreateNotaryToken 创建司法存证事务，返回的 token 串联该业务的各个存证环节(phase)
+func (c *client) CreateNotaryToken(ctx context.Context, req *NotaryTokenRequest) (string, error) {
+	if req == nil || req.Customer == nil {
+		return "", errors.New("antchain: notary customer is required")
+	}
+
+	customer, err := json.Marshal(req.Customer)
+
+	if err != nil {
+		return "", wrapErr(ErrDecodeFailed, err)
+	}
+
+	data, err := c.chainCallForBiz(ctx, MethodCreateNotaryToken,
+		WithParam("bizType", req.BizType),
+		WithParam("subBizType", req.SubBizType),
+		WithParam("customer", string(customer)),
+		WithParam("properties", req.Properties),
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	// 兼容直接返回 token 及返回 {"token": ...}
+	if v := gjson.Get(data, "token"); v.Exists() {
+		return v.String(), nil
+	}
+
+	return data, nil
+}
+
+// SubmitNotaryText 在存证事务的 phase 环节提交文本存证
+func (c *client) SubmitNotaryText(ctx context.Context, token, phase, content string) (*NotaryEvidence, error) {
+	data, err := c.chainCallForBiz(ctx, MethodNotaryText,
+		WithParam("notaryToken", token), // token 为 call 使用的访问令牌
+		WithParam("phase", phase),
+		WithParam("notaryContent", content),
+		WithParam("timestamp", c.now().UnixMilli()),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parseNotaryEvidence(data, token, phase)
+}
+
+// SubmitNotaryDigest 在存证事务的 phase 环节提交文件摘要存证(见 HashFile、HashReader)
+func (c *client) SubmitNotaryDigest(ctx context.Context, token, phase string, digest *Digest) (*NotaryEvidence, error) {
+	if digest == nil {
+		return nil, errors.New("antchain: notary digest is required")
+	}
+
+	data, err := c.chainCallForBiz(ctx, MethodNotaryHash,
+		WithParam("notaryToken", token), // token 为 call 使用的访问令牌
+		WithParam("phase", phase),
+		WithParam("hashAlgorithm", string(digest.Algorithm)),
+		WithParam("notaryContent", digest.Hex()),
+		WithParam("fileSize", digest.Size),
+		WithParam("timestamp", c.now().UnixMilli()),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parseNotaryEvidence(data, token, phase)
+}
+
+// GetNotaryEvidence 根据存证编号查询司法存证
+func (c *client) GetNotaryEvidence(ctx context.Context, evidenceID string) (*NotaryEvidence, error) {
+	data, err := c.chainCall(ctx, MethodQueryNotary, WithParam("evidenceId", evidenceID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	ev := new(NotaryEvidence)
+
+	if err = json.Unmarshal([]byte(data), ev); err != nil {
+		return nil, wrapErr(ErrDecodeFailed, err)
+	}
+
+	return ev, nil
+}
+
+// parseNotaryEvidence 解析存证结果，兼容直接返回存证编号及返回 {"evidenceId","txHash"}
+func parseNotaryEvidence(data, token, phase string) (*NotaryEvidence, error) {
+	ev := &NotaryEvidence{
+		Token: token,
+		Phase: phase,
+	}
+
+	ret := gjson.Parse(data)
+
+	if !ret.IsObject() {
+		if len(data) == 0 {
+			return nil, wrapErr(ErrDecodeFailed, errors.New("empty notary evidence"))
+		}
+
+		ev.EvidenceID = data
+
+		return ev, nil
+	}
+
+	ev.EvidenceID = ret.Get("evidenceId").String()
+	ev.TxHash = ret.Get("txHash").String()
+	ev.Timestamp = ret.Get("timestamp").Int()
+
+	if len(ev.EvidenceID) == 0 {
+		return nil, wrapErr(ErrDecodeFailed, fmt.Errorf("evidenceId not found: %.256s", data))
+	}
+
+	return ev, nil
+}
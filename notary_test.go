@@ -0,0 +1,195 @@
+package antchain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+func TestCreateNotaryToken(t *testing.T) {
+	customer := &NotaryCustomer{Type: NotaryPerson, Name: "张三", CertNo: "110101199001011234"}
+
+	cases := []struct {
+		name string
+		req  *NotaryTokenRequest
+		resp string
+		want string
+		ok   bool
+	}{
+		{"plain token", &NotaryTokenRequest{BizType: "contract", Customer: customer}, "nt-1", "nt-1", true},
+		{"object token", &NotaryTokenRequest{BizType: "contract", Customer: customer}, `{"token":"nt-2"}`, "nt-2", true},
+		{"nil request", nil, "nt-1", "", false},
+		{"no customer", &NotaryTokenRequest{BizType: "contract"}, "nt-1", "", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			gw := newTestGateway(t, func(params X) (interface{}, bool) { return c.resp, true })
+
+			cli := newTestClient(t, gw)
+
+			token, err := cli.CreateNotaryToken(context.Background(), c.req)
+
+			if !c.ok {
+				if err == nil || gw.last() != nil {
+					t.Fatalf("token = %q, err = %v, request = %v", token, err, gw.last())
+				}
+
+				return
+			}
+
+			if err != nil || token != c.want {
+				t.Fatalf("token = %q, err = %v", token, err)
+			}
+
+			req := gw.last()
+
+			if gw.lastPath() != CHAIN_CALL_FOR_BIZ || req["method"] != string(MethodCreateNotaryToken) || req["bizType"] != "contract" {
+				t.Fatalf("path = %s, request = %v", gw.lastPath(), req)
+			}
+
+			if name := gjson.Get(req["customer"].(string), "certName").String(); name != customer.Name {
+				t.Fatalf("customer = %v", req["customer"])
+			}
+		})
+	}
+}
+
+func TestSubmitNotaryEvidence(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	digest, err := HashBytes(HashSHA256, []byte("file"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name    string
+		submit  func(cli *client) (*NotaryEvidence, error)
+		method  Method
+		content string
+	}{
+		{"text", func(cli *client) (*NotaryEvidence, error) {
+			return cli.SubmitNotaryText(context.Background(), "notary-token", "phase-1", "content")
+		}, MethodNotaryText, "content"},
+		{"digest", func(cli *client) (*NotaryEvidence, error) {
+			return cli.SubmitNotaryDigest(context.Background(), "notary-token", "phase-1", digest)
+		}, MethodNotaryHash, digest.Hex()},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			gw := newTestGateway(t, func(params X) (interface{}, bool) {
+				return `{"evidenceId":"ev-1","txHash":"tx-1"}`, true
+			})
+
+			cli := newTestClient(t, gw, WithClock(ClockFunc(func() time.Time { return now })))
+
+			ev, err := c.submit(cli)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if ev.EvidenceID != "ev-1" || ev.TxHash != "tx-1" || ev.Token != "notary-token" || ev.Phase != "phase-1" {
+				t.Fatalf("evidence = %+v", ev)
+			}
+
+			req := gw.last()
+
+			// 存证事务的 token 不能覆盖 call 使用的访问令牌
+			if req["notaryToken"] != "notary-token" || req["token"] != "access-token" {
+				t.Fatalf("notaryToken = %v, token = %v", req["notaryToken"], req["token"])
+			}
+
+			if req["method"] != string(c.method) || req["phase"] != "phase-1" || req["notaryContent"] != c.content {
+				t.Fatalf("unexpected request %v", req)
+			}
+
+			if ts, _ := req["timestamp"].(float64); int64(ts) != now.UnixMilli() {
+				t.Fatalf("timestamp = %v", req["timestamp"])
+			}
+		})
+	}
+
+	cli := newTestClient(t, newTestGateway(t, func(params X) (interface{}, bool) { return "", true }))
+
+	if _, err = cli.SubmitNotaryDigest(context.Background(), "notary-token", "phase-1", nil); err == nil {
+		t.Fatal("expected error for nil digest")
+	}
+}
+
+func TestParseNotaryEvidence(t *testing.T) {
+	cases := []struct {
+		name string
+		data string
+		want NotaryEvidence
+		ok   bool
+	}{
+		{"plain id", "ev-1", NotaryEvidence{EvidenceID: "ev-1", Token: "t", Phase: "p"}, true},
+		{"object", `{"evidenceId":"ev-2","txHash":"tx","timestamp":1700000000000}`,
+			NotaryEvidence{EvidenceID: "ev-2", TxHash: "tx", Token: "t", Phase: "p", Timestamp: 1700000000000}, true},
+		{"object without id", `{"txHash":"tx"}`, NotaryEvidence{}, false},
+		{"empty", "", NotaryEvidence{}, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ev, err := parseNotaryEvidence(c.data, "t", "p")
+
+			if !c.ok {
+				if !errors.Is(err, ErrDecodeFailed) {
+					t.Fatalf("err = %v, want ErrDecodeFailed", err)
+				}
+
+				return
+			}
+
+			if err != nil || *ev != c.want {
+				t.Fatalf("evidence = %+v, err = %v", ev, err)
+			}
+		})
+	}
+}
+
+func TestGetNotaryEvidence(t *testing.T) {
+	cases := []struct {
+		name string
+		resp string
+		want string
+		err  error
+	}{
+		{"found", `{"evidenceId":"ev-1","txHash":"tx-1","phase":"phase-1"}`, "tx-1", nil},
+		{"invalid", "not json", "", ErrDecodeFailed},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			gw := newTestGateway(t, func(params X) (interface{}, bool) { return c.resp, true })
+
+			cli := newTestClient(t, gw)
+
+			ev, err := cli.GetNotaryEvidence(context.Background(), "ev-1")
+
+			if c.err != nil {
+				if !errors.Is(err, c.err) {
+					t.Fatalf("err = %v, want %v", err, c.err)
+				}
+
+				return
+			}
+
+			if err != nil || ev.TxHash != c.want {
+				t.Fatalf("evidence = %+v, err = %v", ev, err)
+			}
+
+			if req := gw.last(); gw.lastPath() != CHAIN_CALL || req["method"] != string(MethodQueryNotary) || req["evidenceId"] != "ev-1" {
+				t.Fatalf("path = %s, request = %v", gw.lastPath(), req)
+			}
+		})
+	}
+}
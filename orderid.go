@@ -0,0 +1,34 @@
+package antchain
+
+import "github.com/google/uuid"
+
+// IDGenerator chainCallForBiz 请求的 orderId 生成器(如：ULID、snowflake，测试中可返回固定序列)；
+// 网关根据 orderId 去重，生成的 ID 须保证唯一
+type IDGenerator interface {
+	NewID() string
+}
+
+// IDGeneratorFunc 函数形式的 IDGenerator
+type IDGeneratorFunc func() string
+
+// NewID 返回新的 ID
+func (f IDGeneratorFunc) NewID() string {
+	return f()
+}
+
+type uuidGenerator struct{}
+
+func (uuidGenerator) NewID() string {
+	return uuid.New().String()
+}
+
+// WithIDGenerator 设置 orderId 生成器(默认为 UUID v4)，g 为 nil 时使用默认生成器
+func WithIDGenerator(g IDGenerator) ClientOption {
+	return func(c *client) {
+		if g == nil {
+			g = uuidGenerator{}
+		}
+
+		c.ids = g
+	}
+}
@@ -0,0 +1,35 @@
+package antchain
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWithIDGenerator(t *testing.T) {
+	cases := []struct {
+		name string
+		gen  IDGenerator
+		want string
+	}{
+		{"custom", IDGeneratorFunc(func() string { return "order-1" }), "order-1"},
+		{"nil keeps default", nil, ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			gw := newTestGateway(t, func(params X) (interface{}, bool) { return "0x1", true })
+
+			cli := newTestClient(t, gw, WithIDGenerator(c.gen))
+
+			if _, err := cli.Deposit(context.Background(), "content", 100); err != nil {
+				t.Fatal(err)
+			}
+
+			id, _ := gw.last()["orderId"].(string)
+
+			if len(id) == 0 || (len(c.want) != 0 && id != c.want) {
+				t.Fatalf("orderId = %q, want %q", id, c.want)
+			}
+		})
+	}
+}
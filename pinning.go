@@ -0,0 +1,102 @@
+package antchain
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// ErrPinMismatch 网关证书链中没有与固定公钥匹配的证书
+var ErrPinMismatch = errors.New("antchain: certificate pin mismatch")
+
+// SPKIHash 返回证书公钥(SubjectPublicKeyInfo)的 SHA-256 摘要(base64)，即 WithPinnedPublicKeys 使用的格式
+func SPKIHash(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// pinSet 固定的公钥摘要
+type pinSet map[string]bool
+
+func newPinSet(pins []string) pinSet {
+	ps := make(pinSet, len(pins))
+
+	for _, v := range pins {
+		ps[strings.TrimPrefix(strings.TrimSpace(v), "sha256/")] = true
+	}
+
+	return ps
+}
+
+func (ps pinSet) match(cert *x509.Certificate) bool {
+	return ps[SPKIHash(cert)]
+}
+
+// verify 校验网关证书链：经过证书校验时，任一已验证链中包含固定的公钥即通过；
+// 跳过证书校验时，叶子证书须为固定的公钥，或能经网关提供的中间证书验证至固定公钥的证书
+func (ps pinSet) verify(cs tls.ConnectionState) error {
+	if len(cs.PeerCertificates) == 0 {
+		return ErrPinMismatch
+	}
+
+	if len(cs.VerifiedChains) != 0 {
+		for _, chain := range cs.VerifiedChains {
+			for _, cert := range chain {
+				if ps.match(cert) {
+					return nil
+				}
+			}
+		}
+
+		return ErrPinMismatch
+	}
+
+	leaf := cs.PeerCertificates[0]
+
+	if ps.match(leaf) {
+		return nil
+	}
+
+	roots := x509.NewCertPool()
+	intermediates := x509.NewCertPool()
+	pinned := false
+
+	for _, cert := range cs.PeerCertificates[1:] {
+		if ps.match(cert) {
+			roots.AddCert(cert)
+			pinned = true
+		} else {
+			intermediates.AddCert(cert)
+		}
+	}
+
+	if !pinned {
+		return ErrPinMismatch
+	}
+
+	// 跳过证书校验时 tls 未校验域名，此处一并校验
+	_, err := leaf.Verify(x509.VerifyOptions{
+		DNSName:       cs.ServerName,
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+
+	if err != nil {
+		return wrapErr(ErrPinMismatch, err)
+	}
+
+	return nil
+}
+
+// WithPinnedPublicKeys 固定网关叶子证书或 CA 证书的公钥(SPKI SHA-256 摘要，base64，可带 sha256/ 前缀，见 SPKIHash)，
+// 证书链中没有匹配的公钥时拒绝连接，防止通过被攻破的企业 CA 拦截请求；多个摘要用于证书轮换
+func WithPinnedPublicKeys(pins ...string) ClientOption {
+	return func(c *client) {
+		c.transport.pins = append(c.transport.pins, pins...)
+	}
+}
@@ -0,0 +1,80 @@
+package antchain
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func testCert(t *testing.T, tmpl, parent *x509.Certificate, pub, priv interface{}) *x509.Certificate {
+	t.Helper()
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, pub, priv)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert
+}
+
+func TestPinVerifyChecksServerName(t *testing.T) {
+	caKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	leafKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "pinned ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	ca := testCert(t, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+
+	leaf := testCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "gateway.example"},
+		DNSNames:     []string{"gateway.example"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, &leafKey.PublicKey, caKey)
+
+	ps := newPinSet([]string{"sha256/" + SPKIHash(ca)})
+
+	cs := tls.ConnectionState{
+		ServerName:       "gateway.example",
+		PeerCertificates: []*x509.Certificate{leaf, ca},
+	}
+
+	if err := ps.verify(cs); err != nil {
+		t.Fatal(err)
+	}
+
+	// 同一 CA 为其它域名签发的证书不能通过
+	cs.ServerName = "other.example"
+
+	if err := ps.verify(cs); !errors.Is(err, ErrPinMismatch) {
+		t.Fatalf("err = %v, want ErrPinMismatch", err)
+	}
+
+	if err := newPinSet([]string{SPKIHash(leaf) + "x"}).verify(cs); !errors.Is(err, ErrPinMismatch) {
+		t.Fatalf("unpinned chain: err = %v", err)
+	}
+}
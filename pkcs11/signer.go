@@ -0,0 +1,144 @@
+// Package pkcs11 使用 PKCS#11 硬件设备(HSM、智能卡)中的私钥进行 shakehand 签名；
+// 为避免引入 cgo 依赖，本包只依赖最小的 Module/Session 接口，可基于 github.com/miekg/pkcs11 等绑定实现
+package pkcs11
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/shenghui0779/antchain"
+)
+
+// CKM_RSA_PKCS PKCS#11 的 RSA PKCS#1 v1.5 签名机制(对调用方传入的 DigestInfo 签名)
+const CKM_RSA_PKCS uint = 0x00000001
+
+// KeyHandle 私钥对象句柄
+type KeyHandle uint
+
+// Module PKCS#11 模块
+type Module interface {
+	// OpenSession 打开 slot 的会话并使用 pin 登录
+	OpenSession(slot uint, pin string) (Session, error)
+}
+
+// Session PKCS#11 会话
+type Session interface {
+	// FindPrivateKey 按标签(CKA_LABEL)查找私钥
+	FindPrivateKey(label string) (KeyHandle, error)
+
+	// Sign 使用 mechanism 对 data 签名
+	Sign(key KeyHandle, mechanism uint, data []byte) ([]byte, error)
+
+	// Close 登出并关闭会话
+	Close() error
+}
+
+// Config PKCS#11 配置
+type Config struct {
+	Slot     uint   // 设备 slot
+	PIN      string // 用户 PIN
+	KeyLabel string // 私钥标签
+}
+
+// digestInfoPrefixes PKCS#1 v1.5 签名中 DigestInfo 的 DER 前缀
+var digestInfoPrefixes = map[crypto.Hash][]byte{
+	crypto.SHA1:   {0x30, 0x21, 0x30, 0x09, 0x06, 0x05, 0x2b, 0x0e, 0x03, 0x02, 0x1a, 0x05, 0x00, 0x04, 0x14},
+	crypto.SHA256: {0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x01, 0x05, 0x00, 0x04, 0x20},
+}
+
+// Signer 实现 antchain.Signer，签名在 PKCS#11 设备内完成；会话在首次签名时打开，签名串行执行
+type Signer struct {
+	module Module
+	cfg    *Config
+
+	mutex   sync.Mutex
+	session Session
+	key     KeyHandle
+}
+
+// NewSigner 返回 PKCS#11 签名器
+func NewSigner(module Module, cfg *Config) *Signer {
+	return &Signer{
+		module: module,
+		cfg:    cfg,
+	}
+}
+
+var _ antchain.Signer = (*Signer)(nil)
+
+func (s *Signer) Sign(hash crypto.Hash, data []byte) ([]byte, error) {
+	prefix, ok := digestInfoPrefixes[hash]
+
+	if !ok || !hash.Available() {
+		return nil, fmt.Errorf("pkcs11: unsupported hash %s", hash.String())
+	}
+
+	h := hash.New()
+	h.Write(data)
+
+	digestInfo := append(append([]byte{}, prefix...), h.Sum(nil)...)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+
+	sign, err := s.session.Sign(s.key, CKM_RSA_PKCS, digestInfo)
+
+	if err != nil {
+		// 会话可能已失效(如：设备重新插拔)，下次签名时重新打开
+		s.session.Close()
+		s.session = nil
+
+		return nil, fmt.Errorf("pkcs11: %w", err)
+	}
+
+	return sign, nil
+}
+
+func (s *Signer) open() error {
+	if s.session != nil {
+		return nil
+	}
+
+	session, err := s.module.OpenSession(s.cfg.Slot, s.cfg.PIN)
+
+	if err != nil {
+		return fmt.Errorf("pkcs11: %w", err)
+	}
+
+	key, err := session.FindPrivateKey(s.cfg.KeyLabel)
+
+	if err != nil {
+		session.Close()
+
+		return fmt.Errorf("pkcs11: find key %q: %w", s.cfg.KeyLabel, err)
+	}
+
+	s.session = session
+	s.key = key
+
+	return nil
+}
+
+// Close 关闭会话
+func (s *Signer) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.session == nil {
+		return nil
+	}
+
+	err := s.session.Close()
+	s.session = nil
+
+	return err
+}
+
+// ErrKeyNotFound 可供 Session 实现在找不到私钥时返回
+var ErrKeyNotFound = errors.New("pkcs11: key not found")
@@ -0,0 +1,129 @@
+package antchain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	// ErrMethodNotAllowed 网关方法不在 CallPolicy 的允许范围内
+	ErrMethodNotAllowed = errors.New("antchain: method not allowed")
+	// ErrInvalidParams 请求参数不符合 CallPolicy 中的参数定义
+	ErrInvalidParams = errors.New("antchain: invalid params")
+)
+
+// ParamSchema 网关方法的参数定义
+type ParamSchema struct {
+	Required []string             // 必需的参数
+	Optional []string             // 可选的参数；Required 与 Optional 均为空时不限制参数名
+	Validate func(params X) error // 自定义校验(可为空)
+}
+
+// check 校验参数
+func (ps *ParamSchema) check(params X) error {
+	for _, key := range ps.Required {
+		if _, ok := params[key]; !ok {
+			return fmt.Errorf("missing param %q", key)
+		}
+	}
+
+	if len(ps.Required) != 0 || len(ps.Optional) != 0 {
+		known := make(map[string]bool, len(ps.Required)+len(ps.Optional))
+
+		for _, key := range ps.Required {
+			known[key] = true
+		}
+
+		for _, key := range ps.Optional {
+			known[key] = true
+		}
+
+		unknown := make([]string, 0)
+
+		for key := range params {
+			if !known[key] {
+				unknown = append(unknown, key)
+			}
+		}
+
+		if len(unknown) != 0 {
+			sort.Strings(unknown)
+
+			return fmt.Errorf("unknown params %q", unknown)
+		}
+	}
+
+	if ps.Validate != nil {
+		return ps.Validate(params)
+	}
+
+	return nil
+}
+
+// CallPolicy ChainCall、ChainCallForBiz、SubmitMultiSig 的调用策略，由平台方配置，
+// 使多团队共用的服务可以安全地开放通用调用；SDK 封装的方法不受影响
+type CallPolicy struct {
+	Allow   []Method                // 允许的方法，为空表示除 Deny 外均允许
+	Deny    []Method                // 禁止的方法，优先于 Allow
+	Schemas map[Method]*ParamSchema // 方法的参数定义
+}
+
+// check 校验方法及参数
+func (p *CallPolicy) check(method Method, options []ChainCallOption) error {
+	for _, v := range p.Deny {
+		if v == method {
+			return fmt.Errorf("%w: %s", ErrMethodNotAllowed, method)
+		}
+	}
+
+	if len(p.Allow) != 0 {
+		allowed := false
+
+		for _, v := range p.Allow {
+			if v == method {
+				allowed = true
+
+				break
+			}
+		}
+
+		if !allowed {
+			return fmt.Errorf("%w: %s", ErrMethodNotAllowed, method)
+		}
+	}
+
+	schema, ok := p.Schemas[method]
+
+	if !ok || schema == nil {
+		return nil
+	}
+
+	params := make(X)
+
+	for _, f := range options {
+		f(params)
+	}
+
+	if err := schema.check(params); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidParams, method, err)
+	}
+
+	return nil
+}
+
+// WithCallPolicy 设置 ChainCall、ChainCallForBiz、SubmitMultiSig 的调用策略(方法白名单/黑名单及参数定义)
+func WithCallPolicy(p *CallPolicy) ClientOption {
+	return func(c *client) {
+		c.policy = p
+	}
+}
+
+// checkPolicy 校验通用调用是否符合 CallPolicy
+func (c *client) checkPolicy(method Method, options []ChainCallOption) error {
+	if c.policy == nil {
+		return nil
+	}
+
+	return c.policy.check(method, options)
+}
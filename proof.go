@@ -0,0 +1,210 @@
+package antchain
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shenghui0779/antchain/codec"
+	"github.com/tidwall/gjson"
+)
+
+// ProofNode Merkle 路径上的兄弟节点
+type ProofNode struct {
+	Hash string `json:"hash"` // 兄弟节点hash(hex)
+	Left bool   `json:"left"` // 兄弟节点是否位于左侧
+}
+
+// InclusionProof 交易在区块中的 Merkle 包含证明
+type InclusionProof struct {
+	BlockNumber int64       `json:"block_number"`
+	TxHash      string      `json:"tx_hash"`
+	Index       int         `json:"index"` // 交易在区块中的序号
+	Path        []ProofNode `json:"path"`
+	Root        string      `json:"root"` // 块头中的 transactionRoot
+}
+
+func (c *client) QueryTxProof(ctx context.Context, hash string) (*InclusionProof, error) {
+	tx, err := c.QueryTransaction(ctx, hash)
+
+	if err != nil {
+		return nil, err
+	}
+
+	blockNumber := gjson.Get(tx, "blockNumber").Int()
+
+	if blockNumber <= 0 {
+		return nil, wrapErr(ErrDecodeFailed, fmt.Errorf("tx %s has no block number", hash))
+	}
+
+	header, err := c.QueryBlockHeader(ctx, blockNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.QueryBlockBody(ctx, blockNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, 0)
+
+	for _, v := range gjson.Get(body, "transactionList.#.hash").Array() {
+		hashes = append(hashes, v.String())
+	}
+
+	proof, err := BuildInclusionProof(hashes, hash)
+
+	if err != nil {
+		return nil, err
+	}
+
+	proof.BlockNumber = blockNumber
+
+	// 本地计算的根须与块头一致：树的构造方式与链不一致或网关返回的交易列表被篡改时，证明无法通过 VerifyInclusion
+	root := headerField(header, "transactionRoot")
+
+	if !root.Exists() {
+		return nil, wrapErr(ErrDecodeFailed, fmt.Errorf("block %d header has no transactionRoot", blockNumber))
+	}
+
+	expected, err := decodeRoot(root.String())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if hex.EncodeToString(expected) != proof.Root {
+		return nil, wrapErr(ErrDecodeFailed, fmt.Errorf("block %d merkle root %s, header transactionRoot %s", blockNumber, proof.Root, root.String()))
+	}
+
+	return proof, nil
+}
+
+// decodeRoot 解析块头中的 transactionRoot，兼容 hex(可带0x前缀)及 base64 形式
+func decodeRoot(s string) ([]byte, error) {
+	if b, err := codec.HexToBytes(s); err == nil && len(b) == sha256.Size {
+		return b, nil
+	}
+
+	b, err := codec.Base64ToBytes(s)
+
+	if err != nil || len(b) != sha256.Size {
+		return nil, wrapErr(ErrDecodeFailed, fmt.Errorf("invalid transactionRoot %q", s))
+	}
+
+	return b, nil
+}
+
+// BuildInclusionProof 根据区块内的交易hash列表(hex)构造指定交易的 Merkle 证明；
+// Merkle 树采用 SHA-256 二叉树，节点数为奇数时复制最后一个节点
+func BuildInclusionProof(txHashes []string, txHash string) (*InclusionProof, error) {
+	index := -1
+	level := make([][]byte, 0, len(txHashes))
+
+	for i, v := range txHashes {
+		b, err := decodeHash(v)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if strings.EqualFold(codec.Trim0x(v), codec.Trim0x(txHash)) {
+			index = i
+		}
+
+		level = append(level, b)
+	}
+
+	if index < 0 {
+		return nil, fmt.Errorf("antchain: tx %s not found in block", txHash)
+	}
+
+	proof := &InclusionProof{
+		TxHash: txHash,
+		Index:  index,
+	}
+
+	for pos := index; len(level) > 1; pos /= 2 {
+		if len(level)%2 == 1 {
+			level = append(level, level[len(level)-1])
+		}
+
+		if pos%2 == 0 {
+			proof.Path = append(proof.Path, ProofNode{Hash: hex.EncodeToString(level[pos+1])})
+		} else {
+			proof.Path = append(proof.Path, ProofNode{Hash: hex.EncodeToString(level[pos-1]), Left: true})
+		}
+
+		next := make([][]byte, 0, len(level)/2)
+
+		for i := 0; i < len(level); i += 2 {
+			next = append(next, merkleParent(level[i], level[i+1]))
+		}
+
+		level = next
+	}
+
+	proof.Root = hex.EncodeToString(level[0])
+
+	return proof, nil
+}
+
+// VerifyInclusion 本地校验交易的 Merkle 证明，root 为块头中的 transactionRoot
+func VerifyInclusion(txHash string, proof *InclusionProof, root string) (bool, error) {
+	if proof == nil {
+		return false, errors.New("antchain: nil inclusion proof")
+	}
+
+	node, err := decodeHash(txHash)
+
+	if err != nil {
+		return false, err
+	}
+
+	for _, v := range proof.Path {
+		sibling, err := decodeHash(v.Hash)
+
+		if err != nil {
+			return false, err
+		}
+
+		if v.Left {
+			node = merkleParent(sibling, node)
+		} else {
+			node = merkleParent(node, sibling)
+		}
+	}
+
+	expected, err := decodeHash(root)
+
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(node, expected), nil
+}
+
+func merkleParent(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write(left)
+	h.Write(right)
+
+	return h.Sum(nil)
+}
+
+func decodeHash(s string) ([]byte, error) {
+	b, err := codec.HexToBytes(s)
+
+	if err != nil {
+		return nil, wrapErr(ErrDecodeFailed, err)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,145 @@
+package antchain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// SHA-256("tx0")、SHA-256("tx1")、SHA-256("tx2")；以下向量按 BuildInclusionProof 的树定义推导，
+// 尚未以真实 AntChain 区块校验，树的构造与链不一致时由 QueryTxProof 的块头比对发现
+var testTxHashes = []string{
+	"95cd603fe577fa9548ec0c9b50b067566fe07c8af6acba45f6196f3a15d511f6",
+	"709b55bd3da0f5a838125bd0ee20c5bfdd7caba173912d4281cae816b79a201b",
+	"27ca64c092a959c7edc525ed45e845b1de6a7590d173fd2fad9133c8a779a1e3",
+}
+
+func TestBuildInclusionProofVector(t *testing.T) {
+	// root = H(H(tx0||tx1) || H(tx2||tx2))
+	const root = "726da7d399987671da491c4886e07dacd532fb6e7e48862732701d2443e3b532"
+
+	proof, err := BuildInclusionProof(testTxHashes, "0x"+testTxHashes[2])
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if proof.Root != root || proof.Index != 2 || len(proof.Path) != 2 {
+		t.Fatalf("proof = %+v", proof)
+	}
+
+	if proof.Path[0].Hash != testTxHashes[2] || proof.Path[0].Left || !proof.Path[1].Left {
+		t.Fatalf("path = %+v", proof.Path)
+	}
+
+	ok, err := VerifyInclusion(testTxHashes[2], proof, root)
+
+	if err != nil || !ok {
+		t.Fatalf("verify = %v, %v", ok, err)
+	}
+}
+
+func TestInclusionProofAllPositions(t *testing.T) {
+	for n := 1; n <= 9; n++ {
+		hashes := make([]string, 0, n)
+
+		for i := 0; i < n; i++ {
+			sum := sha256.Sum256([]byte(fmt.Sprintf("tx%d", i)))
+			hashes = append(hashes, hex.EncodeToString(sum[:]))
+		}
+
+		var root string
+
+		for i, h := range hashes {
+			proof, err := BuildInclusionProof(hashes, h)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if i == 0 {
+				root = proof.Root
+			} else if proof.Root != root {
+				t.Fatalf("n=%d index=%d root = %s, want %s", n, i, proof.Root, root)
+			}
+
+			if ok, err := VerifyInclusion(h, proof, root); err != nil || !ok {
+				t.Fatalf("n=%d index=%d verify = %v, %v", n, i, ok, err)
+			}
+
+			if ok, _ := VerifyInclusion(hashes[(i+1)%n], proof, root); ok && n > 1 {
+				t.Fatalf("n=%d index=%d proof accepted another tx", n, i)
+			}
+		}
+	}
+}
+
+func TestInclusionProofErrors(t *testing.T) {
+	if _, err := BuildInclusionProof(testTxHashes, "00"); err == nil {
+		t.Fatal("missing tx accepted")
+	}
+
+	if _, err := BuildInclusionProof([]string{"zz"}, "zz"); err == nil {
+		t.Fatal("invalid hash accepted")
+	}
+
+	if _, err := VerifyInclusion(testTxHashes[0], nil, testTxHashes[0]); err == nil {
+		t.Fatal("nil proof accepted")
+	}
+}
+
+func TestQueryTxProofChecksHeaderRoot(t *testing.T) {
+	const root = "726da7d399987671da491c4886e07dacd532fb6e7e48862732701d2443e3b532"
+
+	rootBytes, _ := hex.DecodeString(root)
+
+	body := `{"transactionList":[{"hash":"` + testTxHashes[0] + `"},{"hash":"` + testTxHashes[1] + `"},{"hash":"` + testTxHashes[2] + `"}]}`
+
+	cases := []struct {
+		name   string
+		tx     string
+		header string
+		ok     bool
+	}{
+		{"hex root", `{"blockNumber":5}`, `{"transactionRoot":"0x` + root + `"}`, true},
+		{"base64 root", `{"blockNumber":5}`, `{"blockHeader":{"transactionRoot":"` + base64.StdEncoding.EncodeToString(rootBytes) + `"}}`, true},
+		{"root mismatch", `{"blockNumber":5}`, `{"transactionRoot":"` + testTxHashes[0] + `"}`, false},
+		{"no root", `{"blockNumber":5}`, `{}`, false},
+		{"pending tx", `{"hash":"0x1"}`, `{"transactionRoot":"` + root + `"}`, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			gw := newTestGateway(t, func(params X) (interface{}, bool) {
+				switch Method(params["method"].(string)) {
+				case MethodQueryTransaction:
+					return c.tx, true
+				case MethodQueryBlock:
+					return c.header, true
+				default:
+					return body, true
+				}
+			})
+
+			cli := newTestClient(t, gw)
+
+			proof, err := cli.QueryTxProof(context.Background(), testTxHashes[1])
+
+			if !c.ok {
+				if !errors.Is(err, ErrDecodeFailed) {
+					t.Fatalf("err = %v, want ErrDecodeFailed", err)
+				}
+
+				return
+			}
+
+			if err != nil || proof.Root != root || proof.BlockNumber != 5 {
+				t.Fatalf("proof = %+v, err = %v", proof, err)
+			}
+		})
+	}
+}
@@ -3,28 +3,56 @@ package antchain
 import (
 	"context"
 	"fmt"
+	"strconv"
 )
 
 func (c *client) QueryTransaction(ctx context.Context, hash string) (string, error) {
-	return c.chainCall(ctx, "QUERYTRANSACTION", WithParam("hash", hash))
+	return c.cachedChainCall(ctx, MethodQueryTransaction, hash, WithParam("hash", hash))
 }
 
 func (c *client) QueryReceipt(ctx context.Context, hash string) (string, error) {
-	return c.chainCall(ctx, "QUERYRECEIPT", WithParam("hash", hash))
+	return c.cachedChainCall(ctx, MethodQueryReceipt, hash, WithParam("hash", hash))
 }
 
 func (c *client) QueryBlockHeader(ctx context.Context, blockNumber int64) (string, error) {
-	return c.chainCall(ctx, "QUERYBLOCK", WithParam("requestStr", blockNumber))
+	return c.cachedChainCall(ctx, MethodQueryBlock, strconv.FormatInt(blockNumber, 10), WithParam("requestStr", blockNumber))
 }
 
 func (c *client) QueryBlockBody(ctx context.Context, blockNumber int64) (string, error) {
-	return c.chainCall(ctx, "QUERYBLOCKBODY", WithParam("requestStr", blockNumber))
+	return c.cachedChainCall(ctx, MethodQueryBlockBody, strconv.FormatInt(blockNumber, 10), WithParam("requestStr", blockNumber))
 }
 
 func (c *client) QueryLastBlock(ctx context.Context) (string, error) {
-	return c.chainCall(ctx, "QUERYLASTBLOCK")
+	return c.chainCall(ctx, MethodQueryLastBlock)
 }
 
-func (c *client) QueryAccount(ctx context.Context, account string) (string, error) {
-	return c.chainCall(ctx, "QUERYACCOUNT", WithParam("requestStr", fmt.Sprintf(`{"queryAccount":"%s"}`, account)))
+func (c *client) QueryAccount(ctx context.Context, account string) (*Account, error) {
+	data, err := c.chainCall(ctx, MethodQueryAccount, WithParam("requestStr", fmt.Sprintf(`{"queryAccount":"%s"}`, account)))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parseAccount(data, c.strict)
+}
+
+func (c *client) QueryAccountByPublicKey(ctx context.Context, pubKey []byte) (*Account, error) {
+	return c.queryAccountByIdentity(ctx, GetIdentityByPublicKey(pubKey))
+}
+
+// queryAccountByIdentity 按 Identity 查询账户；SDK 内 Identity 统一为 base64，网关的 queryIdentity 为 hex，只在此处转换
+func (c *client) queryAccountByIdentity(ctx context.Context, identity *Identity) (*Account, error) {
+	h, err := identity.Hex()
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := c.chainCall(ctx, MethodQueryAccount, WithParam("requestStr", fmt.Sprintf(`{"queryIdentity":"%s"}`, h)))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parseAccount(data, c.strict)
 }
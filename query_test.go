@@ -0,0 +1,57 @@
+package antchain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/tidwall/gjson"
+)
+
+func TestQueryAccountByPublicKey(t *testing.T) {
+	gw := newTestGateway(t, func(params X) (interface{}, bool) { return `{"id":"x","status":"NORMAL"}`, true })
+
+	cli := newTestClient(t, gw)
+
+	pubKey := []byte("public-key")
+
+	if _, err := cli.QueryAccountByPublicKey(context.Background(), pubKey); err != nil {
+		t.Fatal(err)
+	}
+
+	sum := sha256.Sum256(pubKey)
+
+	if got := gjson.Get(gw.last()["requestStr"].(string), "queryIdentity").String(); got != hex.EncodeToString(sum[:]) {
+		t.Fatalf("queryIdentity = %q", got)
+	}
+
+	if got := GetIdentityByPublicKey(pubKey).Data; got != base64.StdEncoding.EncodeToString(sum[:]) {
+		t.Fatalf("identity = %q", got)
+	}
+}
+
+func TestParseIdentity(t *testing.T) {
+	want := GetIdentityByName("alice")
+
+	h, err := want.Hex()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, s := range []string{want.Data, h, "0x" + h} {
+		identity, err := ParseIdentity(s)
+
+		if err != nil || identity.Data != want.Data {
+			t.Fatalf("ParseIdentity(%q) = %v, %v", s, identity, err)
+		}
+	}
+
+	for _, s := range []string{"", "abcd", "0x1234"} {
+		if _, err := ParseIdentity(s); err == nil {
+			t.Fatalf("ParseIdentity(%q) succeeded", s)
+		}
+	}
+}
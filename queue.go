@@ -0,0 +1,367 @@
+package antchain
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrQueueFull 提交队列已满
+	ErrQueueFull = errors.New("antchain: submit queue full")
+	// ErrQueueClosed 提交队列已关闭
+	ErrQueueClosed = errors.New("antchain: submit queue closed")
+)
+
+// Priority 交易提交的优先级
+type Priority int
+
+const (
+	// PriorityUrgent 紧急(如：司法存证)，优先于其它优先级执行
+	PriorityUrgent Priority = iota
+	// PriorityNormal 普通
+	PriorityNormal
+	// PriorityBulk 批量(如：历史数据回填)，仅在没有更高优先级的待执行交易时执行
+	PriorityBulk
+
+	priorityCount
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityUrgent:
+		return "urgent"
+	case PriorityNormal:
+		return "normal"
+	case PriorityBulk:
+		return "bulk"
+	}
+
+	return "unknown"
+}
+
+// SubmitFunc 提交交易，返回交易哈希
+type SubmitFunc func(ctx context.Context) (string, error)
+
+// tokenBucket 令牌桶限速，rate<=0 表示不限速
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// take 取一个令牌，不足时返回需要等待的时长
+func (b *tokenBucket) take(now time.Time) (bool, time.Duration) {
+	if b.rate <= 0 {
+		return true, 0
+	}
+
+	if !b.last.IsZero() {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+
+		return true, 0
+	}
+
+	return false, time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+type queuedJob struct {
+	ctx    context.Context
+	fn     SubmitFunc
+	result chan queuedResult
+}
+
+type queuedResult struct {
+	hash string
+	err  error
+}
+
+// QueueStats 各优先级的队列状态
+type QueueStats struct {
+	Priority  Priority
+	Pending   int   // 待执行数
+	Submitted int64 // 累计执行数
+	Dropped   int64 // 因队列已满被拒绝的数量
+}
+
+// SubmitQueueOption SubmitQueue 的可选配置
+type SubmitQueueOption func(q *SubmitQueue)
+
+// WithPriorityRate 限制优先级每秒执行的交易数，burst 为允许的突发数(默认：不限速)
+func WithPriorityRate(p Priority, rate float64, burst int) SubmitQueueOption {
+	return func(q *SubmitQueue) {
+		if p < 0 || p >= priorityCount {
+			return
+		}
+
+		if burst < 1 {
+			burst = 1
+		}
+
+		q.limits[p] = &tokenBucket{
+			rate:   rate,
+			burst:  float64(burst),
+			tokens: float64(burst),
+		}
+	}
+}
+
+// WithPriorityCapacity 限制优先级的待执行交易数，超出时 Submit 返回 ErrQueueFull(默认：不限制)
+func WithPriorityCapacity(p Priority, capacity int) SubmitQueueOption {
+	return func(q *SubmitQueue) {
+		if p < 0 || p >= priorityCount {
+			return
+		}
+
+		q.capacity[p] = capacity
+	}
+}
+
+// SubmitQueue 按优先级调度交易提交：高优先级的交易总是先于低优先级执行，
+// 各优先级可单独限速，避免紧急交易被批量任务阻塞，也避免紧急流量完全挤占批量任务
+type SubmitQueue struct {
+	workers int
+	now     func() time.Time
+
+	mutex     sync.Mutex
+	queues    [priorityCount][]*queuedJob
+	limits    [priorityCount]*tokenBucket
+	capacity  [priorityCount]int
+	submitted [priorityCount]int64
+	dropped   [priorityCount]int64
+	closed    bool
+
+	wake chan struct{}
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewSubmitQueue 返回 SubmitQueue，workers 为并发执行的交易数(默认：1)
+func NewSubmitQueue(workers int, options ...SubmitQueueOption) *SubmitQueue {
+	if workers <= 0 {
+		workers = 1
+	}
+
+	q := &SubmitQueue{
+		workers: workers,
+		now:     time.Now,
+		wake:    make(chan struct{}, workers),
+		done:    make(chan struct{}),
+	}
+
+	for i := range q.limits {
+		q.limits[i] = new(tokenBucket)
+	}
+
+	for _, f := range options {
+		f(q)
+	}
+
+	q.wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go q.work()
+	}
+
+	return q
+}
+
+// Submit 按优先级排队并等待 fn 执行完成，排队期间 ctx 取消则不再执行
+func (q *SubmitQueue) Submit(ctx context.Context, p Priority, fn SubmitFunc) (string, error) {
+	if p < 0 || p >= priorityCount {
+		p = PriorityNormal
+	}
+
+	job := &queuedJob{
+		ctx:    ctx,
+		fn:     fn,
+		result: make(chan queuedResult, 1),
+	}
+
+	q.mutex.Lock()
+
+	if q.closed {
+		q.mutex.Unlock()
+
+		return "", ErrQueueClosed
+	}
+
+	if c := q.capacity[p]; c > 0 && len(q.queues[p]) >= c {
+		q.dropped[p]++
+		q.mutex.Unlock()
+
+		return "", ErrQueueFull
+	}
+
+	q.queues[p] = append(q.queues[p], job)
+
+	q.mutex.Unlock()
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case ret := <-job.result:
+		return ret.hash, ret.err
+	}
+}
+
+// next 返回下一个可执行的交易；没有时返回最近一个受限速的优先级需要等待的时长(0 表示等待新交易)
+func (q *SubmitQueue) next() (*queuedJob, time.Duration) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	now := q.now()
+
+	var wait time.Duration
+
+	for p := range q.queues {
+		// 跳过排队期间已取消的交易
+		for len(q.queues[p]) != 0 && q.queues[p][0].ctx.Err() != nil {
+			q.queues[p][0] = nil
+			q.queues[p] = q.queues[p][1:]
+		}
+
+		if len(q.queues[p]) == 0 {
+			continue
+		}
+
+		ok, d := q.limits[p].take(now)
+
+		if !ok {
+			if wait == 0 || d < wait {
+				wait = d
+			}
+
+			continue
+		}
+
+		job := q.queues[p][0]
+
+		q.queues[p][0] = nil
+		q.queues[p] = q.queues[p][1:]
+		q.submitted[p]++
+
+		return job, 0
+	}
+
+	return nil, wait
+}
+
+func (q *SubmitQueue) work() {
+	defer q.wg.Done()
+
+	for {
+		select {
+		case <-q.done:
+			return
+		default:
+		}
+
+		job, wait := q.next()
+
+		if job != nil {
+			hash, err := job.fn(job.ctx)
+
+			job.result <- queuedResult{hash: hash, err: err}
+
+			continue
+		}
+
+		if wait > 0 {
+			timer := time.NewTimer(wait)
+
+			select {
+			case <-q.done:
+				timer.Stop()
+
+				return
+			case <-q.wake:
+				timer.Stop()
+			case <-timer.C:
+			}
+
+			continue
+		}
+
+		select {
+		case <-q.done:
+			return
+		case <-q.wake:
+		}
+	}
+}
+
+// Stats 返回各优先级的队列状态
+func (q *SubmitQueue) Stats() []QueueStats {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	ret := make([]QueueStats, 0, priorityCount)
+
+	for p := range q.queues {
+		ret = append(ret, QueueStats{
+			Priority:  Priority(p),
+			Pending:   len(q.queues[p]),
+			Submitted: q.submitted[p],
+			Dropped:   q.dropped[p],
+		})
+	}
+
+	return ret
+}
+
+// Close 关闭队列：不再接受新交易，待执行的交易返回 ErrQueueClosed，等待执行中的交易完成或 ctx 结束
+func (q *SubmitQueue) Close(ctx context.Context) error {
+	q.mutex.Lock()
+
+	if q.closed {
+		q.mutex.Unlock()
+
+		return nil
+	}
+
+	q.closed = true
+
+	for p := range q.queues {
+		for _, job := range q.queues[p] {
+			job.result <- queuedResult{err: ErrQueueClosed}
+		}
+
+		q.queues[p] = nil
+	}
+
+	close(q.done)
+
+	q.mutex.Unlock()
+
+	finished := make(chan struct{})
+
+	go func() {
+		q.wg.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-finished:
+		return nil
+	}
+}
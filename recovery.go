@@ -0,0 +1,130 @@
+package antchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// ErrTxFailed 交易已上链但执行失败(回执的 result 非 0)
+var ErrTxFailed = errors.New("antchain: transaction failed")
+
+// RecoverStep 账户恢复的步骤
+type RecoverStep int
+
+const (
+	// RecoverStepPreReset 提交预重置(使用恢复密钥)
+	RecoverStepPreReset RecoverStep = iota + 1
+	// RecoverStepReset 等待期结束后提交重置
+	RecoverStepReset
+	// RecoverStepVerify 校验账户已恢复正常
+	RecoverStepVerify
+)
+
+func (s RecoverStep) String() string {
+	switch s {
+	case RecoverStepPreReset:
+		return "pre-reset"
+	case RecoverStepReset:
+		return "reset"
+	case RecoverStepVerify:
+		return "verify"
+	}
+
+	return fmt.Sprintf("RecoverStep(%d)", int(s))
+}
+
+// RecoverRequest 账户恢复请求
+type RecoverRequest struct {
+	Account     string        // 待恢复的链账户
+	NewKmsKeyID string        // 新的托管密钥标识
+	Gas         int           // 交易gas
+	Delay       time.Duration // 预重置与重置之间的等待期(由链上配置决定)
+	Interval    time.Duration // 轮询交易回执的间隔，默认1秒
+
+	// Progress 每个步骤完成(或失败)时回调，data 为交易hash(或校验步骤的账户状态)
+	Progress func(step RecoverStep, data string, err error)
+}
+
+func (c *client) RecoverAccount(ctx context.Context, req *RecoverRequest) error {
+	progress := func(step RecoverStep, data string, err error) error {
+		if req.Progress != nil {
+			req.Progress(step, data, err)
+		}
+
+		if err != nil {
+			return fmt.Errorf("antchain: recover account %s at step %s: %w", req.Account, step, err)
+		}
+
+		return nil
+	}
+
+	for _, step := range []RecoverStep{RecoverStepPreReset, RecoverStepReset} {
+		method := MethodPreResetAccount
+
+		if step == RecoverStepReset {
+			method = MethodResetAccount
+
+			if err := sleepContext(ctx, req.Delay); err != nil {
+				return progress(step, "", err)
+			}
+		}
+
+		hash, err := c.chainCallForBiz(ctx, method,
+			WithParam("resetAccount", req.Account),
+			WithParam("newAccountKmsId", req.NewKmsKeyID),
+			WithParam("gas", req.Gas),
+		)
+
+		if err == nil {
+			_, err = WaitReceipt(ctx, c, hash, req.Interval)
+		}
+
+		if err := progress(step, hash, err); err != nil {
+			return err
+		}
+	}
+
+	account, err := c.QueryAccount(ctx, req.Account)
+
+	if err == nil && account.Status != AccountStatusNormal {
+		err = fmt.Errorf("unexpected account status %s", account.Status)
+	}
+
+	var status string
+
+	if account != nil {
+		status = string(account.Status)
+	}
+
+	return progress(RecoverStepVerify, status, err)
+}
+
+// receiptError 回执的执行结果(result)非 0 时返回 ErrTxFailed
+func receiptError(hash, receipt string) error {
+	if code := gjson.Get(receipt, "result").Int(); code != 0 {
+		return fmt.Errorf("%w: %s result %d", ErrTxFailed, hash, code)
+	}
+
+	return nil
+}
+
+// sleepContext 等待 d 或直到 ctx 结束
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
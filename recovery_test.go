@@ -0,0 +1,49 @@
+package antchain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRecoverAccountReportsFailedReceipt(t *testing.T) {
+	gw := newTestGateway(t, func(params X) (interface{}, bool) {
+		switch Method(params["method"].(string)) {
+		case MethodPreResetAccount:
+			return "0xpre", true
+		case MethodResetAccount:
+			return "0xreset", true
+		case MethodQueryReceipt:
+			if params["hash"] == "0xreset" {
+				return `{"result":10}`, true
+			}
+
+			return `{"result":0}`, true
+		}
+
+		return nil, false
+	})
+
+	cli := newTestClient(t, gw)
+
+	var steps []RecoverStep
+
+	err := cli.RecoverAccount(context.Background(), &RecoverRequest{
+		Account:     "account",
+		NewKmsKeyID: "new-kms",
+		Gas:         100,
+		Interval:    time.Millisecond,
+		Progress: func(step RecoverStep, data string, err error) {
+			steps = append(steps, step)
+		},
+	})
+
+	if !errors.Is(err, ErrTxFailed) {
+		t.Fatalf("err = %v, want ErrTxFailed", err)
+	}
+
+	if len(steps) != 2 || steps[1] != RecoverStepReset {
+		t.Fatalf("steps = %v", steps)
+	}
+}
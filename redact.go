@@ -0,0 +1,115 @@
+package antchain
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// redacted 替换敏感信息的占位符
+const redacted = "[REDACTED]"
+
+// secretFieldRegexp JSON 中的敏感字段(token、签名、私钥等)
+var secretFieldRegexp = regexp.MustCompile(`(?i)"(token|secret|signature|sign|accessKey|access_key|privateKey|private_key|password)"(\s*):(\s*)"[^"]*"`)
+
+// redactSecrets 替换 JSON 文本中敏感字段的值
+func redactSecrets(s string) string {
+	return secretFieldRegexp.ReplaceAllString(s, `"$1"$2:$3"`+redacted+`"`)
+}
+
+// secretParamKeys 请求参数中的敏感字段(小写)
+var secretParamKeys = map[string]bool{
+	"token":       true,
+	"secret":      true,
+	"signature":   true,
+	"txsignature": true,
+	"sign":        true,
+	"accesskey":   true,
+	"access_key":  true,
+	"privatekey":  true,
+	"private_key": true,
+	"password":    true,
+}
+
+// redactParams 返回隐去敏感字段的请求参数副本(嵌套的对象一并复制)，交给钩子等外部代码，
+// 避免其修改实际发送的参数或将凭证写入日志
+func redactParams(params X) X {
+	v := make(X, len(params))
+
+	for key, value := range params {
+		switch {
+		case secretParamKeys[strings.ToLower(key)]:
+			v[key] = redacted
+		case strings.EqualFold(key, "accessId"), strings.EqualFold(key, "mykmsKeyId"):
+			s, _ := value.(string)
+			v[key] = maskSecret(s)
+		default:
+			switch m := value.(type) {
+			case X:
+				v[key] = redactParams(m)
+			case map[string]interface{}:
+				v[key] = map[string]interface{}(redactParams(m))
+			default:
+				v[key] = value
+			}
+		}
+	}
+
+	return v
+}
+
+// maskSecret 只保留前4个字符，用于 AccessID 等标识
+func maskSecret(s string) string {
+	if len(s) == 0 {
+		return ""
+	}
+
+	if len(s) <= 4 {
+		return strings.Repeat("*", len(s))
+	}
+
+	return s[:4] + strings.Repeat("*", len(s)-4)
+}
+
+// Redacted 返回隐去凭证信息的配置副本，用于日志及错误信息
+func (c *Config) Redacted() *Config {
+	v := *c
+
+	v.AccessID = maskSecret(c.AccessID)
+	v.MyKmsKeyID = maskSecret(c.MyKmsKeyID)
+
+	if len(c.AccessKey) != 0 {
+		v.AccessKey = redacted
+	}
+
+	return &v
+}
+
+// String 输出隐去凭证信息的配置，避免凭证被打印到日志中
+func (c *Config) String() string {
+	v := c.Redacted()
+
+	return fmt.Sprintf("{BizID:%s Endpoint:%s TenantID:%s AccessID:%s AccessKey:%s Account:%s MyKmsKeyID:%s RestAPIVersion:%s SignType:%s}",
+		v.BizID, v.Endpoint, v.TenantID, v.AccessID, v.AccessKey, v.Account, v.MyKmsKeyID, v.RestAPIVersion, v.SignType)
+}
+
+// GoString 同 String，作用于 %#v
+func (c *Config) GoString() string {
+	return "&antchain.Config" + c.String()
+}
+
+// LogValue 实现 slog.LogValuer，通过 slog 输出配置时隐去凭证信息
+func (c *Config) LogValue() slog.Value {
+	v := c.Redacted()
+
+	return slog.GroupValue(
+		slog.String("biz_id", v.BizID),
+		slog.String("endpoint", v.Endpoint),
+		slog.String("tenant_id", v.TenantID),
+		slog.String("access_id", v.AccessID),
+		slog.String("access_key", v.AccessKey),
+		slog.String("account", v.Account),
+		slog.String("mykmskey_id", v.MyKmsKeyID),
+	)
+}
@@ -0,0 +1,123 @@
+package redisstore
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/shenghui0779/antchain"
+)
+
+const (
+	// refreshScript 仅当锁仍由自己持有时续期
+	refreshScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("PEXPIRE", KEYS[1], ARGV[2]) else return 0 end`
+	// unlockScript 仅当锁仍由自己持有时删除
+	unlockScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("DEL", KEYS[1]) else return 0 end`
+)
+
+// LockClient 分布式锁使用的 Redis 客户端，如 go-redis：
+//
+//	func (r goRedis) SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error) {
+//		return r.Client.SetNX(ctx, key, value, ttl).Result()
+//	}
+//
+//	func (r goRedis) Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+//		return r.Client.Eval(ctx, script, keys, args...).Result()
+//	}
+type LockClient interface {
+	// SetNX 键不存在时设置键的值及过期时间，返回是否设置成功
+	SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error)
+
+	// Eval 执行 Lua 脚本
+	Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error)
+}
+
+// Locker 基于 Redis 的 antchain.Locker(单实例 SET NX PX，锁的值为随机令牌，续期及释放时校验令牌)；
+// 不提供 fencing token：进程暂停超过 ttl 时锁可能已被其它持有者获取，受保护的操作须幂等或自行校验版本，
+// 需要 fencing 时使用 etcdlock(Revision)
+type Locker struct {
+	cli LockClient
+}
+
+// NewLocker 返回基于 Redis 的 Locker
+func NewLocker(cli LockClient) *Locker {
+	return &Locker{cli: cli}
+}
+
+// TryLock 尝试获取锁，已被其它持有者持有时返回 antchain.ErrLockHeld
+func (l *Locker) TryLock(ctx context.Context, key string, ttl time.Duration) (antchain.Lock, error) {
+	b := make([]byte, 16)
+
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+
+	value := hex.EncodeToString(b)
+
+	ok, err := l.cli.SetNX(ctx, key, value, ttl)
+
+	if err != nil {
+		return nil, fmt.Errorf("redisstore: setnx: %w", err)
+	}
+
+	if !ok {
+		return nil, antchain.ErrLockHeld
+	}
+
+	return &lock{
+		cli:   l.cli,
+		key:   key,
+		value: value,
+	}, nil
+}
+
+type lock struct {
+	cli   LockClient
+	key   string
+	value string
+}
+
+func (l *lock) Refresh(ctx context.Context, ttl time.Duration) error {
+	ret, err := l.cli.Eval(ctx, refreshScript, []string{l.key}, l.value, strconv.FormatInt(ttl.Milliseconds(), 10))
+
+	if err != nil {
+		return fmt.Errorf("redisstore: refresh lock: %w", err)
+	}
+
+	if !scriptOK(ret) {
+		return antchain.ErrLockNotHeld
+	}
+
+	return nil
+}
+
+func (l *lock) Unlock(ctx context.Context) error {
+	ret, err := l.cli.Eval(ctx, unlockScript, []string{l.key}, l.value)
+
+	if err != nil {
+		return fmt.Errorf("redisstore: unlock: %w", err)
+	}
+
+	if !scriptOK(ret) {
+		return antchain.ErrLockNotHeld
+	}
+
+	return nil
+}
+
+// scriptOK 脚本是否返回了非零整数
+func scriptOK(ret interface{}) bool {
+	switch v := ret.(type) {
+	case int64:
+		return v != 0
+	case int:
+		return v != 0
+	case string:
+		return v != "0" && len(v) != 0
+	}
+
+	return false
+}
@@ -0,0 +1,100 @@
+// Package redisstore 基于 Redis 实现 antchain.TokenStore 及 antchain.Locker：
+// 水平扩展的多个副本共享同一 AccessID 的 token，并保证后台任务只在一个副本上运行
+package redisstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/shenghui0779/antchain"
+)
+
+// DefaultPrefix 默认的键前缀
+const DefaultPrefix = "antchain:token:"
+
+// Client Redis 客户端，可基于 go-redis、redigo 等实现，如 go-redis：
+//
+//	type goRedis struct{ *redis.Client }
+//
+//	func (r goRedis) Get(ctx context.Context, key string) (string, bool, error) {
+//		v, err := r.Client.Get(ctx, key).Result()
+//		if err == redis.Nil {
+//			return "", false, nil
+//		}
+//		return v, err == nil, err
+//	}
+//
+//	func (r goRedis) Set(ctx context.Context, key, value string, ttl time.Duration) error {
+//		return r.Client.Set(ctx, key, value, ttl).Err()
+//	}
+type Client interface {
+	// Get 返回键的值，键不存在时返回 false
+	Get(ctx context.Context, key string) (string, bool, error)
+
+	// Set 设置键的值及过期时间
+	Set(ctx context.Context, key, value string, ttl time.Duration) error
+}
+
+// TokenStore 基于 Redis 的 antchain.TokenStore
+type TokenStore struct {
+	cli    Client
+	prefix string
+	now    func() time.Time
+}
+
+// NewTokenStore 返回基于 Redis 的 TokenStore，prefix 为空则使用 DefaultPrefix
+func NewTokenStore(cli Client, prefix string) *TokenStore {
+	if len(prefix) == 0 {
+		prefix = DefaultPrefix
+	}
+
+	return &TokenStore{
+		cli:    cli,
+		prefix: prefix,
+		now:    time.Now,
+	}
+}
+
+// Load 返回 accessID 对应的 token，不存在返回 nil
+func (s *TokenStore) Load(ctx context.Context, accessID string) (*antchain.SharedToken, error) {
+	v, ok, err := s.cli.Get(ctx, s.prefix+accessID)
+
+	if err != nil {
+		return nil, fmt.Errorf("redisstore: get: %w", err)
+	}
+
+	if !ok {
+		return nil, nil
+	}
+
+	token := new(antchain.SharedToken)
+
+	if err := json.Unmarshal([]byte(v), token); err != nil {
+		return nil, fmt.Errorf("redisstore: decode: %w", err)
+	}
+
+	return token, nil
+}
+
+// Save 保存 token，键在 token 过期时同时过期
+func (s *TokenStore) Save(ctx context.Context, token *antchain.SharedToken) error {
+	ttl := token.ExpireAt.Sub(s.now())
+
+	if ttl <= 0 {
+		return nil
+	}
+
+	b, err := json.Marshal(token)
+
+	if err != nil {
+		return err
+	}
+
+	if err := s.cli.Set(ctx, s.prefix+token.AccessID, string(b), ttl); err != nil {
+		return fmt.Errorf("redisstore: set: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package antchain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Region 蚂蚁链REST服务所在区域
+type Region string
+
+// RegionHangzhou 华东1(杭州)；其它区域的服务地址未在文档中确认，需要时通过 Config.Endpoint 指定
+const RegionHangzhou Region = "cn-hangzhou"
+
+// EndpointHangzhou 华东1(杭州)REST服务地址(即官方示例中的 ENDPOINT)
+const EndpointHangzhou = "https://rest.baas.alipay.com"
+
+var regionEndpoints = map[Region]string{
+	RegionHangzhou: EndpointHangzhou,
+}
+
+// Endpoint 返回区域对应的REST服务地址
+func (r Region) Endpoint() (string, error) {
+	endpoint, ok := regionEndpoints[r]
+
+	if !ok {
+		return "", fmt.Errorf("antchain: unknown region %q", string(r))
+	}
+
+	return endpoint, nil
+}
+
+// Environment 蚂蚁链运行环境
+type Environment int
+
+const (
+	// EnvProduction 生产环境
+	EnvProduction Environment = iota + 1
+	// EnvSandbox 测试(沙箱)环境
+	EnvSandbox
+)
+
+// checkEnvironment 校验服务地址与运行环境是否匹配，避免测试配置误连生产链；
+// 沙箱环境的服务地址未在文档中确认，须通过 Config.Endpoint 显式指定，且不能是内置区域的生产地址
+func checkEnvironment(env Environment, endpoint string) error {
+	if env != EnvSandbox {
+		return nil
+	}
+
+	if len(endpoint) == 0 {
+		return errors.New("antchain: sandbox environment requires an explicit endpoint")
+	}
+
+	for r, v := range regionEndpoints {
+		if strings.TrimRight(endpoint, "/") == v {
+			return fmt.Errorf("antchain: sandbox environment can not use production endpoint of region %q", string(r))
+		}
+	}
+
+	return nil
+}
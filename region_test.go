@@ -0,0 +1,43 @@
+package antchain
+
+import "testing"
+
+func TestRegionEndpoint(t *testing.T) {
+	cases := []struct {
+		region Region
+		want   string
+		ok     bool
+	}{
+		{RegionHangzhou, EndpointHangzhou, true},
+		{"cn-shanghai", "", false},
+		{"", "", false},
+	}
+
+	for _, c := range cases {
+		endpoint, err := c.region.Endpoint()
+
+		if (err == nil) != c.ok || endpoint != c.want {
+			t.Errorf("Endpoint(%q) = %q, %v", c.region, endpoint, err)
+		}
+	}
+}
+
+func TestCheckEnvironment(t *testing.T) {
+	cases := []struct {
+		env      Environment
+		endpoint string
+		ok       bool
+	}{
+		{EnvSandbox, "https://sandbox.example.com", true},
+		{EnvSandbox, "", false},
+		{EnvSandbox, EndpointHangzhou + "/", false},
+		{EnvProduction, EndpointHangzhou, true},
+		{0, "", true},
+	}
+
+	for _, c := range cases {
+		if err := checkEnvironment(c.env, c.endpoint); (err == nil) != c.ok {
+			t.Errorf("checkEnvironment(%d, %q) = %v", c.env, c.endpoint, err)
+		}
+	}
+}
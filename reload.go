@@ -0,0 +1,181 @@
+package antchain
+
+import (
+	"encoding/json"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+// defaultReloadInterval 检查配置文件变更的默认间隔
+const defaultReloadInterval = 10 * time.Second
+
+// credential 客户端当前使用的配置与私钥，热更新时整体替换
+type credential struct {
+	cfg *Config
+	key Signer
+}
+
+func (c *client) credential() *credential {
+	return c.cred.Load().(*credential)
+}
+
+// LoadConfig 从 JSON 文件加载配置
+func LoadConfig(path string) (*Config, error) {
+	b, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := new(Config)
+
+	if err = json.Unmarshal(b, cfg); err != nil {
+		return nil, wrapErr(ErrDecodeFailed, err)
+	}
+
+	return cfg, nil
+}
+
+// LoadConfigFS 从 fsys 中的 JSON 文件加载配置
+func LoadConfigFS(fsys fs.FS, name string) (*Config, error) {
+	b, err := fs.ReadFile(fsys, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := new(Config)
+
+	if err = json.Unmarshal(b, cfg); err != nil {
+		return nil, wrapErr(ErrDecodeFailed, err)
+	}
+
+	return cfg, nil
+}
+
+// loadSigner 读取配置的 AccessKey，设置了 WithKeyFS 时从其中读取
+func (c *client) loadSigner(cfg *Config) (Signer, error) {
+	if c.keyFS != nil {
+		return LoadSignerFS(cfg.SignType, c.keyFS, cfg.AccessKey)
+	}
+
+	return LoadSigner(cfg.SignType, cfg.AccessKey)
+}
+
+// statKey 返回 AccessKey 文件的信息
+func (c *client) statKey(name string) (fs.FileInfo, error) {
+	if c.keyFS != nil {
+		return fs.Stat(c.keyFS, name)
+	}
+
+	return os.Stat(name)
+}
+
+// Reload 使用新的配置及其 AccessKey 文件替换当前凭证，并丢弃已缓存的 token；
+// 请求地址及 HTTP 相关配置不会改变
+func (c *client) Reload(cfg *Config) error {
+	cred := &credential{cfg: cfg}
+
+	// 使用 CredentialsProvider 时不读取 AccessKey 文件
+	if c.provider == nil {
+		signer, err := c.loadSigner(cfg)
+
+		if err != nil {
+			return err
+		}
+
+		cred.key = signer
+	}
+
+	c.refreshMutex.Lock()
+	defer c.refreshMutex.Unlock()
+
+	c.cred.Store(cred)
+
+	c.tokens.reset()
+
+	return nil
+}
+
+// reloadWatcher 监听配置文件及 AccessKey 文件的变更
+type reloadWatcher struct {
+	configPath string
+	interval   time.Duration
+	callback   func(err error)
+	modTimes   map[string]time.Time
+}
+
+// changed 判断文件的修改时间是否变化
+func (w *reloadWatcher) changed(stat func(name string) (fs.FileInfo, error), paths ...string) bool {
+	changed := false
+
+	for _, path := range paths {
+		if len(path) == 0 {
+			continue
+		}
+
+		info, err := stat(path)
+
+		if err != nil {
+			continue
+		}
+
+		if last, ok := w.modTimes[path]; ok && !info.ModTime().Equal(last) {
+			changed = true
+		}
+
+		w.modTimes[path] = info.ModTime()
+	}
+
+	return changed
+}
+
+// watch 定期检查文件变更并热更新凭证，证书轮换无需重启服务
+func (c *client) watch() {
+	w := c.watcher
+	w.modTimes = make(map[string]time.Time)
+	w.changed(os.Stat, w.configPath)
+	w.changed(c.statKey, c.credential().cfg.AccessKey)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.life.done:
+			return
+		case <-ticker.C:
+		}
+
+		configChanged := w.changed(os.Stat, w.configPath)
+		keyChanged := w.changed(c.statKey, c.credential().cfg.AccessKey)
+
+		if !configChanged && !keyChanged {
+			continue
+		}
+
+		cfg := c.credential().cfg
+
+		var err error
+
+		if len(w.configPath) != 0 {
+			cfg, err = LoadConfig(w.configPath)
+		}
+
+		if err == nil {
+			err = c.Reload(cfg)
+		}
+
+		if err != nil {
+			c.log.Error("hot reload failed", "error", err)
+		} else {
+			c.log.Info("config reloaded")
+		}
+
+		if w.callback != nil {
+			w.callback(err)
+		}
+	}
+}
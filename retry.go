@@ -0,0 +1,78 @@
+package antchain
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+const (
+	defaultRetryBackoff = 200 * time.Millisecond
+	maxRetryBackoff     = 30 * time.Second
+)
+
+// retrySetting 请求失败后的重试配置
+type retrySetting struct {
+	attempts int
+	backoff  time.Duration
+}
+
+// wait 返回第 n 次重试前的等待时长：限流时优先使用网关返回的 Retry-After，否则指数退避
+func (rs retrySetting) wait(n int, err error) time.Duration {
+	var te *ThrottleError
+
+	if errors.As(err, &te) && te.RetryAfter > 0 {
+		return te.RetryAfter
+	}
+
+	backoff := rs.backoff
+
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+
+	d := backoff << uint(n)
+
+	// 位移溢出时 d 可能为负数
+	if d <= 0 || d > maxRetryBackoff {
+		d = maxRetryBackoff
+	}
+
+	return d
+}
+
+// withRetry 按重试配置执行 fn
+func withRetry(ctx context.Context, rs retrySetting, log *slog.Logger, fn func() (string, error)) (string, error) {
+	tokenRenewed := false
+
+	for n := 0; ; n++ {
+		data, err := fn()
+
+		// token 失效时请求未被处理，无论是否配置重试都可以换新 token 再试一次
+		if IsTokenExpired(err) && !tokenRenewed {
+			tokenRenewed = true
+			n--
+
+			continue
+		}
+
+		if err == nil || n >= rs.attempts || !IsRetryable(err) {
+			return data, err
+		}
+
+		wait := rs.wait(n, err)
+
+		log.WarnContext(ctx, "request failed, retrying", "attempt", n+1, "wait", wait, "error", err)
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return "", ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,525 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.27.1
+// source: antchain.proto
+
+// 蚂蚁链 REST 网关的 gRPC 封装，供非 Go 服务复用同一套接入(凭证、重试、限流等)
+
+package antchainpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AntChain_Deposit_FullMethodName           = "/antchain.v1.AntChain/Deposit"
+	AntChain_GetDepositContent_FullMethodName = "/antchain.v1.AntChain/GetDepositContent"
+	AntChain_DeploySolidity_FullMethodName    = "/antchain.v1.AntChain/DeploySolidity"
+	AntChain_CallSolidity_FullMethodName      = "/antchain.v1.AntChain/CallSolidity"
+	AntChain_SimulateSolidity_FullMethodName  = "/antchain.v1.AntChain/SimulateSolidity"
+	AntChain_QueryTransaction_FullMethodName  = "/antchain.v1.AntChain/QueryTransaction"
+	AntChain_QueryReceipt_FullMethodName      = "/antchain.v1.AntChain/QueryReceipt"
+	AntChain_QueryBlockHeader_FullMethodName  = "/antchain.v1.AntChain/QueryBlockHeader"
+	AntChain_QueryBlockBody_FullMethodName    = "/antchain.v1.AntChain/QueryBlockBody"
+	AntChain_QueryLastBlock_FullMethodName    = "/antchain.v1.AntChain/QueryLastBlock"
+	AntChain_QueryAccount_FullMethodName      = "/antchain.v1.AntChain/QueryAccount"
+)
+
+// AntChainClient is the client API for AntChain service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AntChainClient interface {
+	// 存证
+	Deposit(ctx context.Context, in *DepositRequest, opts ...grpc.CallOption) (*TxResponse, error)
+	// 读取交易的链上存证内容
+	GetDepositContent(ctx context.Context, in *TxHashRequest, opts ...grpc.CallOption) (*DepositContentResponse, error)
+	// 部署Solidity合约
+	DeploySolidity(ctx context.Context, in *DeployRequest, opts ...grpc.CallOption) (*TxResponse, error)
+	// 异步调用Solidity合约
+	CallSolidity(ctx context.Context, in *CallRequest, opts ...grpc.CallOption) (*TxResponse, error)
+	// 模拟执行Solidity合约调用
+	SimulateSolidity(ctx context.Context, in *CallRequest, opts ...grpc.CallOption) (*SimulateResponse, error)
+	// 查询交易
+	QueryTransaction(ctx context.Context, in *TxHashRequest, opts ...grpc.CallOption) (*DataResponse, error)
+	// 查询交易回执
+	QueryReceipt(ctx context.Context, in *TxHashRequest, opts ...grpc.CallOption) (*DataResponse, error)
+	// 查询块头
+	QueryBlockHeader(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*DataResponse, error)
+	// 查询块体
+	QueryBlockBody(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*DataResponse, error)
+	// 查询最新块高
+	QueryLastBlock(ctx context.Context, in *LastBlockRequest, opts ...grpc.CallOption) (*DataResponse, error)
+	// 查询账户
+	QueryAccount(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*DataResponse, error)
+}
+
+type antChainClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAntChainClient(cc grpc.ClientConnInterface) AntChainClient {
+	return &antChainClient{cc}
+}
+
+func (c *antChainClient) Deposit(ctx context.Context, in *DepositRequest, opts ...grpc.CallOption) (*TxResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TxResponse)
+	err := c.cc.Invoke(ctx, AntChain_Deposit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *antChainClient) GetDepositContent(ctx context.Context, in *TxHashRequest, opts ...grpc.CallOption) (*DepositContentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DepositContentResponse)
+	err := c.cc.Invoke(ctx, AntChain_GetDepositContent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *antChainClient) DeploySolidity(ctx context.Context, in *DeployRequest, opts ...grpc.CallOption) (*TxResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TxResponse)
+	err := c.cc.Invoke(ctx, AntChain_DeploySolidity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *antChainClient) CallSolidity(ctx context.Context, in *CallRequest, opts ...grpc.CallOption) (*TxResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TxResponse)
+	err := c.cc.Invoke(ctx, AntChain_CallSolidity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *antChainClient) SimulateSolidity(ctx context.Context, in *CallRequest, opts ...grpc.CallOption) (*SimulateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SimulateResponse)
+	err := c.cc.Invoke(ctx, AntChain_SimulateSolidity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *antChainClient) QueryTransaction(ctx context.Context, in *TxHashRequest, opts ...grpc.CallOption) (*DataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DataResponse)
+	err := c.cc.Invoke(ctx, AntChain_QueryTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *antChainClient) QueryReceipt(ctx context.Context, in *TxHashRequest, opts ...grpc.CallOption) (*DataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DataResponse)
+	err := c.cc.Invoke(ctx, AntChain_QueryReceipt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *antChainClient) QueryBlockHeader(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*DataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DataResponse)
+	err := c.cc.Invoke(ctx, AntChain_QueryBlockHeader_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *antChainClient) QueryBlockBody(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*DataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DataResponse)
+	err := c.cc.Invoke(ctx, AntChain_QueryBlockBody_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *antChainClient) QueryLastBlock(ctx context.Context, in *LastBlockRequest, opts ...grpc.CallOption) (*DataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DataResponse)
+	err := c.cc.Invoke(ctx, AntChain_QueryLastBlock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *antChainClient) QueryAccount(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*DataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DataResponse)
+	err := c.cc.Invoke(ctx, AntChain_QueryAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AntChainServer is the server API for AntChain service.
+// All implementations must embed UnimplementedAntChainServer
+// for forward compatibility.
+type AntChainServer interface {
+	// 存证
+	Deposit(context.Context, *DepositRequest) (*TxResponse, error)
+	// 读取交易的链上存证内容
+	GetDepositContent(context.Context, *TxHashRequest) (*DepositContentResponse, error)
+	// 部署Solidity合约
+	DeploySolidity(context.Context, *DeployRequest) (*TxResponse, error)
+	// 异步调用Solidity合约
+	CallSolidity(context.Context, *CallRequest) (*TxResponse, error)
+	// 模拟执行Solidity合约调用
+	SimulateSolidity(context.Context, *CallRequest) (*SimulateResponse, error)
+	// 查询交易
+	QueryTransaction(context.Context, *TxHashRequest) (*DataResponse, error)
+	// 查询交易回执
+	QueryReceipt(context.Context, *TxHashRequest) (*DataResponse, error)
+	// 查询块头
+	QueryBlockHeader(context.Context, *BlockRequest) (*DataResponse, error)
+	// 查询块体
+	QueryBlockBody(context.Context, *BlockRequest) (*DataResponse, error)
+	// 查询最新块高
+	QueryLastBlock(context.Context, *LastBlockRequest) (*DataResponse, error)
+	// 查询账户
+	QueryAccount(context.Context, *AccountRequest) (*DataResponse, error)
+	mustEmbedUnimplementedAntChainServer()
+}
+
+// UnimplementedAntChainServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAntChainServer struct{}
+
+func (UnimplementedAntChainServer) Deposit(context.Context, *DepositRequest) (*TxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Deposit not implemented")
+}
+func (UnimplementedAntChainServer) GetDepositContent(context.Context, *TxHashRequest) (*DepositContentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDepositContent not implemented")
+}
+func (UnimplementedAntChainServer) DeploySolidity(context.Context, *DeployRequest) (*TxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeploySolidity not implemented")
+}
+func (UnimplementedAntChainServer) CallSolidity(context.Context, *CallRequest) (*TxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CallSolidity not implemented")
+}
+func (UnimplementedAntChainServer) SimulateSolidity(context.Context, *CallRequest) (*SimulateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SimulateSolidity not implemented")
+}
+func (UnimplementedAntChainServer) QueryTransaction(context.Context, *TxHashRequest) (*DataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryTransaction not implemented")
+}
+func (UnimplementedAntChainServer) QueryReceipt(context.Context, *TxHashRequest) (*DataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryReceipt not implemented")
+}
+func (UnimplementedAntChainServer) QueryBlockHeader(context.Context, *BlockRequest) (*DataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryBlockHeader not implemented")
+}
+func (UnimplementedAntChainServer) QueryBlockBody(context.Context, *BlockRequest) (*DataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryBlockBody not implemented")
+}
+func (UnimplementedAntChainServer) QueryLastBlock(context.Context, *LastBlockRequest) (*DataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryLastBlock not implemented")
+}
+func (UnimplementedAntChainServer) QueryAccount(context.Context, *AccountRequest) (*DataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryAccount not implemented")
+}
+func (UnimplementedAntChainServer) mustEmbedUnimplementedAntChainServer() {}
+func (UnimplementedAntChainServer) testEmbeddedByValue()                  {}
+
+// UnsafeAntChainServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AntChainServer will
+// result in compilation errors.
+type UnsafeAntChainServer interface {
+	mustEmbedUnimplementedAntChainServer()
+}
+
+func RegisterAntChainServer(s grpc.ServiceRegistrar, srv AntChainServer) {
+	// If the following call pancis, it indicates UnimplementedAntChainServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AntChain_ServiceDesc, srv)
+}
+
+func _AntChain_Deposit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DepositRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AntChainServer).Deposit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AntChain_Deposit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AntChainServer).Deposit(ctx, req.(*DepositRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AntChain_GetDepositContent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AntChainServer).GetDepositContent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AntChain_GetDepositContent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AntChainServer).GetDepositContent(ctx, req.(*TxHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AntChain_DeploySolidity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeployRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AntChainServer).DeploySolidity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AntChain_DeploySolidity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AntChainServer).DeploySolidity(ctx, req.(*DeployRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AntChain_CallSolidity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AntChainServer).CallSolidity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AntChain_CallSolidity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AntChainServer).CallSolidity(ctx, req.(*CallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AntChain_SimulateSolidity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AntChainServer).SimulateSolidity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AntChain_SimulateSolidity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AntChainServer).SimulateSolidity(ctx, req.(*CallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AntChain_QueryTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AntChainServer).QueryTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AntChain_QueryTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AntChainServer).QueryTransaction(ctx, req.(*TxHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AntChain_QueryReceipt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AntChainServer).QueryReceipt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AntChain_QueryReceipt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AntChainServer).QueryReceipt(ctx, req.(*TxHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AntChain_QueryBlockHeader_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AntChainServer).QueryBlockHeader(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AntChain_QueryBlockHeader_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AntChainServer).QueryBlockHeader(ctx, req.(*BlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AntChain_QueryBlockBody_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AntChainServer).QueryBlockBody(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AntChain_QueryBlockBody_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AntChainServer).QueryBlockBody(ctx, req.(*BlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AntChain_QueryLastBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LastBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AntChainServer).QueryLastBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AntChain_QueryLastBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AntChainServer).QueryLastBlock(ctx, req.(*LastBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AntChain_QueryAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AntChainServer).QueryAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AntChain_QueryAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AntChainServer).QueryAccount(ctx, req.(*AccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AntChain_ServiceDesc is the grpc.ServiceDesc for AntChain service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AntChain_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "antchain.v1.AntChain",
+	HandlerType: (*AntChainServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Deposit",
+			Handler:    _AntChain_Deposit_Handler,
+		},
+		{
+			MethodName: "GetDepositContent",
+			Handler:    _AntChain_GetDepositContent_Handler,
+		},
+		{
+			MethodName: "DeploySolidity",
+			Handler:    _AntChain_DeploySolidity_Handler,
+		},
+		{
+			MethodName: "CallSolidity",
+			Handler:    _AntChain_CallSolidity_Handler,
+		},
+		{
+			MethodName: "SimulateSolidity",
+			Handler:    _AntChain_SimulateSolidity_Handler,
+		},
+		{
+			MethodName: "QueryTransaction",
+			Handler:    _AntChain_QueryTransaction_Handler,
+		},
+		{
+			MethodName: "QueryReceipt",
+			Handler:    _AntChain_QueryReceipt_Handler,
+		},
+		{
+			MethodName: "QueryBlockHeader",
+			Handler:    _AntChain_QueryBlockHeader_Handler,
+		},
+		{
+			MethodName: "QueryBlockBody",
+			Handler:    _AntChain_QueryBlockBody_Handler,
+		},
+		{
+			MethodName: "QueryLastBlock",
+			Handler:    _AntChain_QueryLastBlock_Handler,
+		},
+		{
+			MethodName: "QueryAccount",
+			Handler:    _AntChain_QueryAccount_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "antchain.proto",
+}
@@ -0,0 +1,184 @@
+// Package rpc 以 gRPC 服务(定义见 antchainpb/antchain.proto)暴露 antchain.Client 的存证、合约及查询能力，
+// 便于其它语言的服务复用同一套蚂蚁链接入
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/shenghui0779/antchain"
+	"github.com/shenghui0779/antchain/rpc/antchainpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server 实现 antchainpb.AntChainServer
+type Server struct {
+	antchainpb.UnimplementedAntChainServer
+
+	cli antchain.Client
+}
+
+// NewServer 返回 gRPC 服务实现
+func NewServer(cli antchain.Client) *Server {
+	return &Server{cli: cli}
+}
+
+// Register 将服务注册到 gRPC Server，如：rpc.Register(grpc.NewServer(), cli)
+func Register(s grpc.ServiceRegistrar, cli antchain.Client) {
+	antchainpb.RegisterAntChainServer(s, NewServer(cli))
+}
+
+func (s *Server) Deposit(ctx context.Context, req *antchainpb.DepositRequest) (*antchainpb.TxResponse, error) {
+	options := make([]antchain.ChainCallOption, 0, len(req.GetProperties()))
+
+	for k, v := range req.GetProperties() {
+		options = append(options, antchain.WithProperty(k, v))
+	}
+
+	hash, err := s.cli.Deposit(ctx, req.GetContent(), int(req.GetGas()), options...)
+
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &antchainpb.TxResponse{Hash: hash}, nil
+}
+
+func (s *Server) GetDepositContent(ctx context.Context, req *antchainpb.TxHashRequest) (*antchainpb.DepositContentResponse, error) {
+	content, err := s.cli.GetDepositContent(ctx, req.GetHash())
+
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &antchainpb.DepositContentResponse{Content: content}, nil
+}
+
+func (s *Server) DeploySolidity(ctx context.Context, req *antchainpb.DeployRequest) (*antchainpb.TxResponse, error) {
+	hash, err := s.cli.DeploySolidity(ctx, req.GetName(), req.GetCode(), int(req.GetGas()), vmOptions(req.GetVmType())...)
+
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &antchainpb.TxResponse{Hash: hash}, nil
+}
+
+func (s *Server) CallSolidity(ctx context.Context, req *antchainpb.CallRequest) (*antchainpb.TxResponse, error) {
+	hash, err := s.cli.AsyncCallSolidity(ctx, req.GetContractName(), req.GetMethodSign(), req.GetInputParams(), req.GetOutTypes(), int(req.GetGas()), vmOptions(req.GetVmType())...)
+
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &antchainpb.TxResponse{Hash: hash}, nil
+}
+
+func (s *Server) SimulateSolidity(ctx context.Context, req *antchainpb.CallRequest) (*antchainpb.SimulateResponse, error) {
+	ret, err := s.cli.SimulateSolidity(ctx, req.GetContractName(), req.GetMethodSign(), req.GetInputParams(), req.GetOutTypes(), vmOptions(req.GetVmType())...)
+
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &antchainpb.SimulateResponse{
+		Output:  ret.Output,
+		GasUsed: ret.GasUsed,
+		Result:  ret.Result,
+	}, nil
+}
+
+func (s *Server) QueryTransaction(ctx context.Context, req *antchainpb.TxHashRequest) (*antchainpb.DataResponse, error) {
+	return dataResponse(s.cli.QueryTransaction(ctx, req.GetHash()))
+}
+
+func (s *Server) QueryReceipt(ctx context.Context, req *antchainpb.TxHashRequest) (*antchainpb.DataResponse, error) {
+	return dataResponse(s.cli.QueryReceipt(ctx, req.GetHash()))
+}
+
+func (s *Server) QueryBlockHeader(ctx context.Context, req *antchainpb.BlockRequest) (*antchainpb.DataResponse, error) {
+	return dataResponse(s.cli.QueryBlockHeader(ctx, req.GetNumber()))
+}
+
+func (s *Server) QueryBlockBody(ctx context.Context, req *antchainpb.BlockRequest) (*antchainpb.DataResponse, error) {
+	return dataResponse(s.cli.QueryBlockBody(ctx, req.GetNumber()))
+}
+
+func (s *Server) QueryLastBlock(ctx context.Context, req *antchainpb.LastBlockRequest) (*antchainpb.DataResponse, error) {
+	return dataResponse(s.cli.QueryLastBlock(ctx))
+}
+
+func (s *Server) QueryAccount(ctx context.Context, req *antchainpb.AccountRequest) (*antchainpb.DataResponse, error) {
+	account, err := s.cli.QueryAccount(ctx, req.GetAccount())
+
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	b, err := json.Marshal(account)
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &antchainpb.DataResponse{Data: string(b)}, nil
+}
+
+func vmOptions(vm string) []antchain.ChainCallOption {
+	if len(vm) == 0 {
+		return nil
+	}
+
+	return []antchain.ChainCallOption{antchain.WithVMType(antchain.VMType(vm))}
+}
+
+func dataResponse(data string, err error) (*antchainpb.DataResponse, error) {
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &antchainpb.DataResponse{Data: data}, nil
+}
+
+// toStatus 将 SDK 错误转换为 gRPC 状态码，网关错误码(ErrCode)附加在错误信息中
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case antchain.IsThrottled(err):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, antchain.ErrGasBudgetExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, antchain.ErrShakehandFailed), antchain.IsTokenExpired(err):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, antchain.ErrInvalidKey), errors.Is(err, antchain.ErrNoCredentials):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, antchain.ErrRequestFailed):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, antchain.ErrSchemaViolation):
+		return status.Error(codes.DataLoss, err.Error())
+	}
+
+	var ae *antchain.APIError
+
+	if errors.As(err, &ae) {
+		if ae.StatusCode == http.StatusUnauthorized || ae.StatusCode == http.StatusForbidden {
+			return status.Error(codes.PermissionDenied, err.Error())
+		}
+
+		if antchain.IsRetryable(err) {
+			return status.Error(codes.Unavailable, err.Error())
+		}
+
+		// 网关业务失败(如：合约执行失败、余额不足)
+		return status.Error(codes.Aborted, err.Error())
+	}
+
+	return status.Error(codes.Unknown, err.Error())
+}
@@ -0,0 +1,115 @@
+package antchain
+
+import (
+	"context"
+	"io"
+)
+
+// AccountService 链账户相关操作
+type AccountService interface {
+	// CreateAccount 创建账户
+	CreateAccount(ctx context.Context, account, kmsID string, gas int) (string, error)
+
+	// Transfer 从当前链账户向 to 转账
+	Transfer(ctx context.Context, to string, amount int64, gas int) (string, error)
+
+	// RecoverAccount 依次执行账户恢复的预重置、重置及校验步骤，并通过 Progress 回调进度
+	RecoverAccount(ctx context.Context, req *RecoverRequest) error
+
+	// QueryAccount 查询账户
+	QueryAccount(ctx context.Context, account string) (*Account, error)
+
+	// QueryAccountByPublicKey 根据公钥推导账户Identity并查询账户
+	QueryAccountByPublicKey(ctx context.Context, pubKey []byte) (*Account, error)
+}
+
+// DepositService 存证相关操作
+type DepositService interface {
+	// Deposit 存证，可通过 WithProperty 附加扩展属性(如：业务类别、标签、操作人)
+	Deposit(ctx context.Context, content string, gas int, options ...ChainCallOption) (string, error)
+
+	// GetDepositContent 返回交易的链上存证内容
+	GetDepositContent(ctx context.Context, txHash string) (string, error)
+
+	// VerifyDeposit 校验交易的链上存证内容与预期内容(原始内容或其SHA-256摘要)是否一致，不一致返回 ErrDepositMismatch，交易执行失败返回 ErrTxFailed
+	VerifyDeposit(ctx context.Context, txHash, expectedContent string) error
+
+	// DepositChunked 分片存证超过大小限制的内容，逐片存证摘要后存证链接各分片的清单
+	DepositChunked(ctx context.Context, r io.Reader, chunkSize, gas int, options ...ChainCallOption) (*ChunkManifest, error)
+
+	// VerifyChunkedDeposit 根据链上清单逐片校验内容，不一致返回 ErrDepositMismatch
+	VerifyChunkedDeposit(ctx context.Context, manifestTxHash string, r io.Reader) error
+
+	// GetDepositCertificate 查询存证交易的存证证书(JSON 及可选的 PDF 文件)
+	GetDepositCertificate(ctx context.Context, txHash string, withPDF bool) (*DepositCertificate, error)
+}
+
+// NotaryService 司法存证相关操作(需要开通司法存证服务)
+type NotaryService interface {
+	// CreateNotaryToken 创建司法存证事务，返回串联各存证环节的 token
+	CreateNotaryToken(ctx context.Context, req *NotaryTokenRequest) (string, error)
+
+	// SubmitNotaryText 在存证事务的 phase 环节提交文本存证
+	SubmitNotaryText(ctx context.Context, token, phase, content string) (*NotaryEvidence, error)
+
+	// SubmitNotaryDigest 在存证事务的 phase 环节提交文件摘要存证
+	SubmitNotaryDigest(ctx context.Context, token, phase string, digest *Digest) (*NotaryEvidence, error)
+
+	// GetNotaryEvidence 根据存证编号查询司法存证
+	GetNotaryEvidence(ctx context.Context, evidenceID string) (*NotaryEvidence, error)
+}
+
+// ContractService 合约相关操作
+type ContractService interface {
+	// DeploySolidity 部署Solidity合约，可通过 WithVMType 部署其它虚拟机类型的合约
+	DeploySolidity(ctx context.Context, name, code string, gas int, options ...ChainCallOption) (string, error)
+
+	// DeploySolidityFromSource 调用 solc 编译源码并部署合约，合约ABI注册到 ABIRegistry
+	DeploySolidityFromSource(ctx context.Context, name, sourcePath string, gas int, options ...DeployOption) (string, error)
+
+	// DeploySolidityFromArtifact 从 Truffle/Hardhat 的 artifact JSON 或 .bin 文件部署合约，合约ABI注册到 ABIRegistry
+	DeploySolidityFromArtifact(ctx context.Context, name, artifactPath string, gas int, options ...DeployOption) (string, error)
+
+	// ABIRegistry 返回合约ABI注册表
+	ABIRegistry() *ABIRegistry
+
+	// AsyncCallSolidity 异步调用Solidity合约，可通过 WithVMType 调用其它虚拟机类型的合约
+	AsyncCallSolidity(ctx context.Context, contractName, methodSign, inputParams, outTypes string, gas int, options ...ChainCallOption) (string, error)
+
+	// SimulateSolidity 模拟执行Solidity合约调用(不改变状态、不消耗gas)，返回执行结果及预估gas
+	SimulateSolidity(ctx context.Context, contractName, methodSign, inputParams, outTypes string, options ...ChainCallOption) (*SimulateResult, error)
+
+	// NewTx 返回链式构造合约调用的 TxBuilder，如：
+	// NewTx().Contract("x").Method("set(string)").Args("v").Gas(100000).Send(ctx)
+	NewTx() *TxBuilder
+
+	// SubmitMultiSig 提交已收集足够签名的多签交易；签名仅在客户端校验，不随交易提交
+	SubmitMultiSig(ctx context.Context, tx *MultiSigTx) (string, error)
+}
+
+// QueryService 交易及区块查询
+type QueryService interface {
+	// QueryTransaction 查询交易
+	QueryTransaction(ctx context.Context, hash string) (string, error)
+
+	// QueryReceipt 查询交易回执
+	QueryReceipt(ctx context.Context, hash string) (string, error)
+
+	// QueryBlockHeader 查询块头
+	QueryBlockHeader(ctx context.Context, blockNumber int64) (string, error)
+
+	// QueryBlockBody 查询块体
+	QueryBlockBody(ctx context.Context, blockNumber int64) (string, error)
+
+	// QueryLastBlock 查询最新块高
+	QueryLastBlock(ctx context.Context) (string, error)
+
+	// AccountHistory 扫描区块查询账户相关的交易，按块高从新到旧通过游标分页
+	AccountHistory(ctx context.Context, req *HistoryRequest) (*HistoryPage, error)
+
+	// QueryTxProof 查询交易所在区块并构造交易的 Merkle 包含证明，计算的根与块头 transactionRoot 不一致时返回 ErrDecodeFailed
+	QueryTxProof(ctx context.Context, hash string) (*InclusionProof, error)
+
+	// FetchBlocks 并发获取 [from, to] 区间的块头与块体，按块高顺序输出；单个区块失败不影响其它区块
+	FetchBlocks(ctx context.Context, from, to int64, concurrency int, options ...FetchOption) <-chan *BlockResult
+}
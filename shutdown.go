@@ -0,0 +1,156 @@
+package antchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrClosed 客户端已关闭
+var ErrClosed = errors.New("antchain: client closed")
+
+// ShutdownError Close 在 ctx 结束前未能完成的工作
+type ShutdownError struct {
+	Tasks    []string // 未退出的后台任务(如：token keepalive、config watcher)
+	InFlight int      // 未完成的请求数
+	Err      error    // ctx 的错误
+}
+
+func (e *ShutdownError) Error() string {
+	pending := e.Tasks
+
+	if e.InFlight > 0 {
+		pending = append(pending[:len(pending):len(pending)], fmt.Sprintf("%d in-flight requests", e.InFlight))
+	}
+
+	return fmt.Sprintf("antchain: shutdown incomplete (%s): %v", strings.Join(pending, ", "), e.Err)
+}
+
+func (e *ShutdownError) Unwrap() error {
+	return e.Err
+}
+
+// lifecycle 跟踪后台任务及进行中的请求，用于 Close 时等待其结束
+type lifecycle struct {
+	mutex    sync.Mutex
+	closed   bool
+	inflight int
+	idle     chan struct{} // 关闭后，请求全部结束时关闭
+	tasks    map[string]chan struct{}
+
+	ctx    context.Context // 后台任务使用，Close 超时后取消
+	cancel context.CancelFunc
+	done   chan struct{} // Close 时关闭，通知后台任务退出
+}
+
+func newLifecycle() *lifecycle {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &lifecycle{
+		tasks:  make(map[string]chan struct{}),
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+}
+
+// spawn 以 name 启动后台任务
+func (l *lifecycle) spawn(name string, fn func()) {
+	exited := make(chan struct{})
+
+	l.mutex.Lock()
+	l.tasks[name] = exited
+	l.mutex.Unlock()
+
+	go func() {
+		defer close(exited)
+
+		fn()
+	}()
+}
+
+// begin 标记请求开始，客户端关闭后返回 ErrClosed
+func (l *lifecycle) begin() (func(), error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.closed {
+		return nil, ErrClosed
+	}
+
+	l.inflight++
+
+	return func() {
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+
+		l.inflight--
+
+		if l.closed && l.inflight == 0 {
+			close(l.idle)
+		}
+	}, nil
+}
+
+// shutdown 拒绝新的请求并通知后台任务退出，在 ctx 结束前等待后台任务及进行中的请求完成
+func (l *lifecycle) shutdown(ctx context.Context) error {
+	l.mutex.Lock()
+
+	if !l.closed {
+		l.closed = true
+		l.idle = make(chan struct{})
+
+		if l.inflight == 0 {
+			close(l.idle)
+		}
+
+		close(l.done)
+	}
+
+	idle := l.idle
+	tasks := make(map[string]chan struct{}, len(l.tasks))
+
+	for name, exited := range l.tasks {
+		tasks[name] = exited
+	}
+
+	l.mutex.Unlock()
+
+	var pending []string
+
+	for name, exited := range tasks {
+		select {
+		case <-exited:
+		case <-ctx.Done():
+			pending = append(pending, name)
+		}
+	}
+
+	inflight := 0
+
+	select {
+	case <-idle:
+	case <-ctx.Done():
+		l.mutex.Lock()
+		inflight = l.inflight
+		l.mutex.Unlock()
+	}
+
+	// 取消后台任务仍在进行的操作(如：shakehand)
+	l.cancel()
+
+	if len(pending) == 0 && inflight == 0 {
+		return nil
+	}
+
+	sort.Strings(pending)
+
+	return &ShutdownError{
+		Tasks:    pending,
+		InFlight: inflight,
+		Err:      ctx.Err(),
+	}
+}
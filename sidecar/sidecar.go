@@ -0,0 +1,284 @@
+// Package sidecar 提供本地 REST 代理：由 sidecar 持有 AccessKey 并对接蚂蚁链网关，
+// 业务容器通过简化的本地接口(Bearer Token 认证)调用，无需接触私钥
+package sidecar
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/shenghui0779/antchain"
+)
+
+// maxBodySize 请求体的最大长度
+const maxBodySize = 4 << 20
+
+// Handler 本地 REST 接口：
+//
+//	POST /v1/deposits                  存证 {"content","gas","properties"} => {"hash"}
+//	GET  /v1/deposits/{hash}           读取存证内容 => {"content"}
+//	POST /v1/contracts/deploy          部署合约 {"name","code","gas","vm_type"} => {"hash"}
+//	POST /v1/contracts/call            调用合约 {"contract_name","method_sign","input_params","out_types","gas","vm_type"} => {"hash"}
+//	POST /v1/contracts/simulate        模拟调用合约(参数同上) => {"output","gas_used","result"}
+//	GET  /v1/transactions/{hash}       查询交易
+//	GET  /v1/receipts/{hash}           查询交易回执
+//	GET  /v1/blocks/last               查询最新块高
+//	GET  /v1/blocks/{number}/header    查询块头
+//	GET  /v1/blocks/{number}/body      查询块体
+//	GET  /v1/accounts/{account}        查询账户
+//	GET  /v1/diagnostics               客户端运行状态(连接池、域名解析、最近一次 shakehand)
+//
+// 查询接口直接返回网关数据(JSON)；失败返回 {"error","code"}
+type Handler struct {
+	cli    antchain.Client
+	tokens [][]byte
+}
+
+// NewHandler 返回本地 REST 接口，tokens 为允许访问的 Bearer Token(去除首尾空白，忽略空令牌)，为空则不认证(仅限 Unix Socket 等受信任的监听方式)
+func NewHandler(cli antchain.Client, tokens ...string) *Handler {
+	h := &Handler{cli: cli}
+
+	for _, v := range tokens {
+		if v = strings.TrimSpace(v); len(v) != 0 {
+			h.tokens = append(h.tokens, []byte(v))
+		}
+	}
+
+	return h
+}
+
+func (h *Handler) authorized(r *http.Request) bool {
+	if len(h.tokens) == 0 {
+		return true
+	}
+
+	auth := r.Header.Get("Authorization")
+
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return false
+	}
+
+	token := []byte(strings.TrimPrefix(auth, "Bearer "))
+
+	ok := false
+
+	// 逐个比较所有 token，避免通过耗时推测
+	for _, v := range h.tokens {
+		if subtle.ConstantTimeCompare(token, v) == 1 {
+			ok = true
+		}
+	}
+
+	return ok
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "", "unauthorized")
+
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	if len(parts) < 2 || parts[0] != "v1" {
+		writeError(w, http.StatusNotFound, "", "not found")
+
+		return
+	}
+
+	ctx := r.Context()
+
+	switch {
+	case r.Method == http.MethodPost && len(parts) == 2 && parts[1] == "deposits":
+		h.deposit(ctx, w, r)
+	case r.Method == http.MethodGet && len(parts) == 3 && parts[1] == "deposits":
+		content, err := h.cli.GetDepositContent(ctx, parts[2])
+
+		writeResult(w, map[string]string{"content": content}, err)
+	case r.Method == http.MethodPost && len(parts) == 3 && parts[1] == "contracts":
+		h.contract(ctx, w, r, parts[2])
+	case r.Method == http.MethodGet && len(parts) == 3 && parts[1] == "transactions":
+		writeData(w)(h.cli.QueryTransaction(ctx, parts[2]))
+	case r.Method == http.MethodGet && len(parts) == 3 && parts[1] == "receipts":
+		writeData(w)(h.cli.QueryReceipt(ctx, parts[2]))
+	case r.Method == http.MethodGet && len(parts) == 3 && parts[1] == "blocks" && parts[2] == "last":
+		writeData(w)(h.cli.QueryLastBlock(ctx))
+	case r.Method == http.MethodGet && len(parts) == 4 && parts[1] == "blocks":
+		h.block(ctx, w, parts[2], parts[3])
+	case r.Method == http.MethodGet && len(parts) == 3 && parts[1] == "accounts":
+		account, err := h.cli.QueryAccount(ctx, parts[2])
+
+		writeResult(w, account, err)
+	case r.Method == http.MethodGet && len(parts) == 2 && parts[1] == "diagnostics":
+		writeResult(w, h.cli.Diagnostics(ctx), nil)
+	default:
+		writeError(w, http.StatusNotFound, "", "not found")
+	}
+}
+
+type depositRequest struct {
+	Content    string            `json:"content"`
+	Gas        int               `json:"gas"`
+	Properties map[string]string `json:"properties"`
+}
+
+func (h *Handler) deposit(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	req := new(depositRequest)
+
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, "", err.Error())
+
+		return
+	}
+
+	options := make([]antchain.ChainCallOption, 0, len(req.Properties))
+
+	for k, v := range req.Properties {
+		options = append(options, antchain.WithProperty(k, v))
+	}
+
+	hash, err := h.cli.Deposit(ctx, req.Content, req.Gas, options...)
+
+	writeResult(w, map[string]string{"hash": hash}, err)
+}
+
+type contractRequest struct {
+	Name         string `json:"name"`
+	Code         string `json:"code"`
+	ContractName string `json:"contract_name"`
+	MethodSign   string `json:"method_sign"`
+	InputParams  string `json:"input_params"`
+	OutTypes     string `json:"out_types"`
+	Gas          int    `json:"gas"`
+	VMType       string `json:"vm_type"`
+}
+
+func (h *Handler) contract(ctx context.Context, w http.ResponseWriter, r *http.Request, action string) {
+	req := new(contractRequest)
+
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, "", err.Error())
+
+		return
+	}
+
+	var options []antchain.ChainCallOption
+
+	if len(req.VMType) != 0 {
+		options = append(options, antchain.WithVMType(antchain.VMType(req.VMType)))
+	}
+
+	switch action {
+	case "deploy":
+		hash, err := h.cli.DeploySolidity(ctx, req.Name, req.Code, req.Gas, options...)
+
+		writeResult(w, map[string]string{"hash": hash}, err)
+	case "call":
+		hash, err := h.cli.AsyncCallSolidity(ctx, req.ContractName, req.MethodSign, req.InputParams, req.OutTypes, req.Gas, options...)
+
+		writeResult(w, map[string]string{"hash": hash}, err)
+	case "simulate":
+		ret, err := h.cli.SimulateSolidity(ctx, req.ContractName, req.MethodSign, req.InputParams, req.OutTypes, options...)
+
+		if err != nil {
+			writeResult(w, nil, err)
+
+			return
+		}
+
+		writeResult(w, map[string]interface{}{
+			"output":   ret.Output,
+			"gas_used": ret.GasUsed,
+			"result":   ret.Result,
+		}, nil)
+	default:
+		writeError(w, http.StatusNotFound, "", "not found")
+	}
+}
+
+func (h *Handler) block(ctx context.Context, w http.ResponseWriter, number, part string) {
+	n, err := strconv.ParseInt(number, 10, 64)
+
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "", "invalid block number")
+
+		return
+	}
+
+	switch part {
+	case "header":
+		writeData(w)(h.cli.QueryBlockHeader(ctx, n))
+	case "body":
+		writeData(w)(h.cli.QueryBlockBody(ctx, n))
+	default:
+		writeError(w, http.StatusNotFound, "", "not found")
+	}
+}
+
+// writeData 返回写出网关数据(JSON)的函数
+func writeData(w http.ResponseWriter) func(data string, err error) {
+	return func(data string, err error) {
+		if err != nil {
+			writeResult(w, nil, err)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write([]byte(data))
+	}
+}
+
+func writeResult(w http.ResponseWriter, v interface{}, err error) {
+	if err != nil {
+		code, status := errorStatus(err)
+
+		writeError(w, status, code, err.Error())
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code antchain.ErrCode, msg string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+
+	json.NewEncoder(w).Encode(map[string]string{
+		"error": msg,
+		"code":  string(code),
+	})
+}
+
+// errorStatus 返回 SDK 错误对应的网关错误码及 HTTP 状态码
+func errorStatus(err error) (antchain.ErrCode, int) {
+	var ae *antchain.APIError
+
+	code := antchain.ErrCode("")
+
+	if errors.As(err, &ae) {
+		code = ae.Code
+	}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return code, http.StatusGatewayTimeout
+	case antchain.IsThrottled(err), errors.Is(err, antchain.ErrGasBudgetExceeded):
+		return code, http.StatusTooManyRequests
+	case antchain.IsRetryable(err), errors.Is(err, antchain.ErrSchemaViolation):
+		return code, http.StatusBadGateway
+	case ae != nil:
+		return code, http.StatusUnprocessableEntity
+	}
+
+	return code, http.StatusInternalServerError
+}
@@ -0,0 +1,133 @@
+package antchain
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidSignature 签名校验失败
+var ErrInvalidSignature = errors.New("antchain: invalid signature")
+
+// TxSigner 本地签名器，用于多签交易(MultiSigTx)的签名方在本地对 Payload 签名
+type TxSigner interface {
+	// PublicKey 返回账户公钥(hex)
+	PublicKey() (string, error)
+
+	// SignTx 对交易数据签名，返回签名(hex)
+	SignTx(payload []byte) (string, error)
+}
+
+type rsaTxSigner struct {
+	key *PrivateKey
+}
+
+// NewRSATxSigner 返回使用 RSA 私钥(SHA256WithRSA)签名的 TxSigner
+func NewRSATxSigner(key *PrivateKey) TxSigner {
+	return &rsaTxSigner{key: key}
+}
+
+func (s *rsaTxSigner) PublicKey() (string, error) {
+	b, err := x509.MarshalPKIXPublicKey(&s.key.key.PublicKey)
+
+	if err != nil {
+		return "", wrapErr(ErrInvalidKey, err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+func (s *rsaTxSigner) SignTx(payload []byte) (string, error) {
+	sign, err := s.key.Sign(crypto.SHA256, payload)
+
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(sign), nil
+}
+
+type ecdsaTxSigner struct {
+	key *ecdsa.PrivateKey
+}
+
+// NewECDSATxSigner 返回使用 ECDSA 私钥(SHA256WithECDSA，ASN.1编码)签名的 TxSigner
+func NewECDSATxSigner(key *ecdsa.PrivateKey) TxSigner {
+	return &ecdsaTxSigner{key: key}
+}
+
+func (s *ecdsaTxSigner) PublicKey() (string, error) {
+	return hex.EncodeToString(elliptic.Marshal(s.key.Curve, s.key.X, s.key.Y)), nil
+}
+
+func (s *ecdsaTxSigner) SignTx(payload []byte) (string, error) {
+	h := sha256.Sum256(payload)
+
+	sign, err := ecdsa.SignASN1(rand.Reader, s.key, h[:])
+
+	if err != nil {
+		return "", wrapErr(ErrSignFailed, err)
+	}
+
+	return hex.EncodeToString(sign), nil
+}
+
+// VerifyTxSignature 校验 TxSigner 的签名：pubKey 为 PublicKey 返回的公钥(RSA 为 PKIX，ECDSA 为未压缩的曲线点)，
+// sign 为 SignTx 返回的签名，均为hex
+func VerifyTxSignature(pubKey, sign string, payload []byte) error {
+	key, err := hex.DecodeString(pubKey)
+
+	if err != nil {
+		return wrapErr(ErrInvalidKey, err)
+	}
+
+	sig, err := hex.DecodeString(sign)
+
+	if err != nil {
+		return wrapErr(ErrInvalidSignature, err)
+	}
+
+	h := sha256.Sum256(payload)
+
+	if pub, err := x509.ParsePKIXPublicKey(key); err == nil {
+		switch pub := pub.(type) {
+		case *rsa.PublicKey:
+			if err = rsa.VerifyPKCS1v15(pub, crypto.SHA256, h[:], sig); err != nil {
+				return wrapErr(ErrInvalidSignature, err)
+			}
+
+			return nil
+		case *ecdsa.PublicKey:
+			if !ecdsa.VerifyASN1(pub, h[:], sig) {
+				return ErrInvalidSignature
+			}
+
+			return nil
+		}
+
+		return wrapErr(ErrInvalidKey, fmt.Errorf("unsupported public key %T", pub))
+	}
+
+	for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+		x, y := elliptic.Unmarshal(curve, key)
+
+		if x == nil {
+			continue
+		}
+
+		if !ecdsa.VerifyASN1(&ecdsa.PublicKey{Curve: curve, X: x, Y: y}, h[:], sig) {
+			return ErrInvalidSignature
+		}
+
+		return nil
+	}
+
+	return wrapErr(ErrInvalidKey, errors.New("unsupported public key"))
+}
@@ -0,0 +1,73 @@
+package antchain
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"testing"
+)
+
+func testECDSASigner(t *testing.T) TxSigner {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewECDSATxSigner(key)
+}
+
+func TestVerifyTxSignature(t *testing.T) {
+	payload := []byte(`{"method":"DEPOSIT"}`)
+
+	ecdsaSigner := testECDSASigner(t)
+	rsaSigner := NewRSATxSigner(testPrivateKey(t))
+
+	ecdsaPub, _ := ecdsaSigner.PublicKey()
+	rsaPub, _ := rsaSigner.PublicKey()
+
+	ecdsaSig, err := ecdsaSigner.SignTx(payload)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rsaSig, err := rsaSigner.SignTx(payload)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name    string
+		pubKey  string
+		sign    string
+		payload []byte
+		want    error
+	}{
+		{"ecdsa", ecdsaPub, ecdsaSig, payload, nil},
+		{"rsa", rsaPub, rsaSig, payload, nil},
+		{"tampered payload", ecdsaPub, ecdsaSig, []byte(`{}`), ErrInvalidSignature},
+		{"wrong key", rsaPub, ecdsaSig, payload, ErrInvalidSignature},
+		{"invalid signature hex", ecdsaPub, "zz", payload, ErrInvalidSignature},
+		{"invalid key hex", "zz", ecdsaSig, payload, ErrInvalidKey},
+		{"unsupported key", "0102", ecdsaSig, payload, ErrInvalidKey},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := VerifyTxSignature(c.pubKey, c.sign, c.payload)
+
+			if c.want == nil && err != nil {
+				t.Fatal(err)
+			}
+
+			if c.want != nil && !errors.Is(err, c.want) {
+				t.Fatalf("err = %v, want %v", err, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package antchain
+
+import (
+	"errors"
+	"sync"
+)
+
+// errFlightPanic 合并请求的执行者 panic 时，等待者收到的错误
+var errFlightPanic = errors.New("antchain: merged request panicked")
+
+type flightCall struct {
+	wg   sync.WaitGroup
+	data string
+	err  error
+}
+
+// flightGroup 合并相同 key 的并发请求，只向网关发起一次
+type flightGroup struct {
+	mutex sync.Mutex
+	calls map[string]*flightCall
+}
+
+func (g *flightGroup) do(key string, fn func() (string, error)) (string, error) {
+	g.mutex.Lock()
+
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+
+	if call, ok := g.calls[key]; ok {
+		g.mutex.Unlock()
+		call.wg.Wait()
+
+		return call.data, call.err
+	}
+
+	call := new(flightCall)
+	call.wg.Add(1)
+	g.calls[key] = call
+
+	g.mutex.Unlock()
+
+	// fn panic 时同样须唤醒等待者并清理 key，否则后续相同请求会永久阻塞
+	defer func() {
+		g.mutex.Lock()
+		delete(g.calls, key)
+		g.mutex.Unlock()
+
+		call.wg.Done()
+	}()
+
+	// fn 正常返回时覆盖
+	call.err = errFlightPanic
+
+	call.data, call.err = fn()
+
+	return call.data, call.err
+}
@@ -0,0 +1,53 @@
+package antchain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFlightGroupPanicReleasesWaiters(t *testing.T) {
+	var g flightGroup
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	go func() {
+		defer func() { recover() }()
+
+		g.do("k", func() (string, error) {
+			close(started)
+			<-release
+
+			panic("boom")
+		})
+	}()
+
+	<-started
+
+	done := make(chan error, 1)
+
+	go func() {
+		_, err := g.do("k", func() (string, error) { return "", nil })
+		done <- err
+	}()
+
+	// 等待第二个请求加入合并
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, errFlightPanic) {
+			t.Fatalf("err = %v, want errFlightPanic", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waiter blocked after panic")
+	}
+
+	data, err := g.do("k", func() (string, error) { return "ok", nil })
+
+	if err != nil || data != "ok" {
+		t.Fatalf("data = %q, err = %v", data, err)
+	}
+}
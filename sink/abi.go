@@ -0,0 +1,92 @@
+package sink
+
+import (
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/shenghui0779/antchain"
+)
+
+// NewABIEventDecoder 返回根据合约ABI解码事件的 EventDecoder：按首个 topic 匹配事件签名，填充 Name 及 Decoded；
+// 未匹配到事件定义的日志原样发布；不同合约的同名事件参数定义不同(如：indexed 不同)时使用 NewContractEventDecoder
+func NewABIEventDecoder(abis ...*antchain.ABI) EventDecoder {
+	events := make(map[string]*antchain.ABIEntry)
+
+	for _, abi := range abis {
+		for _, e := range abi.Events() {
+			if !e.Anonymous {
+				events[EventTopic(e.Signature())] = e
+			}
+		}
+	}
+
+	return func(e *Event) error {
+		if len(e.Topics) == 0 {
+			return nil
+		}
+
+		if entry, ok := events[normalizeTopic(e.Topics[0])]; ok {
+			decodeEvent(entry, e)
+		}
+
+		return nil
+	}
+}
+
+// NewContractEventDecoder 返回按(合约, 事件签名)匹配事件定义的 EventDecoder，abis 的 key 为合约名称或 Identity(hex、base64)；
+// 未匹配到事件定义的日志原样发布
+func NewContractEventDecoder(abis map[string]*antchain.ABI) EventDecoder {
+	events := make(map[[2]string]*antchain.ABIEntry)
+
+	for contract, abi := range abis {
+		id := hex.EncodeToString(identityBytes(contract))
+
+		for _, e := range abi.Events() {
+			if !e.Anonymous {
+				events[[2]string{id, EventTopic(e.Signature())}] = e
+			}
+		}
+	}
+
+	return func(e *Event) error {
+		if len(e.Topics) == 0 {
+			return nil
+		}
+
+		key := [2]string{hex.EncodeToString(identityBytes(e.Contract)), normalizeTopic(e.Topics[0])}
+
+		if entry, ok := events[key]; ok {
+			decodeEvent(entry, e)
+		}
+
+		return nil
+	}
+}
+
+// decodeEvent 解码事件参数；解码失败(如：ABI 与合约不一致)时记录 DecodeError 并发布原始日志，不中断发布
+func decodeEvent(entry *antchain.ABIEntry, e *Event) {
+	topics := make([]string, 0, len(e.Topics)-1)
+
+	for _, v := range e.Topics[1:] {
+		topics = append(topics, normalizeTopic(v))
+	}
+
+	args, err := entry.DecodeLog(topics, e.Data)
+
+	if err != nil {
+		e.DecodeError = err.Error()
+
+		return
+	}
+
+	b, err := json.Marshal(args)
+
+	if err != nil {
+		e.DecodeError = err.Error()
+
+		return
+	}
+
+	e.Name = entry.Name
+	e.Decoded = b
+}
@@ -0,0 +1,80 @@
+package sink
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shenghui0779/antchain"
+)
+
+func mustABI(t *testing.T, s string) *antchain.ABI {
+	t.Helper()
+
+	abi, err := antchain.ParseABI(s)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return abi
+}
+
+const (
+	transferIndexed = `[{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]}]`
+	transferPlain   = `[{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":false},{"name":"to","type":"address","indexed":false},{"name":"value","type":"uint256","indexed":false}]}]`
+)
+
+func word(v string) string {
+	return strings.Repeat("0", 64-len(v)) + v
+}
+
+func TestABIEventDecoderFallsBackOnDecodeError(t *testing.T) {
+	decode := NewABIEventDecoder(mustABI(t, transferIndexed))
+
+	topic := EventTopic("Transfer(address,address,uint256)")
+
+	e := &Event{
+		Topics: []string{topic, word("1"), word("2")},
+		Data:   word("64"),
+	}
+
+	if err := decode(e); err != nil || e.Name != "Transfer" || e.DecodeError != "" {
+		t.Fatalf("err = %v, event = %+v", err, e)
+	}
+
+	// 缺少 indexed 参数的 topic：记录错误，原样发布
+	bad := &Event{Topics: []string{topic}, Data: word("64")}
+
+	if err := decode(bad); err != nil {
+		t.Fatalf("decode error halted the relay: %v", err)
+	}
+
+	if bad.DecodeError == "" || bad.Name != "" || bad.Decoded != nil {
+		t.Fatalf("event = %+v", bad)
+	}
+}
+
+func TestContractEventDecoder(t *testing.T) {
+	decode := NewContractEventDecoder(map[string]*antchain.ABI{
+		"token-a": mustABI(t, transferIndexed),
+		"token-b": mustABI(t, transferPlain),
+	})
+
+	topic := EventTopic("Transfer(address,address,uint256)")
+
+	b := &Event{
+		Contract: "token-b",
+		Topics:   []string{topic},
+		Data:     word("1") + word("2") + word("64"),
+	}
+
+	if err := decode(b); err != nil || b.DecodeError != "" || b.Name != "Transfer" {
+		t.Fatalf("err = %v, event = %+v", err, b)
+	}
+
+	unknown := &Event{Contract: "token-c", Topics: []string{topic}, Data: word("64")}
+
+	if err := decode(unknown); err != nil || unknown.Name != "" || unknown.DecodeError != "" {
+		t.Fatalf("unknown contract decoded: %+v", unknown)
+	}
+}
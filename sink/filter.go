@@ -0,0 +1,205 @@
+package sink
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/shenghui0779/antchain"
+	"github.com/shenghui0779/antchain/codec"
+	"github.com/shenghui0779/antchain/keccak"
+	"github.com/tidwall/gjson"
+)
+
+// bloomSize 日志布隆过滤器的字节长度(2048位)
+const bloomSize = 256
+
+// EventFilter 事件预过滤：只关注少数合约的事件时，跳过不可能匹配的区块、交易回执及日志，
+// 避免在繁忙的链上逐笔查询回执和解码事件；按交易的目标合约过滤回执查询，
+// 开启 UseBloom 且块头带有日志布隆过滤器(logsBloom)时先按布隆过滤器判断整个区块；
+// 合约间调用产生的事件，须将交易直接调用的入口合约一并加入 Contracts
+type EventFilter struct {
+	Contracts []string // 合约名称或 Identity(hex、base64)，为空则不限合约
+	Events    []string // 事件签名，如：Transfer(identity,identity,uint256)，topic 为其 Keccak-256
+	Topics    []string // 事件 topic(hex)，与 Events 合并，均为空则不限事件
+
+	// UseBloom 按块头的布隆过滤器跳过区块，假定其为以太坊格式(2048位，Keccak-256 的 3 组 11 位置位，
+	// 检查项为合约 Identity 及 topic)；须先以链上真实块头确认格式一致，格式不一致会漏掉事件
+	UseBloom bool
+}
+
+// EventTopic 返回事件签名对应的 topic(hex)
+func EventTopic(signature string) string {
+	sum := keccak.Sum256([]byte(signature))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// eventFilter 预处理后的 EventFilter
+type eventFilter struct {
+	bloom     bool
+	contracts *antchain.IdentitySet
+	topics    map[string]bool
+	items     [][]byte // 布隆过滤器的检查项：合约 Identity
+	topicKeys [][]byte // 布隆过滤器的检查项：topic
+}
+
+func newEventFilter(f *EventFilter) *eventFilter {
+	if f == nil {
+		return nil
+	}
+
+	ef := &eventFilter{bloom: f.UseBloom}
+
+	if len(f.Contracts) != 0 {
+		ef.contracts = antchain.NewIdentitySet(f.Contracts...)
+
+		for _, v := range f.Contracts {
+			ef.items = append(ef.items, identityBytes(v))
+		}
+	}
+
+	topics := make([]string, 0, len(f.Events)+len(f.Topics))
+
+	for _, v := range f.Events {
+		topics = append(topics, EventTopic(v))
+	}
+
+	topics = append(topics, f.Topics...)
+
+	if len(topics) != 0 {
+		ef.topics = make(map[string]bool, len(topics))
+
+		for _, v := range topics {
+			t := normalizeTopic(v)
+
+			ef.topics[t] = true
+
+			if b, err := hex.DecodeString(t); err == nil {
+				ef.topicKeys = append(ef.topicKeys, b)
+			}
+		}
+	}
+
+	return ef
+}
+
+// identityBytes 返回合约 Identity 的原始字节，兼容合约名称、Identity 的 hex 及 base64 形式
+func identityBytes(v string) []byte {
+	if identity, err := antchain.ParseIdentity(v); err == nil {
+		if b, err := identity.Bytes(); err == nil {
+			return b
+		}
+	}
+
+	sum := sha256.Sum256([]byte(v))
+
+	return sum[:]
+}
+
+// normalizeTopic 统一为小写 hex，兼容 0x 前缀及 base64 形式
+func normalizeTopic(v string) string {
+	if b, err := codec.HexToBytes(v); err == nil {
+		return codec.BytesToHex(b)
+	}
+
+	if b, err := codec.Base64ToBytes(v); err == nil {
+		return codec.BytesToHex(b)
+	}
+
+	return strings.ToLower(codec.Trim0x(v))
+}
+
+// mayContain 根据块头的日志布隆过滤器判断区块是否可能包含匹配的事件，未开启 UseBloom 或块头没有布隆过滤器时返回 true
+func (ef *eventFilter) mayContain(header string) bool {
+	if ef == nil || !ef.bloom {
+		return true
+	}
+
+	bloom := headerBloom(header)
+
+	if bloom == nil {
+		return true
+	}
+
+	return bloomAny(bloom, ef.items) && bloomAny(bloom, ef.topicKeys)
+}
+
+// matchTx 判断交易的目标合约是否在关注范围内
+func (ef *eventFilter) matchTx(tx string) bool {
+	if ef == nil || ef.contracts == nil {
+		return true
+	}
+
+	return ef.contracts.Contains(gjson.Get(tx, "to").String())
+}
+
+// matchLog 判断日志的合约及首个 topic 是否在关注范围内
+func (ef *eventFilter) matchLog(log gjson.Result) bool {
+	if ef == nil {
+		return true
+	}
+
+	if ef.contracts != nil && !ef.contracts.Contains(log.Get("to").String()) {
+		return false
+	}
+
+	if ef.topics != nil && !ef.topics[normalizeTopic(log.Get("topics.0").String())] {
+		return false
+	}
+
+	return true
+}
+
+// headerBloom 读取块头的日志布隆过滤器(hex 或 base64)
+func headerBloom(header string) []byte {
+	for _, path := range []string{"logsBloom", "logBloom", "blockHeader.logsBloom", "blockHeader.logBloom", "block.blockHeader.logsBloom", "block.blockHeader.logBloom"} {
+		v := gjson.Get(header, path)
+
+		if !v.Exists() {
+			continue
+		}
+
+		s := v.String()
+
+		if b, err := codec.HexToBytes(s); err == nil && len(b) == bloomSize {
+			return b
+		}
+
+		if b, err := codec.Base64ToBytes(s); err == nil && len(b) == bloomSize {
+			return b
+		}
+	}
+
+	return nil
+}
+
+// bloomAny 布隆过滤器是否可能包含 items 中的任意一项，items 为空返回 true
+func bloomAny(bloom []byte, items [][]byte) bool {
+	if len(items) == 0 {
+		return true
+	}
+
+	for _, v := range items {
+		if bloomContains(bloom, v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// bloomContains 以太坊格式的布隆过滤器：取 Keccak-256 摘要的前 3 组双字节各自低 11 位作为置位
+func bloomContains(bloom, item []byte) bool {
+	sum := keccak.Sum256(item)
+
+	for i := 0; i < 6; i += 2 {
+		bit := (uint(sum[i])<<8 | uint(sum[i+1])) & 2047
+
+		if bloom[bloomSize-1-bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,70 @@
+package sink
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/shenghui0779/antchain/keccak"
+)
+
+// bloomAdd 按以太坊格式将 item 置入布隆过滤器
+func bloomAdd(bloom, item []byte) {
+	sum := keccak.Sum256(item)
+
+	for i := 0; i < 6; i += 2 {
+		bit := (uint(sum[i])<<8 | uint(sum[i+1])) & 2047
+
+		bloom[bloomSize-1-bit/8] |= 1 << (bit % 8)
+	}
+}
+
+func TestEventFilterBloomOptIn(t *testing.T) {
+	transfer := EventTopic("Transfer(address,address,uint256)")
+	approval := EventTopic("Approval(address,address,uint256)")
+
+	bloom := make([]byte, bloomSize)
+
+	bloomAdd(bloom, identityBytes("token"))
+
+	b, _ := hex.DecodeString(transfer)
+	bloomAdd(bloom, b)
+
+	header := fmt.Sprintf(`{"logsBloom":"0x%s"}`, hex.EncodeToString(bloom))
+
+	f := &EventFilter{Contracts: []string{"token"}, Events: []string{"Approval(address,address,uint256)"}}
+
+	// 默认不使用布隆过滤器，块头格式未确认时不会漏掉事件
+	if !newEventFilter(f).mayContain(header) {
+		t.Fatal("bloom used without opt-in")
+	}
+
+	f.UseBloom = true
+
+	if newEventFilter(f).mayContain(header) {
+		t.Fatalf("bloom should exclude %s", approval)
+	}
+
+	f.Events = []string{"Transfer(address,address,uint256)"}
+
+	if !newEventFilter(f).mayContain(header) {
+		t.Fatal("bloom should include transfer")
+	}
+
+	if !newEventFilter(f).mayContain(`{"number":1}`) {
+		t.Fatal("header without bloom must not be skipped")
+	}
+}
+
+func TestNormalizeTopic(t *testing.T) {
+	topic := EventTopic("Transfer(address,address,uint256)")
+
+	b, _ := hex.DecodeString(topic)
+
+	for _, v := range []string{topic, "0x" + topic, "0X" + topic, base64.StdEncoding.EncodeToString(b)} {
+		if got := normalizeTopic(v); got != topic {
+			t.Errorf("normalizeTopic(%q) = %s", v, got)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package sink
+
+import "context"
+
+// KafkaMessage 发送到 Kafka 的消息
+type KafkaMessage struct {
+	Topic string
+	Key   []byte
+	Value []byte
+}
+
+// KafkaWriter 同步写入 Kafka，须在消息被 broker 确认(建议 acks=all)后返回；
+// 可基于 kafka-go、sarama 等客户端实现，如 kafka-go：
+//
+//	sink.KafkaWriterFunc(func(ctx context.Context, msgs []*sink.KafkaMessage) error {
+//		km := make([]kafka.Message, 0, len(msgs))
+//		for _, m := range msgs {
+//			km = append(km, kafka.Message{Topic: m.Topic, Key: m.Key, Value: m.Value})
+//		}
+//		return w.WriteMessages(ctx, km...)
+//	})
+type KafkaWriter interface {
+	WriteMessages(ctx context.Context, msgs []*KafkaMessage) error
+}
+
+// KafkaWriterFunc 函数形式的 KafkaWriter
+type KafkaWriterFunc func(ctx context.Context, msgs []*KafkaMessage) error
+
+// WriteMessages 写入消息
+func (f KafkaWriterFunc) WriteMessages(ctx context.Context, msgs []*KafkaMessage) error {
+	return f(ctx, msgs)
+}
+
+// NewKafkaPublisher 返回发布到 Kafka 的 Publisher，消息键用于分区
+func NewKafkaPublisher(w KafkaWriter) Publisher {
+	return PublisherFunc(func(ctx context.Context, msgs []*Message) error {
+		km := make([]*KafkaMessage, 0, len(msgs))
+
+		for _, m := range msgs {
+			km = append(km, &KafkaMessage{
+				Topic: m.Topic,
+				Key:   []byte(m.Key),
+				Value: m.Value,
+			})
+		}
+
+		return w.WriteMessages(ctx, km)
+	})
+}
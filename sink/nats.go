@@ -0,0 +1,23 @@
+package sink
+
+import "context"
+
+// NATSConn NATS 连接，*nats.Conn 满足该接口
+type NATSConn interface {
+	Publish(subj string, data []byte) error
+	FlushWithContext(ctx context.Context) error
+}
+
+// NewNATSPublisher 返回发布到 NATS 的 Publisher，Topic 作为 subject；
+// 发布后 Flush 等待服务端确认收到，如：sink.NewNATSPublisher(nc)
+func NewNATSPublisher(conn NATSConn) Publisher {
+	return PublisherFunc(func(ctx context.Context, msgs []*Message) error {
+		for _, m := range msgs {
+			if err := conn.Publish(m.Topic, m.Value); err != nil {
+				return err
+			}
+		}
+
+		return conn.FlushWithContext(ctx)
+	})
+}
@@ -0,0 +1,435 @@
+// Package sink 持续扫描新区块，将区块、匹配的交易及合约事件发布到 Kafka、NATS 等消息系统；
+// 每个区块的消息发布成功后才记录检查点，保证至少一次(at-least-once)投递
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shenghui0779/antchain"
+	"github.com/shenghui0779/antchain/codec"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	defaultPollInterval = 3 * time.Second
+	// defaultLockKey 默认的锁键
+	defaultLockKey = "antchain:sink:relay"
+	// defaultLockTTL 默认的锁有效期
+	defaultLockTTL = 30 * time.Second
+	// maxBatchBlocks 单次获取的最大区块数，避免追赶历史区块时长时间不记录检查点
+	maxBatchBlocks = 100
+)
+
+// Message 待发布的消息
+type Message struct {
+	Topic string `json:"topic"`
+	Key   string `json:"key"` // 消息键(区块为块高，交易为交易hash，事件为 交易hash:日志序号)，可用于分区及去重
+	Value []byte `json:"value"`
+}
+
+// Publisher 消息发布者，Publish 须在消息被消息系统确认后才返回
+type Publisher interface {
+	Publish(ctx context.Context, msgs []*Message) error
+}
+
+// PublisherFunc 函数形式的 Publisher
+type PublisherFunc func(ctx context.Context, msgs []*Message) error
+
+// Publish 发布消息
+func (f PublisherFunc) Publish(ctx context.Context, msgs []*Message) error {
+	return f(ctx, msgs)
+}
+
+// Checkpoint 检查点，记录最后一个已发布的块高
+type Checkpoint interface {
+	// Load 返回最后一个已发布的块高，没有检查点时 ok 为 false
+	Load(ctx context.Context) (block int64, ok bool, err error)
+
+	// Save 记录最后一个已发布的块高
+	Save(ctx context.Context, block int64) error
+}
+
+// FileCheckpoint 基于本地文件的检查点
+type FileCheckpoint struct {
+	path string
+}
+
+// NewFileCheckpoint 返回基于本地文件的检查点
+func NewFileCheckpoint(path string) *FileCheckpoint {
+	return &FileCheckpoint{path: path}
+}
+
+// Load 读取检查点
+func (fc *FileCheckpoint) Load(ctx context.Context) (int64, bool, error) {
+	b, err := ioutil.ReadFile(fc.path)
+
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, false, nil
+		}
+
+		return 0, false, err
+	}
+
+	block, err := strconv.ParseInt(strings.TrimSpace(string(b)), 10, 64)
+
+	if err != nil {
+		return 0, false, fmt.Errorf("sink: invalid checkpoint file %s: %w", fc.path, err)
+	}
+
+	return block, true, nil
+}
+
+// Save 写入检查点(先写临时文件再重命名，避免写入中断导致文件损坏)
+func (fc *FileCheckpoint) Save(ctx context.Context, block int64) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(fc.path), filepath.Base(fc.path)+".tmp")
+
+	if err != nil {
+		return err
+	}
+
+	if _, err = tmp.WriteString(strconv.FormatInt(block, 10)); err == nil {
+		err = tmp.Sync()
+	}
+
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+
+	if err != nil {
+		os.Remove(tmp.Name())
+
+		return err
+	}
+
+	return os.Rename(tmp.Name(), fc.path)
+}
+
+// Event 合约事件(交易回执中的日志)
+type Event struct {
+	BlockNumber int64           `json:"blockNumber"`
+	TxHash      string          `json:"txHash"`
+	LogIndex    int             `json:"logIndex"`
+	Contract    string          `json:"contract"`
+	Topics      []string        `json:"topics"`
+	Data        string          `json:"data"`
+	Name        string          `json:"name,omitempty"`        // 事件名称，由 EventDecoder 填充
+	Decoded     json.RawMessage `json:"decoded,omitempty"`     // 解码后的事件参数，由 EventDecoder 填充
+	DecodeError string          `json:"decodeError,omitempty"` // 解码失败的原因，此时 Name、Decoded 为空
+}
+
+// EventDecoder 根据合约ABI解码事件，填充 Name 及 Decoded；返回 ErrSkipEvent 则不发布该事件
+type EventDecoder func(e *Event) error
+
+// ErrSkipEvent EventDecoder 返回该错误时跳过事件
+var ErrSkipEvent = errors.New("sink: skip event")
+
+// Config 发布配置，Topic 为空则不发布对应类型的消息
+type Config struct {
+	BlockTopic string // 区块消息的 Topic
+	TxTopic    string // 交易消息的 Topic
+	EventTopic string // 合约事件消息的 Topic
+
+	TxFilter    func(tx string) bool // 交易过滤(交易及其事件均受影响)，为空则不过滤
+	DecodeEvent EventDecoder         // 事件解码，为空则发布原始日志
+	EventFilter *EventFilter         // 事件预过滤，为空则查询全部交易回执
+
+	StartBlock    int64         // 没有检查点时的起始块高
+	Confirmations int64         // 只发布落后最新块高 Confirmations 个块的区块，降低分叉回滚的影响
+	Concurrency   int           // 并发获取区块数，默认 1
+	PollInterval  time.Duration // 轮询新区块的间隔，默认 3 秒
+
+	// Locker 不为空时，多个副本中只有持有锁的副本运行，避免重复发布
+	Locker  antchain.Locker
+	LockKey string        // 锁键，默认 antchain:sink:relay
+	LockTTL time.Duration // 锁有效期，默认 30 秒
+
+	// Failover 为 true 时未持有锁的副本作为备用节点持续竞选，主节点失效后自动接管，Run 直到 ctx 结束才返回
+	Failover bool
+	OnError  func(err error) // Failover 时发布出错的回调，出错后释放锁并重新竞选
+}
+
+// Relay 将链上数据发布到消息系统
+type Relay struct {
+	cli antchain.QueryService
+	pub Publisher
+	cp  Checkpoint
+	cfg *Config
+
+	filter *eventFilter
+}
+
+// NewRelay 返回 Relay
+func NewRelay(cli antchain.QueryService, pub Publisher, cp Checkpoint, cfg *Config) *Relay {
+	return &Relay{
+		cli: cli,
+		pub: pub,
+		cp:  cp,
+		cfg: cfg,
+
+		filter: newEventFilter(cfg.EventFilter),
+	}
+}
+
+// Run 从检查点之后的区块开始持续发布，直到 ctx 取消或出现错误；出现错误后可再次调用 Run 从检查点继续。
+// 配置了 Locker 时，锁已被其它副本持有返回 antchain.ErrLockHeld，运行期间失去锁返回 antchain.ErrLockNotHeld；
+// 开启 Failover 时则作为主节点选举的参与者持续运行
+func (r *Relay) Run(ctx context.Context) error {
+	if r.cfg.Locker == nil {
+		return r.run(ctx)
+	}
+
+	key := r.cfg.LockKey
+
+	if len(key) == 0 {
+		key = defaultLockKey
+	}
+
+	ttl := r.cfg.LockTTL
+
+	if ttl <= 0 {
+		ttl = defaultLockTTL
+	}
+
+	if r.cfg.Failover {
+		e := antchain.NewElector(r.cfg.Locker, key,
+			antchain.WithLeaseTTL(ttl),
+			antchain.WithCampaignInterval(r.interval()),
+			antchain.WithElectionErrorHandler(r.cfg.OnError),
+		)
+
+		return e.Run(ctx, r.run)
+	}
+
+	return antchain.RunExclusive(ctx, r.cfg.Locker, key, ttl, r.run)
+}
+
+func (r *Relay) interval() time.Duration {
+	if r.cfg.PollInterval <= 0 {
+		return defaultPollInterval
+	}
+
+	return r.cfg.PollInterval
+}
+
+func (r *Relay) run(ctx context.Context) error {
+	next := r.cfg.StartBlock
+
+	last, ok, err := r.cp.Load(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		next = last + 1
+	}
+
+	interval := r.interval()
+
+	for {
+		head, err := r.head(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		if next <= head {
+			to := head
+
+			if to-next >= maxBatchBlocks {
+				to = next + maxBatchBlocks - 1
+			}
+
+			next, err = r.relay(ctx, next, to)
+
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		timer := time.NewTimer(interval)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// head 返回可以发布的最大块高
+func (r *Relay) head(ctx context.Context) (int64, error) {
+	data, err := r.cli.QueryLastBlock(ctx)
+
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := antchain.ParseBlockNumber(data)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return n - r.cfg.Confirmations, nil
+}
+
+// relay 发布 [from, to] 区间的区块，返回下一个待发布的块高
+func (r *Relay) relay(ctx context.Context, from, to int64) (int64, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	next := from
+
+	for ret := range r.cli.FetchBlocks(ctx, from, to, r.cfg.Concurrency) {
+		if ret.Err != nil {
+			return next, fmt.Errorf("sink: block %d: %w", ret.Number, ret.Err)
+		}
+
+		msgs, err := r.messages(ctx, ret)
+
+		if err != nil {
+			return next, err
+		}
+
+		if len(msgs) != 0 {
+			if err = r.pub.Publish(ctx, msgs); err != nil {
+				return next, fmt.Errorf("sink: publish block %d: %w", ret.Number, err)
+			}
+		}
+
+		if err = r.cp.Save(ctx, ret.Number); err != nil {
+			return next, fmt.Errorf("sink: save checkpoint %d: %w", ret.Number, err)
+		}
+
+		next = ret.Number + 1
+	}
+
+	return next, ctx.Err()
+}
+
+// messages 构造区块的全部消息
+func (r *Relay) messages(ctx context.Context, ret *antchain.BlockResult) ([]*Message, error) {
+	msgs := make([]*Message, 0)
+
+	number := strconv.FormatInt(ret.Number, 10)
+
+	if len(r.cfg.BlockTopic) != 0 {
+		msgs = append(msgs, &Message{
+			Topic: r.cfg.BlockTopic,
+			Key:   number,
+			Value: []byte(`{"number":` + number + `,"header":` + codec.RawJSON(ret.Header) + `,"body":` + codec.RawJSON(ret.Body) + `}`),
+		})
+	}
+
+	// 区块不可能包含关注的事件时不再查询回执
+	events := len(r.cfg.EventTopic) != 0 && r.filter.mayContain(ret.Header)
+
+	if len(r.cfg.TxTopic) == 0 && !events {
+		return msgs, nil
+	}
+
+	for i, v := range gjson.Get(ret.Body, "transactionList").Array() {
+		tx := v.Raw
+
+		if do := v.Get("transactionDO"); do.Exists() {
+			tx = do.Raw
+		}
+
+		if r.cfg.TxFilter != nil && !r.cfg.TxFilter(tx) {
+			continue
+		}
+
+		hash := gjson.Get(tx, "hash").String()
+
+		if len(r.cfg.TxTopic) != 0 {
+			msgs = append(msgs, &Message{
+				Topic: r.cfg.TxTopic,
+				Key:   hash,
+				Value: []byte(`{"blockNumber":` + number + `,"index":` + strconv.Itoa(i) + `,"tx":` + tx + `}`),
+			})
+		}
+
+		if !events || !r.filter.matchTx(tx) {
+			continue
+		}
+
+		evs, err := r.events(ctx, ret.Number, hash)
+
+		if err != nil {
+			return nil, err
+		}
+
+		msgs = append(msgs, evs...)
+	}
+
+	return msgs, nil
+}
+
+// events 查询交易回执并构造合约事件消息
+func (r *Relay) events(ctx context.Context, blockNumber int64, hash string) ([]*Message, error) {
+	receipt, err := r.cli.QueryReceipt(ctx, hash)
+
+	if err != nil {
+		return nil, fmt.Errorf("sink: receipt %s: %w", hash, err)
+	}
+
+	msgs := make([]*Message, 0)
+
+	for i, l := range gjson.Get(receipt, "logs").Array() {
+		if !r.filter.matchLog(l) {
+			continue
+		}
+
+		e := &Event{
+			BlockNumber: blockNumber,
+			TxHash:      hash,
+			LogIndex:    i,
+			Contract:    l.Get("to").String(),
+			Topics:      make([]string, 0),
+			Data:        l.Get("logData").String(),
+		}
+
+		for _, t := range l.Get("topics").Array() {
+			e.Topics = append(e.Topics, t.String())
+		}
+
+		if r.cfg.DecodeEvent != nil {
+			if err := r.cfg.DecodeEvent(e); err != nil {
+				if errors.Is(err, ErrSkipEvent) {
+					continue
+				}
+
+				return nil, fmt.Errorf("sink: decode event %s:%d: %w", hash, i, err)
+			}
+		}
+
+		b, err := json.Marshal(e)
+
+		if err != nil {
+			return nil, err
+		}
+
+		msgs = append(msgs, &Message{
+			Topic: r.cfg.EventTopic,
+			Key:   hash + ":" + strconv.Itoa(i),
+			Value: b,
+		})
+	}
+
+	return msgs, nil
+}
@@ -0,0 +1,92 @@
+package antchain
+
+import (
+	"context"
+	"log/slog"
+)
+
+// defaultLogLevel SDK 内部诊断日志的默认级别
+const defaultLogLevel = slog.LevelWarn
+
+// levelHandler 过滤低于 level 的日志
+type levelHandler struct {
+	level   slog.Leveler
+	handler slog.Handler
+}
+
+func (h *levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level() && h.handler.Enabled(ctx, level)
+}
+
+func (h *levelHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.handler.Handle(ctx, r)
+}
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelHandler{
+		level:   h.level,
+		handler: h.handler.WithAttrs(attrs),
+	}
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{
+		level:   h.level,
+		handler: h.handler.WithGroup(name),
+	}
+}
+
+// logSetting 内部诊断日志配置
+type logSetting struct {
+	handler slog.Handler
+	level   slog.Leveler
+}
+
+// logger 返回内部诊断日志使用的 slog.Logger，默认输出到 slog.Default()，级别为 Warn
+func (ls logSetting) logger() *slog.Logger {
+	handler := ls.handler
+
+	if handler == nil {
+		handler = slog.Default().Handler()
+	}
+
+	level := ls.level
+
+	if level == nil {
+		level = defaultLogLevel
+	}
+
+	return slog.New(&levelHandler{
+		level:   level,
+		handler: handler,
+	}).With("sdk", "antchain")
+}
+
+// WithSlogHandler 设置 SDK 内部诊断日志(token刷新、重试、配置热加载等)的 slog.Handler，默认使用 slog.Default()
+func WithSlogHandler(h slog.Handler) ClientOption {
+	return func(c *client) {
+		c.logSetting.handler = h
+	}
+}
+
+// WithLogLevel 设置 SDK 内部诊断日志的级别(默认 Warn)，可传入 *slog.LevelVar 以便运行时调整
+func WithLogLevel(level slog.Leveler) ClientOption {
+	return func(c *client) {
+		c.logSetting.level = level
+	}
+}
+
+// slogLogger 基于 slog 的 Logger
+type slogLogger struct {
+	l *slog.Logger
+}
+
+// NewSlogLogger 返回基于 slog 的 Logger，可用于 NewLogHook
+func NewSlogLogger(l *slog.Logger) Logger {
+	return &slogLogger{l: l}
+}
+
+// Log 按级别输出日志
+func (s *slogLogger) Log(ctx context.Context, level LogLevel, msg string, keyvals ...interface{}) {
+	s.l.Log(ctx, slog.Level(level*4), msg, keyvals...)
+}
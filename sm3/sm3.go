@@ -0,0 +1,37 @@
+// Package sm3 提供国密 SM3 杂凑算法(GB/T 32905-2016)，实现使用 github.com/tjfoc/gmsm/sm3
+package sm3
+
+import (
+	"hash"
+
+	"github.com/tjfoc/gmsm/sm3"
+)
+
+// Size SM3 摘要的字节长度
+const Size = 32
+
+// BlockSize SM3 的分组字节长度
+const BlockSize = 64
+
+// digest gmsm 的 Sum(b) 会将 b 写入摘要而非把结果追加到 b 之后，此处按 hash.Hash 的约定追加
+type digest struct {
+	hash.Hash
+}
+
+func (d digest) Sum(b []byte) []byte {
+	return append(b, d.Hash.Sum(nil)...)
+}
+
+// New 返回 SM3 的 hash.Hash
+func New() hash.Hash {
+	return digest{Hash: sm3.New()}
+}
+
+// Sum 返回 data 的 SM3 摘要
+func Sum(data []byte) [Size]byte {
+	var out [Size]byte
+
+	copy(out[:], sm3.Sm3Sum(data))
+
+	return out
+}
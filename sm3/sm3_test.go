@@ -0,0 +1,84 @@
+package sm3
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// GB/T 32905-2016 附录 A 的示例
+var vectors = []struct {
+	in  string
+	out string
+}{
+	{"abc", "66c7f0f462eeedd9d1f2d46bdc10e4e24167c4875cf2f7a2297da02b8f4ba8e0"},
+	{strings.Repeat("abcd", 16), "debe9ff92275b8a138604889c18e5a4d6fdb70e5387e5765293dcba39c0c5732"},
+}
+
+func TestVectors(t *testing.T) {
+	for _, v := range vectors {
+		sum := Sum([]byte(v.in))
+
+		if got := hex.EncodeToString(sum[:]); got != v.out {
+			t.Errorf("Sum(%q) = %s, want %s", v.in, got, v.out)
+		}
+	}
+}
+
+func TestStreaming(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 100)
+	want := Sum(data)
+
+	// 跨分组边界的各种写入长度结果一致
+	for _, chunk := range []int{1, 7, 63, 64, 65, 200} {
+		h := New()
+
+		for i := 0; i < len(data); i += chunk {
+			end := i + chunk
+
+			if end > len(data) {
+				end = len(data)
+			}
+
+			h.Write(data[i:end])
+		}
+
+		if got := h.Sum(nil); !bytes.Equal(got, want[:]) {
+			t.Fatalf("chunk %d: %x, want %x", chunk, got, want)
+		}
+	}
+}
+
+func TestHashContract(t *testing.T) {
+	want := Sum([]byte("abcdef"))
+
+	cases := []struct {
+		name   string
+		prefix []byte
+	}{
+		{"nil", nil},
+		{"prefix", []byte("prefix")},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			h := New()
+			h.Write([]byte("abc"))
+
+			// Sum 不改变状态，之后可继续写入
+			h.Sum(c.prefix)
+			h.Write([]byte("def"))
+
+			got := h.Sum(c.prefix)
+
+			if !bytes.Equal(got[:len(c.prefix)], c.prefix) || !bytes.Equal(got[len(c.prefix):], want[:]) {
+				t.Fatalf("Sum = %x, want %x%x", got, c.prefix, want)
+			}
+		})
+	}
+
+	if h := New(); h.Size() != Size || h.BlockSize() != BlockSize {
+		t.Fatalf("size = %d, block size = %d", h.Size(), h.BlockSize())
+	}
+}
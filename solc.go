@@ -0,0 +1,157 @@
+package antchain
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/shenghui0779/antchain/codec"
+	"github.com/tidwall/gjson"
+)
+
+// DeployOption 从源码/编译产物部署合约的可选配置
+type DeployOption func(s *deploySetting)
+
+type deploySetting struct {
+	solc           string
+	optimizeRuns   int
+	sourceContract string
+	options        []ChainCallOption
+}
+
+// WithSolc 指定 solc 可执行文件路径，默认为 PATH 中的 solc
+func WithSolc(path string) DeployOption {
+	return func(s *deploySetting) {
+		s.solc = path
+	}
+}
+
+// WithSolcOptimize 开启 solc 优化
+func WithSolcOptimize(runs int) DeployOption {
+	return func(s *deploySetting) {
+		s.optimizeRuns = runs
+	}
+}
+
+// WithSourceContract 指定源码中要部署的合约名称，默认与部署名称相同
+func WithSourceContract(name string) DeployOption {
+	return func(s *deploySetting) {
+		s.sourceContract = name
+	}
+}
+
+// WithDeployParams 设置部署请求的其它参数(如：WithVMType)
+func WithDeployParams(options ...ChainCallOption) DeployOption {
+	return func(s *deploySetting) {
+		s.options = append(s.options, options...)
+	}
+}
+
+func newDeploySetting(name string, options ...DeployOption) *deploySetting {
+	s := &deploySetting{
+		solc:           "solc",
+		sourceContract: name,
+	}
+
+	for _, f := range options {
+		f(s)
+	}
+
+	return s
+}
+
+// compileSolidity 调用 solc 编译源码，返回合约的字节码(hex)及ABI
+func compileSolidity(ctx context.Context, s *deploySetting, sourcePath string) (string, string, error) {
+	args := []string{"--combined-json", "abi,bin"}
+
+	if s.optimizeRuns > 0 {
+		args = append(args, "--optimize", "--optimize-runs", strconv.Itoa(s.optimizeRuns))
+	}
+
+	args = append(args, sourcePath)
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, s.solc, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", "", fmt.Errorf("antchain: solc: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var bin, abi string
+
+	// key 格式为 "源文件路径:合约名称"
+	gjson.GetBytes(stdout.Bytes(), "contracts").ForEach(func(key, value gjson.Result) bool {
+		if key.String() != s.sourceContract && !strings.HasSuffix(key.String(), ":"+s.sourceContract) {
+			return true
+		}
+
+		bin = value.Get("bin").String()
+
+		// 旧版本 solc 的 abi 为 JSON 字符串
+		if v := value.Get("abi"); v.Type == gjson.String {
+			abi = v.String()
+		} else {
+			abi = v.Raw
+		}
+
+		return false
+	})
+
+	if len(bin) == 0 {
+		return "", "", fmt.Errorf("antchain: contract %s not found in %s", s.sourceContract, sourcePath)
+	}
+
+	return bin, abi, nil
+}
+
+// encodeBytecode 将 hex 字节码转为网关要求的 base64 格式
+func encodeBytecode(bin string) (string, error) {
+	b, err := hex.DecodeString(codec.Trim0x(strings.TrimSpace(bin)))
+
+	if err != nil {
+		return "", wrapErr(ErrDecodeFailed, err)
+	}
+
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+func (c *client) DeploySolidityFromSource(ctx context.Context, name, sourcePath string, gas int, options ...DeployOption) (string, error) {
+	s := newDeploySetting(name, options...)
+
+	bin, abi, err := compileSolidity(ctx, s, sourcePath)
+
+	if err != nil {
+		return "", err
+	}
+
+	return c.deployBytecode(ctx, name, bin, abi, gas, s)
+}
+
+// deployBytecode 部署字节码(hex)并注册ABI
+func (c *client) deployBytecode(ctx context.Context, name, bin, abi string, gas int, s *deploySetting) (string, error) {
+	code, err := encodeBytecode(bin)
+
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := c.DeploySolidity(ctx, name, code, gas, s.options...)
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(abi) != 0 {
+		c.abis.Register(name, abi)
+	}
+
+	return hash, nil
+}
@@ -0,0 +1,80 @@
+package antchain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/tidwall/gjson"
+)
+
+// WithStrictParsing 开启严格解析：解析网关响应为结构体(如：Account、SimulateResult)时，
+// 存在未知字段、缺少必需字段或数字格式错误均返回 ErrDecodeFailed，而非忽略或返回零值；
+// 用于在测试环境中尽早发现网关响应格式的变化
+func WithStrictParsing() ClientOption {
+	return func(c *client) {
+		c.strict = true
+	}
+}
+
+// strictUnmarshal 严格解析 JSON 对象：校验必需字段，并禁止未知字段
+func strictUnmarshal(data []byte, v interface{}, required ...string) error {
+	ret := gjson.ParseBytes(data)
+
+	if !ret.IsObject() {
+		return wrapErr(ErrDecodeFailed, fmt.Errorf("expected json object: %.256s", data))
+	}
+
+	for _, field := range required {
+		if !ret.Get(field).Exists() {
+			return wrapErr(ErrDecodeFailed, fmt.Errorf("missing field %q", field))
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(v); err != nil {
+		return wrapErr(ErrDecodeFailed, err)
+	}
+
+	return nil
+}
+
+// strictInt 严格读取整数字段，字段缺失或不是整数(兼容数字字符串)时返回错误
+func strictInt(ret gjson.Result, field string) (int64, error) {
+	v := ret.Get(field)
+
+	switch v.Type {
+	case gjson.Number, gjson.String:
+		n, err := strconv.ParseInt(v.String(), 10, 64)
+
+		if err != nil {
+			return 0, wrapErr(ErrDecodeFailed, fmt.Errorf("malformed number field %q: %s", field, v.Raw))
+		}
+
+		return n, nil
+	case gjson.Null:
+		if !v.Exists() {
+			return 0, wrapErr(ErrDecodeFailed, fmt.Errorf("missing field %q", field))
+		}
+	}
+
+	return 0, wrapErr(ErrDecodeFailed, fmt.Errorf("malformed number field %q: %s", field, v.Raw))
+}
+
+// strictString 严格读取字符串字段，字段缺失或不是字符串时返回错误
+func strictString(ret gjson.Result, field string) (string, error) {
+	v := ret.Get(field)
+
+	if !v.Exists() {
+		return "", wrapErr(ErrDecodeFailed, fmt.Errorf("missing field %q", field))
+	}
+
+	if v.Type != gjson.String {
+		return "", wrapErr(ErrDecodeFailed, fmt.Errorf("field %q is not a string: %s", field, v.Raw))
+	}
+
+	return v.String(), nil
+}
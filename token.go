@@ -0,0 +1,264 @@
+package antchain
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultTokenTTL shakehand token 的默认有效期(网关未返回有效期时使用)
+	defaultTokenTTL = 30 * time.Minute
+	// defaultTokenRefreshAhead 默认在 token 过期前多久进行刷新
+	defaultTokenRefreshAhead = 2 * time.Minute
+	// defaultShakehandBudget 请求设置了截止时间时，shakehand 默认可占用剩余时间的比例
+	defaultShakehandBudget = 0.3
+)
+
+// accessToken shakehand 获取的 token 及其对应的 AccessID
+type accessToken struct {
+	value    string
+	accessID string
+	expireAt time.Time // 网关返回的过期时间，未返回则为零值
+	issuedAt time.Time
+}
+
+// tokenSetting token 有效期配置
+type tokenSetting struct {
+	ttl          time.Duration
+	refreshAhead time.Duration
+}
+
+// refreshAt 计算 token 的刷新时间：过期时间提前 refreshAhead，且提前量不超过有效期的一半
+func (ts tokenSetting) refreshAt(now, expireAt time.Time) time.Time {
+	ahead := ts.refreshAhead
+
+	if ahead <= 0 {
+		ahead = defaultTokenRefreshAhead
+	}
+
+	if half := expireAt.Sub(now) / 2; ahead > half {
+		ahead = half
+	}
+
+	return expireAt.Add(-ahead)
+}
+
+// expireAt 返回 token 的过期时间，优先使用网关返回的过期时间
+func (ts tokenSetting) expireAt(now time.Time, token *accessToken) time.Time {
+	if !token.expireAt.IsZero() {
+		return token.expireAt
+	}
+
+	ttl := ts.ttl
+
+	if ttl <= 0 {
+		ttl = defaultTokenTTL
+	}
+
+	return now.Add(ttl)
+}
+
+// tokenCache 缓存 shakehand 获取的 token
+type tokenCache struct {
+	mutex     sync.Mutex
+	token     *accessToken
+	refreshAt time.Time
+	expireAt  time.Time
+	rejected  string // 最近一次被网关判定为过期的 token，不再从 TokenStore 加载
+}
+
+// get 返回未到刷新时间的 token
+func (tc *tokenCache) get(now time.Time) (*accessToken, bool) {
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+
+	if tc.token == nil || !now.Before(tc.refreshAt) {
+		return nil, false
+	}
+
+	return tc.token, true
+}
+
+// valid 返回已到刷新时间但尚未过期的 token
+func (tc *tokenCache) valid(now time.Time) (*accessToken, bool) {
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+
+	if tc.token == nil || !now.Before(tc.expireAt) {
+		return nil, false
+	}
+
+	return tc.token, true
+}
+
+func (tc *tokenCache) set(token *accessToken, refreshAt, expireAt time.Time) {
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+
+	tc.token = token
+	tc.refreshAt = refreshAt
+	tc.expireAt = expireAt
+}
+
+func (tc *tokenCache) reset() {
+	tc.set(nil, time.Time{}, time.Time{})
+}
+
+// invalidate 清除被网关判定为过期的 token
+func (tc *tokenCache) invalidate() {
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+
+	if tc.token != nil {
+		tc.rejected = tc.token.value
+	}
+
+	tc.token = nil
+	tc.refreshAt = time.Time{}
+	tc.expireAt = time.Time{}
+}
+
+// isRejected 是否为已被网关判定为过期的 token
+func (tc *tokenCache) isRejected(value string) bool {
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+
+	return len(tc.rejected) != 0 && tc.rejected == value
+}
+
+// token 优先返回缓存的 token，即将过期时重新 shakehand
+func (c *client) token(ctx context.Context) (*accessToken, error) {
+	if token, ok := c.tokens.get(c.now()); ok {
+		c.reportToken(token, true)
+
+		return token, nil
+	}
+
+	// 设置了截止时间的请求优先使用尚未过期的 token，不为提前刷新占用请求的时间
+	if _, ok := ctx.Deadline(); ok {
+		if token, ok := c.tokens.valid(c.now()); ok {
+			c.reportToken(token, true)
+
+			return token, nil
+		}
+	}
+
+	// 避免并发请求同时 shakehand
+	c.refreshMutex.Lock()
+	defer c.refreshMutex.Unlock()
+
+	if token, ok := c.tokens.get(c.now()); ok {
+		c.reportToken(token, true)
+
+		return token, nil
+	}
+
+	c.reportToken(nil, false)
+
+	shakeCtx, cancel := c.shakehandContext(ctx)
+	defer cancel()
+
+	return c.refreshToken(shakeCtx)
+}
+
+// shakehandContext 按比例为 shakehand 分配请求剩余的时间，避免 shakehand 过慢时主请求没有足够的时间
+func (c *client) shakehandContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+
+	if !ok {
+		return ctx, func() {}
+	}
+
+	ratio := c.shakehandBudget
+
+	if ratio <= 0 || ratio >= 1 {
+		ratio = defaultShakehandBudget
+	}
+
+	// ctx 的截止时间基于真实时钟，不能使用 c.now(可能为 WithClock 注入的时钟)
+	remain := time.Until(deadline)
+
+	return context.WithTimeout(ctx, time.Duration(float64(remain)*ratio))
+}
+
+func (c *client) refreshToken(ctx context.Context) (*accessToken, error) {
+	if token, ok := c.loadSharedToken(ctx); ok {
+		return token, nil
+	}
+
+	now := c.now()
+
+	token, err := c.shakehand(ctx)
+
+	c.recordShakehand(now, token, err)
+
+	if err != nil {
+		c.log.WarnContext(ctx, "token refresh failed", "error", err)
+
+		return nil, err
+	}
+
+	expireAt := c.tokenTTL.expireAt(now, token)
+
+	c.log.DebugContext(ctx, "token refreshed", "access_id", maskSecret(token.accessID), "expire_at", expireAt)
+
+	c.tokens.set(token, c.tokenTTL.refreshAt(now, expireAt), expireAt)
+
+	c.saveSharedToken(ctx, token, expireAt)
+
+	return token, nil
+}
+
+// keepAlive 后台定时在 token 过期前刷新，使业务请求无需等待 shakehand；
+// 配置了 Locker 时仅持有锁的副本刷新，其它副本按需从 TokenStore 加载
+func (c *client) keepAlive() {
+	ticker := time.NewTicker(c.keepAliveInterval)
+	defer ticker.Stop()
+
+	var lock *keepAliveLock
+
+	if c.locker != nil {
+		accessID, _, _, _ := c.accessKey(c.life.ctx)
+
+		lock = &keepAliveLock{
+			locker: c.locker,
+			key:    "antchain:keepalive:" + accessID,
+			ttl:    3 * c.keepAliveInterval,
+		}
+
+		defer lock.release()
+	}
+
+	for {
+		if lock != nil && !lock.hold(c.life.ctx) {
+			select {
+			case <-c.life.done:
+				return
+			case <-ticker.C:
+			}
+
+			continue
+		}
+
+		if _, ok := c.tokens.get(c.now()); !ok {
+			c.refreshMutex.Lock()
+
+			ctx, cancel := context.WithTimeout(c.life.ctx, c.keepAliveInterval)
+			c.refreshToken(ctx)
+			cancel()
+
+			c.refreshMutex.Unlock()
+		}
+
+		select {
+		case <-c.life.done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *client) Close(ctx context.Context) error {
+	return c.life.shutdown(ctx)
+}
@@ -0,0 +1,59 @@
+package antchain
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestShakehandContextIgnoresInjectedClock(t *testing.T) {
+	gw := newTestGateway(t, func(params X) (interface{}, bool) { return "", true })
+
+	// 注入的时钟比真实时间快一小时，不应影响按 ctx 截止时间分配的 shakehand 时长
+	cli := newTestClient(t, gw, WithClock(ClockFunc(func() time.Time { return time.Now().Add(time.Hour) })))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	shakeCtx, shakeCancel := cli.shakehandContext(ctx)
+	defer shakeCancel()
+
+	deadline, _ := shakeCtx.Deadline()
+
+	if remain := time.Until(deadline); remain > 10*time.Second || remain <= 0 {
+		t.Fatalf("shakehand budget = %s", remain)
+	}
+}
+
+func TestShakehandWrapsErrors(t *testing.T) {
+	gw := newTestGateway(t, func(params X) (interface{}, bool) { return "", true })
+
+	failingSigner := SignerFunc(func(hash crypto.Hash, data []byte) ([]byte, error) {
+		return nil, wrapErr(ErrSignFailed, errors.New("device unavailable"))
+	})
+
+	cases := []struct {
+		name     string
+		provider CredentialsProvider
+		cause    error
+	}{
+		{"sign failed", NewStaticProvider("access", failingSigner), ErrSignFailed},
+		{"no credentials", CredentialsProviderFunc(func(ctx context.Context) (*Credentials, error) {
+			return nil, ErrNoCredentials
+		}), ErrNoCredentials},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cli := newTestClient(t, gw, WithCredentialsProvider(c.provider))
+
+			_, err := cli.shakehand(context.Background())
+
+			if !errors.Is(err, ErrShakehandFailed) || !errors.Is(err, c.cause) {
+				t.Fatalf("err = %v, want %v wrapping %v", err, ErrShakehandFailed, c.cause)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package antchain
+
+import (
+	"context"
+	"time"
+)
+
+// SharedToken 多个副本共享的 shakehand token
+type SharedToken struct {
+	AccessID string    `json:"accessId"`
+	Value    string    `json:"value"`
+	ExpireAt time.Time `json:"expireAt"`
+	IssuedAt time.Time `json:"issuedAt"`
+}
+
+// TokenStore 分布式 token 缓存(如：Redis，参考 redisstore)，
+// 使用同一 AccessID 的多个副本共享 token，只在 token 缺失或即将过期时 shakehand
+type TokenStore interface {
+	// Load 返回 accessID 对应的 token，不存在返回 nil
+	Load(ctx context.Context, accessID string) (*SharedToken, error)
+
+	// Save 保存 token，应在 token.ExpireAt 之后过期
+	Save(ctx context.Context, token *SharedToken) error
+}
+
+// WithTokenStore 通过 TokenStore 在多个副本间共享 token；
+// TokenStore 不可用时退化为各自 shakehand，不影响业务请求
+func WithTokenStore(store TokenStore) ClientOption {
+	return func(c *client) {
+		c.tokenStore = store
+	}
+}
+
+// loadSharedToken 从 TokenStore 加载未到刷新时间、且未被网关判定为过期的 token
+func (c *client) loadSharedToken(ctx context.Context) (*accessToken, bool) {
+	if c.tokenStore == nil {
+		return nil, false
+	}
+
+	accessID, _, _, err := c.accessKey(ctx)
+
+	if err != nil {
+		return nil, false
+	}
+
+	shared, err := c.tokenStore.Load(ctx, accessID)
+
+	if err != nil {
+		c.log.WarnContext(ctx, "load shared token failed", "error", err)
+
+		return nil, false
+	}
+
+	if shared == nil || len(shared.Value) == 0 || shared.AccessID != accessID || c.tokens.isRejected(shared.Value) {
+		return nil, false
+	}
+
+	now := c.now()
+	refreshAt := c.tokenTTL.refreshAt(shared.IssuedAt, shared.ExpireAt)
+
+	if !now.Before(refreshAt) {
+		return nil, false
+	}
+
+	token := &accessToken{
+		value:    shared.Value,
+		accessID: shared.AccessID,
+		expireAt: shared.ExpireAt,
+		issuedAt: shared.IssuedAt,
+	}
+
+	c.tokens.set(token, refreshAt, shared.ExpireAt)
+
+	return token, true
+}
+
+// saveSharedToken 将 shakehand 获取的 token 保存到 TokenStore
+func (c *client) saveSharedToken(ctx context.Context, token *accessToken, expireAt time.Time) {
+	if c.tokenStore == nil {
+		return
+	}
+
+	err := c.tokenStore.Save(ctx, &SharedToken{
+		AccessID: token.accessID,
+		Value:    token.value,
+		ExpireAt: expireAt,
+		IssuedAt: token.issuedAt,
+	})
+
+	if err != nil {
+		c.log.WarnContext(ctx, "save shared token failed", "error", err)
+	}
+}
@@ -0,0 +1,330 @@
+package antchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// TopUpRecord 一次自动充值的记录，用于审计
+type TopUpRecord struct {
+	Account string    `json:"account"` // 被充值账户
+	Balance int64     `json:"balance"` // 充值前余额
+	Amount  int64     `json:"amount"`  // 充值金额
+	TxHash  string    `json:"tx_hash"` // 转账交易hash
+	Err     error     `json:"-"`       // 转账失败的错误
+	Time    time.Time `json:"time"`    // 充值时间
+}
+
+// TopUpRule 账户的自动充值规则
+type TopUpRule struct {
+	Account   string // 需要保持余额的账户
+	Threshold int64  // 余额低于该值时充值
+	Amount    int64  // 每次充值金额
+	Gas       int    // 转账交易的gas
+}
+
+// ErrTopUpLimit 时间窗口内的充值总额已达上限
+var ErrTopUpLimit = errors.New("antchain: top-up limit exceeded")
+
+// errReceiptPending 交易回执尚未产生
+var errReceiptPending = errors.New("receipt not available")
+
+// defaultTopUpPendingTTL 充值交易超过该时间仍无回执时不再等待，允许再次充值
+const defaultTopUpPendingTTL = 10 * time.Minute
+
+// TopUpQuery GasTopUp 查询账户余额及充值交易回执所需的接口，Client 已实现
+type TopUpQuery interface {
+	// QueryAccount 查询账户
+	QueryAccount(ctx context.Context, account string) (*Account, error)
+
+	// QueryReceipt 查询交易回执
+	QueryReceipt(ctx context.Context, hash string) (string, error)
+}
+
+// GasTopUpOption GasTopUp 的可选配置
+type GasTopUpOption func(t *GasTopUp)
+
+// WithTopUpLimit 限制任意 window 时间内所有账户的充值总额不超过 max，超出时本次不充值并返回 ErrTopUpLimit
+func WithTopUpLimit(window time.Duration, max int64) GasTopUpOption {
+	return func(t *GasTopUp) {
+		t.window = window
+		t.max = max
+	}
+}
+
+// WithTopUpClock 指定时间源，默认使用 query 客户端的时间源(见 WithClock)
+func WithTopUpClock(clock Clock) GasTopUpOption {
+	return func(t *GasTopUp) {
+		if clock != nil {
+			t.now = clock.Now
+		}
+	}
+}
+
+// topUpPending 尚未确认的充值交易，hash 为空表示转账请求进行中
+type topUpPending struct {
+	hash string
+	at   time.Time
+}
+
+// topUpSpend 一次已发起的充值
+type topUpSpend struct {
+	amount int64
+	at     time.Time
+}
+
+// GasTopUp 当账户余额低于阈值时，自动从资金账户转账，避免长时间运行的任务因余额不足中断；
+// 账户的充值交易回执返回前不再重复充值，可通过 WithTopUpLimit 限制时间窗口内的充值总额
+type GasTopUp struct {
+	query    TopUpQuery     // 用于查询账户余额及充值回执
+	treasury AccountService // 资金账户(以资金账户配置创建的客户端)
+	audit    func(record *TopUpRecord)
+	now      func() time.Time
+
+	window time.Duration
+	max    int64
+
+	mutex   sync.Mutex
+	rules   map[string]*TopUpRule
+	pending map[string]*topUpPending
+	spends  []topUpSpend
+}
+
+// NewGasTopUp 返回 GasTopUp，audit 用于记录每次充值(可为空)
+func NewGasTopUp(query TopUpQuery, treasury AccountService, audit func(record *TopUpRecord), options ...GasTopUpOption) *GasTopUp {
+	t := &GasTopUp{
+		query:    query,
+		treasury: treasury,
+		audit:    audit,
+		now:      time.Now,
+		rules:    make(map[string]*TopUpRule),
+		pending:  make(map[string]*topUpPending),
+	}
+
+	if c, ok := query.(*client); ok {
+		t.now = c.now
+	}
+
+	for _, f := range options {
+		f(t)
+	}
+
+	return t
+}
+
+// AddRule 添加账户的充值规则
+func (t *GasTopUp) AddRule(rule *TopUpRule) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.rules[rule.Account] = rule
+}
+
+// Check 检查所有账户的余额并按规则充值，返回本次的充值记录
+func (t *GasTopUp) Check(ctx context.Context) []*TopUpRecord {
+	t.mutex.Lock()
+
+	rules := make([]*TopUpRule, 0, len(t.rules))
+
+	for _, v := range t.rules {
+		rules = append(rules, v)
+	}
+
+	t.mutex.Unlock()
+
+	records := make([]*TopUpRecord, 0)
+
+	for _, rule := range rules {
+		if record := t.check(ctx, rule); record != nil {
+			records = append(records, record)
+		}
+	}
+
+	return records
+}
+
+func (t *GasTopUp) check(ctx context.Context, rule *TopUpRule) *TopUpRecord {
+	// 上一次充值尚未确认时跳过，避免重复转账
+	if wait, record := t.settle(ctx, rule.Account); wait || record != nil {
+		return record
+	}
+
+	account, err := t.query.QueryAccount(ctx, rule.Account)
+
+	if err != nil {
+		return t.record(&TopUpRecord{
+			Account: rule.Account,
+			Err:     err,
+			Time:    t.now(),
+		})
+	}
+
+	if account.Balance >= rule.Threshold {
+		return nil
+	}
+
+	record := &TopUpRecord{
+		Account: rule.Account,
+		Balance: account.Balance,
+		Amount:  rule.Amount,
+		Time:    t.now(),
+	}
+
+	if err := t.reserve(rule.Account, rule.Amount, record.Time); err != nil {
+		record.Err = err
+
+		return t.record(record)
+	}
+
+	record.TxHash, record.Err = t.treasury.Transfer(ctx, rule.Account, rule.Amount, rule.Gas)
+
+	t.mutex.Lock()
+
+	if record.Err != nil {
+		// 转账失败，退回占用的额度
+		delete(t.pending, rule.Account)
+
+		for i := len(t.spends) - 1; i >= 0; i-- {
+			if t.spends[i].at.Equal(record.Time) && t.spends[i].amount == rule.Amount {
+				t.spends = append(t.spends[:i], t.spends[i+1:]...)
+
+				break
+			}
+		}
+	} else {
+		t.pending[rule.Account] = &topUpPending{hash: record.TxHash, at: record.Time}
+	}
+
+	t.mutex.Unlock()
+
+	return t.record(record)
+}
+
+// settle 检查账户上一次充值交易的回执：尚未确认返回 wait=true；执行失败或超时未确认时返回对应的记录
+func (t *GasTopUp) settle(ctx context.Context, account string) (bool, *TopUpRecord) {
+	t.mutex.Lock()
+	p, ok := t.pending[account]
+	t.mutex.Unlock()
+
+	if !ok {
+		return false, nil
+	}
+
+	// 转账请求进行中
+	if len(p.hash) == 0 {
+		return true, nil
+	}
+
+	receipt, err := t.query.QueryReceipt(ctx, p.hash)
+
+	if err == nil && len(receipt) == 0 {
+		err = errReceiptPending
+	}
+
+	if err != nil {
+		if t.now().Sub(p.at) < defaultTopUpPendingTTL {
+			return true, nil
+		}
+
+		t.clearPending(account, p)
+
+		return false, t.record(&TopUpRecord{
+			Account: account,
+			TxHash:  p.hash,
+			Err:     fmt.Errorf("%w: top-up %s not confirmed: %v", ErrTxTimeout, p.hash, err),
+			Time:    t.now(),
+		})
+	}
+
+	t.clearPending(account, p)
+
+	if err := receiptError(p.hash, receipt); err != nil {
+		return false, t.record(&TopUpRecord{
+			Account: account,
+			TxHash:  p.hash,
+			Err:     err,
+			Time:    t.now(),
+		})
+	}
+
+	return false, nil
+}
+
+func (t *GasTopUp) clearPending(account string, p *topUpPending) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.pending[account] == p {
+		delete(t.pending, account)
+	}
+}
+
+// reserve 占用账户的充值(标记进行中)并计入时间窗口的充值总额，超出上限返回 ErrTopUpLimit
+func (t *GasTopUp) reserve(account string, amount int64, now time.Time) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if _, ok := t.pending[account]; ok {
+		return fmt.Errorf("antchain: top-up of %s already in progress", account)
+	}
+
+	if t.window > 0 {
+		var (
+			spent int64
+			kept  = t.spends[:0]
+		)
+
+		for _, v := range t.spends {
+			if now.Sub(v.at) < t.window {
+				kept = append(kept, v)
+				spent += v.amount
+			}
+		}
+
+		t.spends = kept
+
+		if spent+amount > t.max {
+			return fmt.Errorf("%w: %d spent in %s, max %d", ErrTopUpLimit, spent, t.window, t.max)
+		}
+
+		t.spends = append(t.spends, topUpSpend{amount: amount, at: now})
+	}
+
+	t.pending[account] = &topUpPending{at: now}
+
+	return nil
+}
+
+func (t *GasTopUp) record(record *TopUpRecord) *TopUpRecord {
+	if t.audit != nil {
+		t.audit(record)
+	}
+
+	return record
+}
+
+// defaultTopUpInterval GasTopUp 默认的检查间隔
+const defaultTopUpInterval = time.Minute
+
+// Run 按 interval 定期检查，直到 ctx 结束；interval<=0 时使用默认的 1 分钟
+func (t *GasTopUp) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultTopUpInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		t.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package antchain
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// topUpChain 模拟余额查询、回执查询及资金账户转账
+type topUpChain struct {
+	AccountService
+
+	mutex     sync.Mutex
+	balances  map[string]int64
+	receipts  map[string]string
+	transfers []string
+}
+
+func (c *topUpChain) QueryAccount(ctx context.Context, account string) (*Account, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return &Account{ID: account, Balance: c.balances[account]}, nil
+}
+
+func (c *topUpChain) QueryReceipt(ctx context.Context, hash string) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.receipts[hash], nil
+}
+
+func (c *topUpChain) Transfer(ctx context.Context, to string, amount int64, gas int) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.transfers = append(c.transfers, to)
+
+	return "0x" + to, nil
+}
+
+func (c *topUpChain) set(f func()) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	f()
+}
+
+func newTopUpChain() *topUpChain {
+	return &topUpChain{
+		balances: map[string]int64{"a": 10, "b": 10},
+		receipts: make(map[string]string),
+	}
+}
+
+func TestGasTopUpWaitsForPendingTransfer(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	chain := newTopUpChain()
+
+	topup := NewGasTopUp(chain, chain, nil, WithTopUpClock(ClockFunc(func() time.Time { return now })))
+	topup.AddRule(&TopUpRule{Account: "a", Threshold: 100, Amount: 500})
+
+	records := topup.Check(context.Background())
+
+	if len(records) != 1 || records[0].TxHash != "0xa" || !records[0].Time.Equal(now) {
+		t.Fatalf("records = %+v", records)
+	}
+
+	// 回执未返回，余额仍低于阈值，不应再次转账
+	if records := topup.Check(context.Background()); len(records) != 0 || len(chain.transfers) != 1 {
+		t.Fatalf("records = %+v, transfers = %v", records, chain.transfers)
+	}
+
+	chain.set(func() {
+		chain.receipts["0xa"] = `{"result":0}`
+		chain.balances["a"] = 510
+	})
+
+	if records := topup.Check(context.Background()); len(records) != 0 || len(chain.transfers) != 1 {
+		t.Fatalf("records = %+v, transfers = %v", records, chain.transfers)
+	}
+}
+
+func TestGasTopUpFailedReceipt(t *testing.T) {
+	chain := newTopUpChain()
+
+	topup := NewGasTopUp(chain, chain, nil)
+	topup.AddRule(&TopUpRule{Account: "a", Threshold: 100, Amount: 500})
+
+	topup.Check(context.Background())
+
+	chain.set(func() { chain.receipts["0xa"] = `{"result":10201}` })
+
+	records := topup.Check(context.Background())
+
+	if len(records) != 1 || !errors.Is(records[0].Err, ErrTxFailed) {
+		t.Fatalf("records = %+v", records)
+	}
+}
+
+func TestGasTopUpLimit(t *testing.T) {
+	chain := newTopUpChain()
+
+	topup := NewGasTopUp(chain, chain, nil, WithTopUpLimit(time.Hour, 150))
+	topup.AddRule(&TopUpRule{Account: "a", Threshold: 100, Amount: 100})
+	topup.AddRule(&TopUpRule{Account: "b", Threshold: 100, Amount: 100})
+
+	var limited int
+
+	for _, v := range topup.Check(context.Background()) {
+		if errors.Is(v.Err, ErrTopUpLimit) {
+			limited++
+		}
+	}
+
+	if limited != 1 || len(chain.transfers) != 1 {
+		t.Fatalf("limited = %d, transfers = %v", limited, chain.transfers)
+	}
+}
+
+func TestGasTopUpRunDefaultInterval(t *testing.T) {
+	topup := NewGasTopUp(nil, nil, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// interval 为 0 时不应 panic
+	topup.Run(ctx, 0)
+}
@@ -0,0 +1,288 @@
+// Package trace 供应链溯源场景的合约封装：登记商品、追加溯源事件、查询溯源记录，
+// 合约源码见 ContractSource，可通过 Deploy 编译部署
+package trace
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/shenghui0779/antchain"
+)
+
+// ContractSource 溯源合约(Solidity)，商品及事件以 JSON 字符串存储，写入时同时触发事件
+const ContractSource = `pragma solidity ^0.4.24;
+
+contract Traceability {
+    mapping(string => string) private products;
+    mapping(string => string[]) private events;
+
+    event ProductRegistered(string productId, string info);
+    event TraceAppended(string productId, uint256 index, string data);
+
+    function registerProduct(string productId, string info) public {
+        require(bytes(products[productId]).length == 0, "product exists");
+        products[productId] = info;
+        emit ProductRegistered(productId, info);
+    }
+
+    function appendEvent(string productId, string data) public {
+        require(bytes(products[productId]).length != 0, "product not found");
+        events[productId].push(data);
+        emit TraceAppended(productId, events[productId].length - 1, data);
+    }
+
+    function getProduct(string productId) public view returns (string) {
+        return products[productId];
+    }
+
+    function getEventCount(string productId) public view returns (uint256) {
+        return events[productId].length;
+    }
+
+    function getEvent(string productId, uint256 index) public view returns (string) {
+        return events[productId][index];
+    }
+}
+`
+
+const (
+	methodRegisterProduct = "registerProduct(string,string)"
+	methodAppendEvent     = "appendEvent(string,string)"
+	methodGetProduct      = "getProduct(string)"
+	methodGetEventCount   = "getEventCount(string)"
+	methodGetEvent        = "getEvent(string,uint256)"
+)
+
+// contractName ContractSource 中的合约名称
+const contractName = "Traceability"
+
+// MaxHistoryEvents History 一次返回的最大事件数，超出时通过 Events 分页查询
+const MaxHistoryEvents = 1000
+
+var (
+	// ErrProductNotFound 商品未登记
+	ErrProductNotFound = errors.New("trace: product not found")
+	// ErrHistoryTooLong 商品的溯源事件超过 MaxHistoryEvents
+	ErrHistoryTooLong = errors.New("trace: too many events, use Events to page")
+)
+
+// Deploy 将 ContractSource 写入临时文件，通过 DeploySolidityFromSource(需要 solc)编译部署溯源合约 name，返回交易hash
+func Deploy(ctx context.Context, cli antchain.ContractService, name string, gas int, options ...antchain.DeployOption) (string, error) {
+	dir, err := os.MkdirTemp("", "antchain-trace")
+
+	if err != nil {
+		return "", err
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, contractName+".sol")
+
+	if err = os.WriteFile(path, []byte(ContractSource), 0o600); err != nil {
+		return "", err
+	}
+
+	options = append([]antchain.DeployOption{antchain.WithSourceContract(contractName)}, options...)
+
+	return cli.DeploySolidityFromSource(ctx, name, path, gas, options...)
+}
+
+// Product 商品登记信息
+type Product struct {
+	ID       string            `json:"id"`
+	Name     string            `json:"name"`
+	Batch    string            `json:"batch,omitempty"`    // 批次
+	Producer string            `json:"producer,omitempty"` // 生产方
+	Extra    map[string]string `json:"extra,omitempty"`
+}
+
+// Event 溯源事件(如：生产、质检、入库、运输、签收)
+type Event struct {
+	Action    string            `json:"action"`
+	Operator  string            `json:"operator,omitempty"`
+	Location  string            `json:"location,omitempty"`
+	Timestamp int64             `json:"timestamp"` // 毫秒时间戳
+	Data      map[string]string `json:"data,omitempty"`
+}
+
+// Tracer 溯源合约的调用封装
+type Tracer struct {
+	cli      antchain.ContractService
+	contract string
+	gas      int
+}
+
+// New 返回调用已部署溯源合约 contract 的 Tracer，gas 为每笔交易的 gas
+func New(cli antchain.ContractService, contract string, gas int) *Tracer {
+	return &Tracer{
+		cli:      cli,
+		contract: contract,
+		gas:      gas,
+	}
+}
+
+// RegisterProduct 登记商品，返回交易hash
+func (t *Tracer) RegisterProduct(ctx context.Context, p *Product) (string, error) {
+	info, err := json.Marshal(p)
+
+	if err != nil {
+		return "", err
+	}
+
+	return t.send(ctx, methodRegisterProduct, p.ID, string(info))
+}
+
+// AppendEvent 为商品追加溯源事件，返回交易hash
+func (t *Tracer) AppendEvent(ctx context.Context, productID string, e *Event) (string, error) {
+	data, err := json.Marshal(e)
+
+	if err != nil {
+		return "", err
+	}
+
+	return t.send(ctx, methodAppendEvent, productID, string(data))
+}
+
+// Product 查询商品登记信息，未登记返回 ErrProductNotFound
+func (t *Tracer) Product(ctx context.Context, productID string) (*Product, error) {
+	info, err := t.callString(ctx, methodGetProduct, productID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(info) == 0 {
+		return nil, ErrProductNotFound
+	}
+
+	p := new(Product)
+
+	if err = json.Unmarshal([]byte(info), p); err != nil {
+		return nil, fmt.Errorf("trace: decode product: %w", err)
+	}
+
+	return p, nil
+}
+
+// EventCount 返回商品的溯源事件数
+func (t *Tracer) EventCount(ctx context.Context, productID string) (int64, error) {
+	ret, err := t.simulate(ctx, methodGetEventCount, []string{"uint256"}, productID)
+
+	if err != nil {
+		return 0, err
+	}
+
+	out, err := antchain.ParseOutput(ret.Output)
+
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := antchain.ParseUint256Hex(out)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if !count.BigInt().IsInt64() {
+		return 0, fmt.Errorf("trace: invalid event count %s", count)
+	}
+
+	return count.BigInt().Int64(), nil
+}
+
+// History 按追加顺序返回商品的全部溯源事件，超过 MaxHistoryEvents 时返回 ErrHistoryTooLong；
+// 合约只提供按下标查询，每个事件一次只读调用(查询通道，不上链、不消耗gas)
+func (t *Tracer) History(ctx context.Context, productID string) ([]*Event, error) {
+	n, err := t.EventCount(ctx, productID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if n > MaxHistoryEvents {
+		return nil, fmt.Errorf("%w: %d events", ErrHistoryTooLong, n)
+	}
+
+	return t.events(ctx, productID, 0, n)
+}
+
+// Events 按追加顺序返回商品下标 [offset, offset+limit) 的溯源事件，limit 不超过 MaxHistoryEvents
+func (t *Tracer) Events(ctx context.Context, productID string, offset, limit int64) ([]*Event, error) {
+	if offset < 0 || limit <= 0 || limit > MaxHistoryEvents {
+		return nil, fmt.Errorf("trace: invalid page offset=%d limit=%d", offset, limit)
+	}
+
+	n, err := t.EventCount(ctx, productID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if offset >= n {
+		return []*Event{}, nil
+	}
+
+	return t.events(ctx, productID, offset, min(n, offset+limit))
+}
+
+func (t *Tracer) events(ctx context.Context, productID string, from, to int64) ([]*Event, error) {
+	events := make([]*Event, 0, to-from)
+
+	for i := from; i < to; i++ {
+		data, err := t.callString(ctx, methodGetEvent, productID, i)
+
+		if err != nil {
+			return nil, err
+		}
+
+		e := new(Event)
+
+		if err = json.Unmarshal([]byte(data), e); err != nil {
+			return nil, fmt.Errorf("trace: decode event %d: %w", i, err)
+		}
+
+		events = append(events, e)
+	}
+
+	return events, nil
+}
+
+func (t *Tracer) send(ctx context.Context, method string, args ...interface{}) (string, error) {
+	h, err := t.cli.NewTx().Contract(t.contract).Method(method).Args(args...).Gas(t.gas).Send(ctx)
+
+	if err != nil {
+		return "", err
+	}
+
+	return h.Hash, nil
+}
+
+func (t *Tracer) simulate(ctx context.Context, method string, outTypes []string, args ...interface{}) (*antchain.SimulateResult, error) {
+	ret, err := t.cli.NewTx().Contract(t.contract).Method(method).Args(args...).OutTypes(outTypes...).Simulate(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if ret.Result != 0 {
+		return nil, fmt.Errorf("trace: %s failed with result %d", method, ret.Result)
+	}
+
+	return ret, nil
+}
+
+// callString 调用返回 string 的只读方法
+func (t *Tracer) callString(ctx context.Context, method string, args ...interface{}) (string, error) {
+	ret, err := t.simulate(ctx, method, []string{"string"}, args...)
+
+	if err != nil {
+		return "", err
+	}
+
+	return antchain.ParseOutputToString(ret.Output)
+}
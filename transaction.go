@@ -1,36 +1,136 @@
 package antchain
 
-import "context"
+import (
+	"context"
+
+	"github.com/tidwall/gjson"
+)
 
 func (c *client) CreateAccount(ctx context.Context, account, kmsID string, gas int) (string, error) {
-	return c.chainCallForBiz(ctx, "TENANTCREATEACCUNT",
+	return c.chainCallForBiz(ctx, MethodCreateAccount,
 		WithParam("newAccountId", account),
 		WithParam("newAccountKmsId", kmsID),
 		WithParam("gas", gas),
 	)
 }
 
-func (c *client) Deposit(ctx context.Context, content string, gas int) (string, error) {
-	return c.chainCallForBiz(ctx, "DEPOSIT",
+func (c *client) Transfer(ctx context.Context, to string, amount int64, gas int) (string, error) {
+	return c.chainCallForBiz(ctx, MethodTransfer,
+		WithParam("toAccount", to),
+		WithParam("amount", amount),
+		WithParam("gas", gas),
+	)
+}
+
+func (c *client) Deposit(ctx context.Context, content string, gas int, options ...ChainCallOption) (string, error) {
+	options = append(options,
 		WithParam("content", content),
 		WithParam("gas", gas),
 	)
+
+	return c.chainCallForBiz(ctx, MethodDeposit, options...)
+}
+
+// VMType 合约虚拟机类型
+type VMType string
+
+const (
+	// VMTypeEVM Solidity(EVM)合约
+	VMTypeEVM VMType = "EVM"
+	// VMTypeWASM WASM合约(如：C++合约)
+	VMTypeWASM VMType = "WASM"
+	// VMTypeNative 系统原生合约
+	VMTypeNative VMType = "NATIVE"
+)
+
+// WithVMType 指定合约的虚拟机类型，默认为EVM
+func WithVMType(vm VMType) ChainCallOption {
+	return WithParam("vmTypeEnum", string(vm))
 }
 
-func (c *client) DeploySolidity(ctx context.Context, name, code string, gas int) (string, error) {
-	return c.chainCallForBiz(ctx, "DEPLOYCONTRACTFORBIZ",
+func (c *client) DeploySolidity(ctx context.Context, name, code string, gas int, options ...ChainCallOption) (string, error) {
+	options = append(options,
 		WithParam("contractName", name),
 		WithParam("contractCode", code),
 		WithParam("gas", gas),
 	)
+
+	return c.chainCallForBiz(ctx, MethodDeployContract, options...)
 }
 
-func (c *client) AsyncCallSolidity(ctx context.Context, contractName, methodSign, inputParams, outTypes string, gas int) (string, error) {
-	return c.chainCallForBiz(ctx, "CALLCONTRACTBIZASYNC",
+func (c *client) AsyncCallSolidity(ctx context.Context, contractName, methodSign, inputParams, outTypes string, gas int, options ...ChainCallOption) (string, error) {
+	options = append(options,
 		WithParam("contractName", contractName),
 		WithParam("methodSignature", methodSign),
 		WithParam("inputParamListStr", inputParams),
 		WithParam("outTypes", outTypes),
 		WithParam("gas", gas),
 	)
+
+	return c.chainCallForBiz(ctx, MethodCallContract, options...)
+}
+
+// SimulateResult 合约调用的模拟执行结果
+type SimulateResult struct {
+	Output  string // 合约方法返回的output(base64)，可通过 ParseOutput 解析
+	GasUsed int64  // 预估消耗的gas
+	Result  int64  // 执行结果码，0表示成功
+}
+
+func (c *client) SimulateSolidity(ctx context.Context, contractName, methodSign, inputParams, outTypes string, options ...ChainCallOption) (*SimulateResult, error) {
+	cfg := c.credential().cfg
+
+	// 模拟执行走查询通道(chainCall)：不分配 nonce、不签名、不计入 gas 预算，未指定时使用 Config 中的账户
+	options = append([]ChainCallOption{WithAccount(cfg.Account, cfg.MyKmsKeyID), WithTenant(cfg.TenantID)}, options...)
+	options = append(options,
+		WithParam("contractName", contractName),
+		WithParam("methodSignature", methodSign),
+		WithParam("inputParamListStr", inputParams),
+		WithParam("outTypes", outTypes),
+	)
+
+	data, err := c.chainCall(ctx, MethodLocalCallContract, options...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ret := gjson.Parse(data)
+
+	if c.strict {
+		return parseSimulateResultStrict(ret)
+	}
+
+	return &SimulateResult{
+		Output:  ret.Get("output").String(),
+		GasUsed: ret.Get("gasUsed").Int(),
+		Result:  ret.Get("result").Int(),
+	}, nil
+}
+
+// parseSimulateResultStrict 严格解析模拟执行结果，要求 output、gasUsed、result 字段且格式正确
+func parseSimulateResultStrict(ret gjson.Result) (*SimulateResult, error) {
+	output, err := strictString(ret, "output")
+
+	if err != nil {
+		return nil, err
+	}
+
+	gasUsed, err := strictInt(ret, "gasUsed")
+
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := strictInt(ret, "result")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &SimulateResult{
+		Output:  output,
+		GasUsed: gasUsed,
+		Result:  result,
+	}, nil
 }
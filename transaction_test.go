@@ -0,0 +1,47 @@
+package antchain
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSimulateSolidityUsesQueryPath(t *testing.T) {
+	gw := newTestGateway(t, func(params X) (interface{}, bool) {
+		return `{"output":"","gasUsed":21000,"result":0}`, true
+	})
+
+	budget := NewGasBudget()
+	budget.SetLimit("account", 1)
+
+	cli := newTestClient(t, gw, WithGasBudget(budget))
+
+	ret, err := cli.SimulateSolidity(context.Background(), "c", "get()", "[]", "[]")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ret.GasUsed != 21000 {
+		t.Fatalf("gasUsed = %d", ret.GasUsed)
+	}
+
+	if gw.lastPath() != CHAIN_CALL {
+		t.Fatalf("path = %s, want %s", gw.lastPath(), CHAIN_CALL)
+	}
+
+	req := gw.last()
+
+	if req["method"] != string(MethodLocalCallContract) || req["account"] != "account" || req["tenantid"] != "tenant" {
+		t.Fatalf("request = %v", req)
+	}
+
+	for _, k := range []string{"orderId", "nonce", "txSignature"} {
+		if _, ok := req[k]; ok {
+			t.Fatalf("simulation sent %s", k)
+		}
+	}
+
+	if budget.Spent("account") != 0 {
+		t.Fatal("simulation consumed gas budget")
+	}
+}
@@ -0,0 +1,330 @@
+package antchain
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// transportSetting 默认 http.Client 的传输层配置
+type transportSetting struct {
+	proxyURL  string
+	proxyAuth *url.Userinfo
+	dial      DialContextFunc
+	resolver  *CachingResolver
+	pool      poolSetting
+
+	clientCerts []tls.Certificate
+	clientPEMs  [][2][]byte // 待解析的客户端证书及私钥(PEM)
+	pins        []string
+
+	insecure bool
+	rootCAs  *x509.CertPool
+	caFiles  []string
+	fsys     fs.FS // 设置了 WithKeyFS 时从其中读取 CA 证书文件
+}
+
+// poolSetting 连接池配置，0 的含义同 http.Transport 对应字段
+type poolSetting struct {
+	maxConnsPerHost     int
+	maxIdleConns        int
+	maxIdleConnsPerHost int
+	idleConnTimeout     time.Duration
+	tlsHandshakeTimeout time.Duration
+
+	// idlePerHostSet 是否显式设置了 maxIdleConnsPerHost，未设置时不超过 maxConnsPerHost
+	idlePerHostSet bool
+}
+
+// defaultPoolSetting 默认连接池配置
+func defaultPoolSetting() poolSetting {
+	return poolSetting{
+		maxConnsPerHost:     1000,
+		maxIdleConnsPerHost: 1000,
+		idleConnTimeout:     60 * time.Second,
+		tlsHandshakeTimeout: 10 * time.Second,
+	}
+}
+
+// validate 校验连接池配置，返回实际使用的每个 host 最大空闲连接数
+func (ps poolSetting) validate() (int, error) {
+	if ps.maxConnsPerHost < 0 || ps.maxIdleConns < 0 || ps.maxIdleConnsPerHost < 0 {
+		return 0, fmt.Errorf("antchain: invalid connection pool: negative size (maxConnsPerHost=%d, maxIdleConns=%d, maxIdleConnsPerHost=%d)",
+			ps.maxConnsPerHost, ps.maxIdleConns, ps.maxIdleConnsPerHost)
+	}
+
+	if ps.idleConnTimeout < 0 || ps.tlsHandshakeTimeout < 0 {
+		return 0, fmt.Errorf("antchain: invalid connection pool: negative timeout (idleConnTimeout=%s, tlsHandshakeTimeout=%s)",
+			ps.idleConnTimeout, ps.tlsHandshakeTimeout)
+	}
+
+	idlePerHost := ps.maxIdleConnsPerHost
+
+	if ps.maxConnsPerHost > 0 && idlePerHost > ps.maxConnsPerHost {
+		if ps.idlePerHostSet {
+			return 0, fmt.Errorf("antchain: invalid connection pool: maxIdleConnsPerHost %d exceeds maxConnsPerHost %d", idlePerHost, ps.maxConnsPerHost)
+		}
+
+		idlePerHost = ps.maxConnsPerHost
+	}
+
+	if ps.maxIdleConns > 0 && idlePerHost > ps.maxIdleConns {
+		if ps.idlePerHostSet {
+			return 0, fmt.Errorf("antchain: invalid connection pool: maxIdleConnsPerHost %d exceeds maxIdleConns %d", idlePerHost, ps.maxIdleConns)
+		}
+
+		idlePerHost = ps.maxIdleConns
+	}
+
+	return idlePerHost, nil
+}
+
+// WithMaxConnsPerHost 设置每个 host 的最大连接数(默认：1000)，0 表示不限制
+func WithMaxConnsPerHost(n int) ClientOption {
+	return func(c *client) {
+		c.transport.pool.maxConnsPerHost = n
+	}
+}
+
+// WithMaxIdleConns 设置所有 host 的最大空闲连接数(默认：0，不限制)
+func WithMaxIdleConns(n int) ClientOption {
+	return func(c *client) {
+		c.transport.pool.maxIdleConns = n
+	}
+}
+
+// WithMaxIdleConnsPerHost 设置每个 host 的最大空闲连接数(默认：1000，且不超过最大连接数)，
+// 不能超过 WithMaxConnsPerHost 及 WithMaxIdleConns 的设置
+func WithMaxIdleConnsPerHost(n int) ClientOption {
+	return func(c *client) {
+		c.transport.pool.maxIdleConnsPerHost = n
+		c.transport.pool.idlePerHostSet = true
+	}
+}
+
+// WithIdleConnTimeout 设置空闲连接的关闭时间(默认：60s)，0 表示不关闭
+func WithIdleConnTimeout(d time.Duration) ClientOption {
+	return func(c *client) {
+		c.transport.pool.idleConnTimeout = d
+	}
+}
+
+// WithTLSHandshakeTimeout 设置 TLS 握手超时时间(默认：10s)，0 表示不限制
+func WithTLSHandshakeTimeout(d time.Duration) ClientOption {
+	return func(c *client) {
+		c.transport.pool.tlsHandshakeTimeout = d
+	}
+}
+
+// DialContextFunc 建立网络连接的函数，签名同 net.Dialer.DialContext
+type DialContextFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// UnixSocketDialer 返回通过 Unix Socket 建立连接的 DialContextFunc (如：本地Sidecar)
+func UnixSocketDialer(path string) DialContextFunc {
+	d := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 60 * time.Second,
+	}
+
+	return func(ctx context.Context, _, _ string) (net.Conn, error) {
+		return d.DialContext(ctx, "unix", path)
+	}
+}
+
+// envProxy 读取环境变量中的代理配置
+var envProxy = http.ProxyFromEnvironment
+
+func (s *transportSetting) proxy() (func(*http.Request) (*url.URL, error), error) {
+	if len(s.proxyURL) == 0 {
+		if s.proxyAuth == nil {
+			return envProxy, nil
+		}
+
+		// 未指定代理地址时，为环境变量中的代理附加认证信息；
+		// envProxy 返回的 URL 在进程内缓存共享，须复制后修改，避免并发写及认证信息泄漏给其它客户端
+		return func(req *http.Request) (*url.URL, error) {
+			u, err := envProxy(req)
+
+			if err != nil || u == nil {
+				return u, err
+			}
+
+			u2 := *u
+			u2.User = s.proxyAuth
+
+			return &u2, nil
+		}, nil
+	}
+
+	u, err := url.Parse(s.proxyURL)
+
+	if err != nil {
+		return nil, fmt.Errorf("antchain: invalid proxy url: %w", err)
+	}
+
+	if s.proxyAuth != nil {
+		u.User = s.proxyAuth
+	}
+
+	return http.ProxyURL(u), nil
+}
+
+// certificates 返回 mTLS 使用的客户端证书
+func (s *transportSetting) certificates() ([]tls.Certificate, error) {
+	certs := append([]tls.Certificate(nil), s.clientCerts...)
+
+	for _, v := range s.clientPEMs {
+		cert, err := tls.X509KeyPair(v[0], v[1])
+
+		if err != nil {
+			return nil, fmt.Errorf("antchain: invalid client certificate: %w", err)
+		}
+
+		certs = append(certs, cert)
+	}
+
+	return certs, nil
+}
+
+// roots 返回校验网关证书使用的 CA 证书，未指定时返回 nil
+func (s *transportSetting) roots() (*x509.CertPool, error) {
+	if s.rootCAs == nil && len(s.caFiles) == 0 {
+		return nil, nil
+	}
+
+	pool := x509.NewCertPool()
+
+	if s.rootCAs != nil {
+		pool = s.rootCAs.Clone()
+	}
+
+	for _, name := range s.caFiles {
+		var (
+			b   []byte
+			err error
+		)
+
+		if s.fsys != nil {
+			b, err = fs.ReadFile(s.fsys, name)
+		} else {
+			b, err = ioutil.ReadFile(name)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("antchain: read ca cert file: %w", err)
+		}
+
+		if !pool.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("antchain: no certificates found in ca cert file %q", name)
+		}
+	}
+
+	return pool, nil
+}
+
+// WithInsecureTLS 跳过网关证书校验(仅用于测试环境)，NewClient 时会输出警告日志；
+// 使用内部 CA 的私有化部署应使用 WithRootCAs 或 WithCACertFile
+func WithInsecureTLS() ClientOption {
+	return func(c *client) {
+		c.transport.insecure = true
+	}
+}
+
+// WithRootCAs 使用指定的 CA 证书校验网关证书(适用于使用内部 CA 的私有化部署)，默认使用系统 CA
+func WithRootCAs(pool *x509.CertPool) ClientOption {
+	return func(c *client) {
+		c.transport.rootCAs = pool
+	}
+}
+
+// WithCACertFile 从 PEM 文件读取 CA 证书校验网关证书，可与 WithRootCAs 同时使用；
+// 设置了 WithKeyFS 时从其中读取
+func WithCACertFile(path string) ClientOption {
+	return func(c *client) {
+		c.transport.caFiles = append(c.transport.caFiles, path)
+	}
+}
+
+// WithClientCertificate 设置 mTLS 客户端证书(适用于要求双向认证的私有化网关)，与 AccessKey 签名同时生效
+func WithClientCertificate(cert tls.Certificate) ClientOption {
+	return func(c *client) {
+		c.transport.clientCerts = append(c.transport.clientCerts, cert)
+	}
+}
+
+// WithClientCertificatePEM 使用 PEM 格式的证书及私钥设置 mTLS 客户端证书，格式错误时 NewClient 返回错误
+func WithClientCertificatePEM(certPEM, keyPEM []byte) ClientOption {
+	return func(c *client) {
+		c.transport.clientPEMs = append(c.transport.clientPEMs, [2][]byte{certPEM, keyPEM})
+	}
+}
+
+func (s *transportSetting) build() (*http.Transport, error) {
+	idlePerHost, err := s.pool.validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	proxy, err := s.proxy()
+
+	if err != nil {
+		return nil, err
+	}
+
+	dial := s.dial
+
+	if dial == nil {
+		dial = (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 60 * time.Second,
+		}).DialContext
+	}
+
+	if s.resolver != nil {
+		dial = s.resolver.DialContext(dial)
+	}
+
+	certs, err := s.certificates()
+
+	if err != nil {
+		return nil, err
+	}
+
+	rootCAs, err := s.roots()
+
+	if err != nil {
+		return nil, err
+	}
+
+	tlsCfg := &tls.Config{
+		InsecureSkipVerify: s.insecure,
+		RootCAs:            rootCAs,
+		Certificates:       certs,
+	}
+
+	if len(s.pins) != 0 {
+		tlsCfg.VerifyConnection = newPinSet(s.pins).verify
+	}
+
+	tr := &http.Transport{
+		Proxy:                 proxy,
+		DialContext:           dial,
+		TLSClientConfig:       tlsCfg,
+		MaxIdleConns:          s.pool.maxIdleConns,
+		MaxIdleConnsPerHost:   idlePerHost,
+		MaxConnsPerHost:       s.pool.maxConnsPerHost,
+		IdleConnTimeout:       s.pool.idleConnTimeout,
+		TLSHandshakeTimeout:   s.pool.tlsHandshakeTimeout,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+
+	return tr, nil
+}
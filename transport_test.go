@@ -0,0 +1,43 @@
+package antchain
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestProxyAuthDoesNotMutateEnvProxy(t *testing.T) {
+	shared := &url.URL{Scheme: "http", Host: "proxy.local:3128"}
+
+	orig := envProxy
+	envProxy = func(*http.Request) (*url.URL, error) { return shared, nil }
+	defer func() { envProxy = orig }()
+
+	s := &transportSetting{proxyAuth: url.UserPassword("user", "secret")}
+
+	proxy, err := s.proxy()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+
+	u, err := proxy(req)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u == shared {
+		t.Fatal("proxy returned the shared env proxy URL")
+	}
+
+	if u.User.String() != "user:secret" {
+		t.Fatalf("proxy user = %q", u.User.String())
+	}
+
+	if shared.User != nil {
+		t.Fatalf("shared env proxy URL was mutated: %v", shared)
+	}
+}
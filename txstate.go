@@ -0,0 +1,373 @@
+package antchain
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// ErrTxTimeout 交易在超时时间内未上链
+var ErrTxTimeout = errors.New("antchain: transaction timeout")
+
+// TxState 交易的生命周期状态
+type TxState string
+
+const (
+	// TxSubmitted 已提交，网关尚未查询到交易
+	TxSubmitted TxState = "SUBMITTED"
+	// TxAccepted 网关已查询到交易，尚无回执
+	TxAccepted TxState = "ACCEPTED"
+	// TxMined 已打包上链且执行成功
+	TxMined TxState = "MINED"
+	// TxConfirmed 上链后已达到要求的确认块数
+	TxConfirmed TxState = "CONFIRMED"
+	// TxFailed 执行失败或超时未上链
+	TxFailed TxState = "FAILED"
+)
+
+// txTransitions 允许的状态迁移
+var txTransitions = map[TxState][]TxState{
+	TxSubmitted: {TxAccepted, TxFailed},
+	TxAccepted:  {TxMined, TxFailed},
+	TxMined:     {TxConfirmed},
+}
+
+// Terminal 是否为终态
+func (s TxState) Terminal() bool {
+	return s == TxConfirmed || s == TxFailed
+}
+
+// CanTransitionTo 是否允许迁移到状态 to
+func (s TxState) CanTransitionTo(to TxState) bool {
+	for _, v := range txTransitions[s] {
+		if v == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TxTransition 一次状态迁移
+type TxTransition struct {
+	Hash        string
+	From        TxState
+	To          TxState
+	BlockNumber int64  // 交易所在块高(ACCEPTED 之后)
+	Receipt     string // 交易回执(MINED 及执行失败时)
+	Err         error  // 失败原因(FAILED 时)
+	Time        time.Time
+}
+
+// TxStatus 交易的当前状态
+type TxStatus struct {
+	Hash        string
+	State       TxState
+	BlockNumber int64
+	Receipt     string
+	Err         error
+	SubmittedAt time.Time
+	UpdatedAt   time.Time
+}
+
+// TxTrackerOption TxTracker 的可选配置
+type TxTrackerOption func(t *TxTracker)
+
+// WithConfirmations 交易所在块之后再产生 n 个块才视为 CONFIRMED(默认：0，上链即确认)
+func WithConfirmations(n int64) TxTrackerOption {
+	return func(t *TxTracker) {
+		t.confirmations = n
+	}
+}
+
+// WithTxTimeout 交易提交后超过 timeout 网关仍未查询到交易(SUBMITTED)则置为 FAILED(默认：不超时)；
+// 已被网关接受(ACCEPTED)的交易可能已上链，继续轮询回执直到上链
+func WithTxTimeout(timeout time.Duration) TxTrackerOption {
+	return func(t *TxTracker) {
+		t.timeout = timeout
+	}
+}
+
+// WithTransitionCallback 状态迁移时的回调，按迁移顺序在轮询的 goroutine 中调用
+func WithTransitionCallback(fn func(tr *TxTransition)) TxTrackerOption {
+	return func(t *TxTracker) {
+		t.callbacks = append(t.callbacks, fn)
+	}
+}
+
+// TxTracker 通过轮询交易及回执驱动交易的状态机：
+//
+//	SUBMITTED -> ACCEPTED -> MINED -> CONFIRMED
+//	    |            |
+//	    +------------+--> FAILED
+type TxTracker struct {
+	cli           QueryService
+	confirmations int64
+	timeout       time.Duration
+	callbacks     []func(tr *TxTransition)
+	now           func() time.Time
+
+	mutex sync.Mutex
+	txs   map[string]*TxStatus
+}
+
+// NewTxTracker 返回 TxTracker
+func NewTxTracker(cli QueryService, options ...TxTrackerOption) *TxTracker {
+	t := &TxTracker{
+		cli: cli,
+		now: time.Now,
+		txs: make(map[string]*TxStatus),
+	}
+
+	for _, f := range options {
+		f(t)
+	}
+
+	return t
+}
+
+// Track 开始跟踪已提交的交易，重复跟踪不会重置状态
+func (t *TxTracker) Track(hash string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if _, ok := t.txs[hash]; ok {
+		return
+	}
+
+	now := t.now()
+
+	t.txs[hash] = &TxStatus{
+		Hash:        hash,
+		State:       TxSubmitted,
+		SubmittedAt: now,
+		UpdatedAt:   now,
+	}
+}
+
+// Status 返回交易的当前状态
+func (t *TxTracker) Status(hash string) (TxStatus, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	s, ok := t.txs[hash]
+
+	if !ok {
+		return TxStatus{}, false
+	}
+
+	return *s, true
+}
+
+// Forget 停止跟踪交易
+func (t *TxTracker) Forget(hash string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	delete(t.txs, hash)
+}
+
+// Pending 返回未到达终态的交易数
+func (t *TxTracker) Pending() int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	n := 0
+
+	for _, s := range t.txs {
+		if !s.State.Terminal() {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Poll 查询所有未到达终态的交易并推进状态，返回本次发生的状态迁移
+func (t *TxTracker) Poll(ctx context.Context) []*TxTransition {
+	t.mutex.Lock()
+
+	pending := make([]TxStatus, 0, len(t.txs))
+
+	for _, s := range t.txs {
+		if !s.State.Terminal() {
+			pending = append(pending, *s)
+		}
+	}
+
+	t.mutex.Unlock()
+
+	var lastBlock int64
+
+	transitions := make([]*TxTransition, 0)
+
+	for _, s := range pending {
+		if ctx.Err() != nil {
+			break
+		}
+
+		transitions = append(transitions, t.advance(ctx, s, &lastBlock)...)
+	}
+
+	return transitions
+}
+
+// advance 推进单个交易的状态，一次轮询可连续迁移多步
+func (t *TxTracker) advance(ctx context.Context, s TxStatus, lastBlock *int64) []*TxTransition {
+	transitions := make([]*TxTransition, 0)
+
+	for !s.State.Terminal() {
+		tr := t.step(ctx, &s, lastBlock)
+
+		if tr == nil {
+			break
+		}
+
+		if !t.apply(tr) {
+			break
+		}
+
+		transitions = append(transitions, tr)
+
+		s.State = tr.To
+		s.BlockNumber = tr.BlockNumber
+		s.Receipt = tr.Receipt
+	}
+
+	return transitions
+}
+
+// step 根据查询结果返回下一次状态迁移，状态不变返回 nil
+func (t *TxTracker) step(ctx context.Context, s *TxStatus, lastBlock *int64) *TxTransition {
+	tr := &TxTransition{
+		Hash:        s.Hash,
+		From:        s.State,
+		BlockNumber: s.BlockNumber,
+		Receipt:     s.Receipt,
+	}
+
+	switch s.State {
+	case TxSubmitted:
+		tx, err := t.cli.QueryTransaction(ctx, s.Hash)
+
+		if err != nil || len(tx) == 0 {
+			return t.expire(s, tr)
+		}
+
+		tr.To = TxAccepted
+		tr.BlockNumber = gjson.Get(tx, "blockNumber").Int()
+	case TxAccepted:
+		receipt, err := t.cli.QueryReceipt(ctx, s.Hash)
+
+		// 交易已被网关接受，可能已上链，不做超时处理，继续轮询回执
+		if err != nil || len(receipt) == 0 {
+			return nil
+		}
+
+		tr.Receipt = receipt
+
+		if n := gjson.Get(receipt, "blockNumber"); n.Exists() {
+			tr.BlockNumber = n.Int()
+		}
+
+		if err := receiptError(s.Hash, receipt); err != nil {
+			tr.To = TxFailed
+			tr.Err = err
+
+			break
+		}
+
+		tr.To = TxMined
+	case TxMined:
+		if t.confirmations > 0 {
+			if *lastBlock == 0 {
+				data, err := t.cli.QueryLastBlock(ctx)
+
+				if err != nil {
+					return nil
+				}
+
+				if *lastBlock, err = ParseBlockNumber(data); err != nil {
+					return nil
+				}
+			}
+
+			if *lastBlock-s.BlockNumber < t.confirmations {
+				return nil
+			}
+		}
+
+		tr.To = TxConfirmed
+	default:
+		return nil
+	}
+
+	return tr
+}
+
+// expire 交易超时未上链时迁移到 FAILED
+func (t *TxTracker) expire(s *TxStatus, tr *TxTransition) *TxTransition {
+	if t.timeout <= 0 || t.now().Sub(s.SubmittedAt) < t.timeout {
+		return nil
+	}
+
+	tr.To = TxFailed
+	tr.Err = ErrTxTimeout
+
+	return tr
+}
+
+// apply 更新交易状态并回调，交易已不再跟踪或状态已变化时返回 false
+func (t *TxTracker) apply(tr *TxTransition) bool {
+	t.mutex.Lock()
+
+	s, ok := t.txs[tr.Hash]
+
+	if !ok || s.State != tr.From || !tr.From.CanTransitionTo(tr.To) {
+		t.mutex.Unlock()
+
+		return false
+	}
+
+	tr.Time = t.now()
+
+	s.State = tr.To
+	s.BlockNumber = tr.BlockNumber
+	s.Receipt = tr.Receipt
+	s.Err = tr.Err
+	s.UpdatedAt = tr.Time
+
+	t.mutex.Unlock()
+
+	for _, f := range t.callbacks {
+		f(tr)
+	}
+
+	return true
+}
+
+// defaultTrackInterval TxTracker.Run 默认的轮询间隔
+const defaultTrackInterval = time.Second
+
+// Run 按 interval 定期轮询，直到 ctx 结束；interval<=0 时使用默认的 1 秒
+func (t *TxTracker) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultTrackInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		t.Poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
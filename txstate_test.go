@@ -0,0 +1,184 @@
+package antchain
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeChain 模拟交易查询：txs 为网关已接受的交易，receipts 为已上链交易的回执
+type fakeChain struct {
+	QueryService
+
+	mutex     sync.Mutex
+	txs       map[string]string
+	receipts  map[string]string
+	lastBlock int64
+}
+
+func newFakeChain() *fakeChain {
+	return &fakeChain{
+		txs:      make(map[string]string),
+		receipts: make(map[string]string),
+	}
+}
+
+func (f *fakeChain) QueryTransaction(ctx context.Context, hash string) (string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.txs[hash], nil
+}
+
+func (f *fakeChain) QueryReceipt(ctx context.Context, hash string) (string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.receipts[hash], nil
+}
+
+func (f *fakeChain) QueryLastBlock(ctx context.Context) (string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return strconv.FormatInt(f.lastBlock, 10), nil
+}
+
+func TestTxStateTransitions(t *testing.T) {
+	cases := map[TxState][]TxState{
+		TxSubmitted: {TxAccepted, TxFailed},
+		TxAccepted:  {TxMined, TxFailed},
+		TxMined:     {TxConfirmed},
+	}
+
+	all := []TxState{TxSubmitted, TxAccepted, TxMined, TxConfirmed, TxFailed}
+
+	for _, from := range all {
+		for _, to := range all {
+			want := false
+
+			for _, v := range cases[from] {
+				want = want || v == to
+			}
+
+			if got := from.CanTransitionTo(to); got != want {
+				t.Errorf("%s -> %s = %v, want %v", from, to, got, want)
+			}
+		}
+	}
+
+	if !TxConfirmed.Terminal() || !TxFailed.Terminal() || TxMined.Terminal() {
+		t.Fatal("unexpected terminal states")
+	}
+}
+
+func TestTxTrackerLifecycle(t *testing.T) {
+	chain := newFakeChain()
+
+	var states []TxState
+
+	tracker := NewTxTracker(chain, WithConfirmations(2), WithTransitionCallback(func(tr *TxTransition) {
+		states = append(states, tr.To)
+	}))
+
+	tracker.Track("0x1")
+
+	if trs := tracker.Poll(context.Background()); len(trs) != 0 {
+		t.Fatalf("unexpected transitions %v", trs)
+	}
+
+	chain.txs["0x1"] = `{"blockNumber":10}`
+	chain.receipts["0x1"] = `{"blockNumber":10,"result":0}`
+	chain.lastBlock = 11
+
+	tracker.Poll(context.Background())
+
+	if s, _ := tracker.Status("0x1"); s.State != TxMined || s.BlockNumber != 10 {
+		t.Fatalf("status = %+v", s)
+	}
+
+	chain.lastBlock = 12
+
+	tracker.Poll(context.Background())
+
+	want := []TxState{TxAccepted, TxMined, TxConfirmed}
+
+	if len(states) != len(want) {
+		t.Fatalf("states = %v, want %v", states, want)
+	}
+
+	for i := range want {
+		if states[i] != want[i] {
+			t.Fatalf("states = %v, want %v", states, want)
+		}
+	}
+
+	if tracker.Pending() != 0 {
+		t.Fatal("confirmed transaction still pending")
+	}
+}
+
+func TestTxTrackerFailedReceipt(t *testing.T) {
+	chain := newFakeChain()
+	chain.txs["0x1"] = `{"blockNumber":10}`
+	chain.receipts["0x1"] = `{"blockNumber":10,"result":10}`
+
+	tracker := NewTxTracker(chain)
+	tracker.Track("0x1")
+	tracker.Poll(context.Background())
+
+	if s, _ := tracker.Status("0x1"); s.State != TxFailed || s.Err == nil {
+		t.Fatalf("status = %+v", s)
+	}
+}
+
+func TestTxTrackerTimeout(t *testing.T) {
+	chain := newFakeChain()
+
+	now := time.Now()
+
+	tracker := NewTxTracker(chain, WithTxTimeout(time.Minute))
+	tracker.now = func() time.Time { return now }
+
+	tracker.Track("0x1")
+	tracker.Track("0x2")
+
+	// 0x2 已被网关接受但尚无回执
+	chain.txs["0x2"] = `{"blockNumber":10}`
+
+	tracker.Poll(context.Background())
+
+	now = now.Add(2 * time.Minute)
+
+	tracker.Poll(context.Background())
+
+	if s, _ := tracker.Status("0x1"); s.State != TxFailed || !errors.Is(s.Err, ErrTxTimeout) {
+		t.Fatalf("submitted tx: %+v", s)
+	}
+
+	// 已接受的交易不超时，继续等待回执
+	if s, _ := tracker.Status("0x2"); s.State != TxAccepted {
+		t.Fatalf("accepted tx: %+v", s)
+	}
+
+	chain.receipts["0x2"] = `{"blockNumber":10,"result":0}`
+
+	tracker.Poll(context.Background())
+
+	if s, _ := tracker.Status("0x2"); s.State != TxConfirmed {
+		t.Fatalf("accepted tx: %+v", s)
+	}
+}
+
+func TestTxTrackerRunDefaultInterval(t *testing.T) {
+	tracker := NewTxTracker(newFakeChain())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// interval 为 0 时不应 panic
+	tracker.Run(ctx, 0)
+}
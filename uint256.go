@@ -0,0 +1,152 @@
+package antchain
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/shenghui0779/antchain/codec"
+)
+
+// maxUint256 2^256-1
+var maxUint256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+// Uint256 无符号256位整数(Solidity uint256)，JSON 编码为十进制字符串
+type Uint256 struct {
+	v big.Int
+}
+
+// NewUint256 由 big.Int 构造 Uint256，超出 [0, 2^256-1] 范围返回错误
+func NewUint256(x *big.Int) (*Uint256, error) {
+	if x == nil || x.Sign() < 0 || x.Cmp(maxUint256) > 0 {
+		return nil, fmt.Errorf("antchain: value %v out of uint256 range", x)
+	}
+
+	u := new(Uint256)
+	u.v.Set(x)
+
+	return u, nil
+}
+
+// Uint256FromUint64 由 uint64 构造 Uint256
+func Uint256FromUint64(x uint64) *Uint256 {
+	u := new(Uint256)
+	u.v.SetUint64(x)
+
+	return u
+}
+
+// ParseUint256 解析十进制或带 0x 前缀的十六进制字符串
+func ParseUint256(s string) (*Uint256, error) {
+	s = strings.TrimSpace(s)
+
+	base := 10
+
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s = s[2:]
+		base = 16
+	}
+
+	x, ok := new(big.Int).SetString(s, base)
+
+	if !ok {
+		return nil, fmt.Errorf("antchain: invalid uint256 %q", s)
+	}
+
+	return NewUint256(x)
+}
+
+// ParseUint256Hex 解析十六进制字符串(可不带 0x 前缀)
+func ParseUint256Hex(s string) (*Uint256, error) {
+	x, ok := new(big.Int).SetString(codec.Trim0x(strings.TrimSpace(s)), 16)
+
+	if !ok {
+		return nil, fmt.Errorf("antchain: invalid uint256 hex %q", s)
+	}
+
+	return NewUint256(x)
+}
+
+// BigInt 返回对应的 big.Int(副本)
+func (u *Uint256) BigInt() *big.Int {
+	return new(big.Int).Set(&u.v)
+}
+
+// IsZero 是否为0
+func (u *Uint256) IsZero() bool {
+	return u.v.Sign() == 0
+}
+
+// Cmp 比较大小，返回 -1、0、1
+func (u *Uint256) Cmp(o *Uint256) int {
+	return u.v.Cmp(&o.v)
+}
+
+// String 返回十进制字符串
+func (u *Uint256) String() string {
+	return u.v.String()
+}
+
+// Hex 返回带 0x 前缀的十六进制字符串
+func (u *Uint256) Hex() string {
+	return "0x" + u.v.Text(16)
+}
+
+// Bytes32 返回 ABI 编码(32字节大端)
+func (u *Uint256) Bytes32() [32]byte {
+	var b [32]byte
+
+	u.v.FillBytes(b[:])
+
+	return b
+}
+
+// EncodeABI 返回 ABI 编码的 hex 字符串(64位，不带 0x 前缀)
+func (u *Uint256) EncodeABI() string {
+	b := u.Bytes32()
+
+	return hex.EncodeToString(b[:])
+}
+
+// MarshalJSON 编码为十进制字符串，避免 JSON 数字精度丢失
+func (u Uint256) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.v.String())
+}
+
+// UnmarshalJSON 支持十进制/十六进制字符串及 JSON 数字，null 不修改原值
+func (u *Uint256) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var s string
+
+	if len(b) != 0 && b[0] == '"' {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return fmt.Errorf("antchain: invalid uint256 %s: %w", b, err)
+		}
+	} else {
+		var n json.Number
+
+		if err := json.Unmarshal(b, &n); err != nil {
+			return fmt.Errorf("antchain: invalid uint256 %s: %w", b, err)
+		}
+
+		s = n.String()
+	}
+
+	v, err := ParseUint256(s)
+
+	if err != nil {
+		return err
+	}
+
+	u.v.Set(&v.v)
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package antchain
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUint256UnmarshalJSON(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{`"123"`, "123", true},
+		{`123`, "123", true},
+		{`"0x1f"`, "31", true},
+		{` "42" `, "42", true},
+		{`"115792089237316195423570985008687907853269984665640564039457584007913129639935"`, maxUint256.String(), true},
+		{`null`, "7", true},
+		{`"123`, "", false},
+		{`123"`, "", false},
+		{`""`, "", false},
+		{`"-1"`, "", false},
+		{`1.5`, "", false},
+		{`true`, "", false},
+		{`"115792089237316195423570985008687907853269984665640564039457584007913129639936"`, "", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.in, func(t *testing.T) {
+			u := Uint256FromUint64(7)
+
+			err := u.UnmarshalJSON([]byte(c.in))
+
+			if (err == nil) != c.ok {
+				t.Fatalf("err = %v", err)
+			}
+
+			if c.ok && u.String() != c.want {
+				t.Fatalf("value = %s, want %s", u, c.want)
+			}
+		})
+	}
+}
+
+func TestUint256JSONRoundTrip(t *testing.T) {
+	var v struct {
+		Amount *Uint256 `json:"amount"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"amount":"0xff"}`), &v); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(v)
+
+	if err != nil || string(b) != `{"amount":"255"}` {
+		t.Fatalf("json = %s, err = %v", b, err)
+	}
+}
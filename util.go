@@ -4,6 +4,7 @@ import (
 	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
+	_ "crypto/sha1"
 	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
@@ -11,9 +12,13 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"io/fs"
 	"io/ioutil"
 	"math/big"
 	"path/filepath"
+	"unicode/utf8"
+
+	"github.com/shenghui0779/antchain/codec"
 )
 
 const (
@@ -34,9 +39,80 @@ const (
 	RSAPKCS8 PemBlockType = "PRIVATE KEY"
 )
 
+// SignType shakehand 签名算法
+type SignType string
+
+const (
+	// SignSHA256WithRSA SHA256WithRSA(默认)
+	SignSHA256WithRSA SignType = "SHA256WithRSA"
+	// SignSHA1WithRSA SHA1WithRSA(早期开通的租户)
+	SignSHA1WithRSA SignType = "SHA1WithRSA"
+	// SignSM3WithSM2 国密 SM3WithSM2，SDK 不内置 SM2 签名实现，须通过 CredentialsProvider 提供签名器(见 SignerFunc)
+	SignSM3WithSM2 SignType = "SM3WithSM2"
+)
+
+// Hash 返回签名算法的摘要算法，SM3WithSM2 由签名器内部计算 SM3(ZA || data)，返回 0
+func (st SignType) Hash() crypto.Hash {
+	switch st {
+	case SignSHA1WithRSA:
+		return crypto.SHA1
+	case SignSM3WithSM2:
+		return 0
+	}
+
+	return crypto.SHA256
+}
+
+// errSM2Signer SM2 签名须使用经过验证的常量时间实现，SDK 不从 AccessKey 文件加载
+var errSM2Signer = wrapErr(ErrInvalidKey, errors.New("SM3WithSM2 requires an external signer, see SignerFunc"))
+
+// LoadSigner 按签名算法读取 PEM 文件中的私钥
+func LoadSigner(signType SignType, pemFile string) (Signer, error) {
+	switch signType {
+	case "", SignSHA256WithRSA, SignSHA1WithRSA:
+		return NewPrivateKeyFromPemFile(pemFile)
+	case SignSM3WithSM2:
+		return nil, errSM2Signer
+	}
+
+	return nil, wrapErr(ErrInvalidKey, fmt.Errorf("unsupported sign type %q", string(signType)))
+}
+
+// LoadSignerFS 按签名算法读取 fsys 中 PEM 文件的私钥(如：go:embed 打包的密钥)
+func LoadSignerFS(signType SignType, fsys fs.FS, name string) (Signer, error) {
+	switch signType {
+	case "", SignSHA256WithRSA, SignSHA1WithRSA:
+		return NewPrivateKeyFromFS(fsys, name)
+	case SignSM3WithSM2:
+		return nil, errSM2Signer
+	}
+
+	return nil, wrapErr(ErrInvalidKey, fmt.Errorf("unsupported sign type %q", string(signType)))
+}
+
 // X is a convenient alias for a map[string]interface{}.
 type X map[string]interface{}
 
+// Signer shakehand 使用的签名器，*PrivateKey 及远程签名(如：KMS)均可实现
+type Signer interface {
+	// Sign 使用 hash 对 data 摘要后签名
+	Sign(hash crypto.Hash, data []byte) ([]byte, error)
+}
+
+// SignerFunc 函数形式的 Signer，可用于接入第三方国密实现(如：github.com/emmansun/gmsm)：
+//
+//	signer := antchain.SignerFunc(func(_ crypto.Hash, data []byte) ([]byte, error) {
+//		return key.SignWithSM2(rand.Reader, nil, data) // 默认 UID，返回 ASN.1 编码的签名
+//	})
+//
+//	cli, err := antchain.NewClient(cfg, antchain.WithCredentialsProvider(antchain.NewStaticProvider(accessID, signer)))
+type SignerFunc func(hash crypto.Hash, data []byte) ([]byte, error)
+
+// Sign 调用 f 签名
+func (f SignerFunc) Sign(hash crypto.Hash, data []byte) ([]byte, error) {
+	return f(hash, data)
+}
+
 // PrivateKey RSA private key
 type PrivateKey struct {
 	key *rsa.PrivateKey
@@ -45,7 +121,7 @@ type PrivateKey struct {
 // Sign returns sha-with-rsa signature.
 func (pk *PrivateKey) Sign(hash crypto.Hash, data []byte) ([]byte, error) {
 	if !hash.Available() {
-		return nil, fmt.Errorf("crypto: requested hash function (%s) is unavailable", hash.String())
+		return nil, wrapErr(ErrSignFailed, fmt.Errorf("requested hash function (%s) is unavailable", hash.String()))
 	}
 
 	h := hash.New()
@@ -54,7 +130,7 @@ func (pk *PrivateKey) Sign(hash crypto.Hash, data []byte) ([]byte, error) {
 	signature, err := rsa.SignPKCS1v15(rand.Reader, pk.key, hash, h.Sum(nil))
 
 	if err != nil {
-		return nil, err
+		return nil, wrapErr(ErrSignFailed, err)
 	}
 
 	return signature, nil
@@ -65,35 +141,62 @@ func NewPrivateKeyFromPemFile(pemFile string) (*PrivateKey, error) {
 	keyPath, err := filepath.Abs(pemFile)
 
 	if err != nil {
-		return nil, err
+		return nil, wrapErr(ErrInvalidKey, err)
 	}
 
 	b, err := ioutil.ReadFile(keyPath)
 
 	if err != nil {
-		return nil, err
+		return nil, wrapErr(ErrInvalidKey, err)
+	}
+
+	return NewPrivateKeyFromPem(b)
+}
+
+// NewPrivateKeyFromFS returns new private key with pem file in fsys.
+func NewPrivateKeyFromFS(fsys fs.FS, name string) (*PrivateKey, error) {
+	b, err := fs.ReadFile(fsys, name)
+
+	if err != nil {
+		return nil, wrapErr(ErrInvalidKey, err)
 	}
 
+	return NewPrivateKeyFromPem(b)
+}
+
+// NewPrivateKeyFromPem returns new private key with pem data.
+func NewPrivateKeyFromPem(b []byte) (*PrivateKey, error) {
 	block, _ := pem.Decode(b)
 
 	if block == nil {
-		return nil, errors.New("no PEM data is found")
+		return nil, wrapErr(ErrInvalidKey, errors.New("no PEM data is found"))
 	}
 
-	var pk interface{}
+	var (
+		pk  interface{}
+		err error
+	)
 
 	switch PemBlockType(block.Type) {
 	case RSAPKCS1:
 		pk, err = x509.ParsePKCS1PrivateKey(block.Bytes)
 	case RSAPKCS8:
 		pk, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, wrapErr(ErrInvalidKey, fmt.Errorf("unsupported PEM block type %q", block.Type))
 	}
 
 	if err != nil {
-		return nil, err
+		return nil, wrapErr(ErrInvalidKey, err)
 	}
 
-	return &PrivateKey{key: pk.(*rsa.PrivateKey)}, nil
+	rsaKey, ok := pk.(*rsa.PrivateKey)
+
+	if !ok {
+		return nil, wrapErr(ErrInvalidKey, errors.New("not a RSA private key"))
+	}
+
+	return &PrivateKey{key: rsaKey}, nil
 }
 
 // Identity 链账户对应的Identity
@@ -101,24 +204,104 @@ type Identity struct {
 	Data string `json:"data"`
 }
 
-// GetIdentityByName 根据链账户名称获取对应的Identity
+// Bytes 返回 Identity 的原始字节
+func (i *Identity) Bytes() ([]byte, error) {
+	b, err := codec.Base64ToBytes(i.Data)
+
+	if err != nil {
+		return nil, wrapErr(ErrDecodeFailed, err)
+	}
+
+	return b, nil
+}
+
+// Hex 返回 Identity 的 hex 格式
+func (i *Identity) Hex() (string, error) {
+	h, err := codec.Base64ToHex(i.Data)
+
+	if err != nil {
+		return "", wrapErr(ErrDecodeFailed, err)
+	}
+
+	return h, nil
+}
+
+// Validate 校验 Identity 是否为32字节的 base64 数据
+func (i *Identity) Validate() error {
+	return ValidateIdentity(i.Data)
+}
+
+// ValidateIdentity 校验 base64 格式的 Identity(编码规则见 codec.IdentityToHex)
+func ValidateIdentity(data string) error {
+	if _, err := codec.IdentityToHex(data); err != nil {
+		return wrapErr(ErrDecodeFailed, err)
+	}
+
+	return nil
+}
+
+// NewIdentityFromBytes 根据原始字节返回 Identity
+func NewIdentityFromBytes(b []byte) (*Identity, error) {
+	if len(b) != codec.IdentityLength {
+		return nil, wrapErr(ErrDecodeFailed, fmt.Errorf("invalid identity length %d, expected %d", len(b), codec.IdentityLength))
+	}
+
+	return &Identity{
+		Data: codec.BytesToBase64(b),
+	}, nil
+}
+
+// NewIdentityFromHex 根据 hex 格式(可带0x前缀)返回 Identity
+func NewIdentityFromHex(h string) (*Identity, error) {
+	data, err := codec.IdentityFromHex(h)
+
+	if err != nil {
+		return nil, wrapErr(ErrDecodeFailed, err)
+	}
+
+	return &Identity{Data: data}, nil
+}
+
+// GetContractIdentity 根据合约名称获取合约部署后在链上的Identity
+func GetContractIdentity(name string) *Identity {
+	return GetIdentityByName(name)
+}
+
+// GetIdentityByName 根据链账户名称获取对应的Identity(结果会被缓存，见 SetMemoSize)
 func GetIdentityByName(name string) *Identity {
-	h := sha256.New()
-	h.Write([]byte(name))
+	return &Identity{
+		Data: defaultMemo.identityByName(name),
+	}
+}
+
+// GetIdentityByPublicKey 根据账户公钥获取对应的Identity(适用于非SDK创建的基于公钥的账户)
+func GetIdentityByPublicKey(pubKey []byte) *Identity {
+	sum := sha256.Sum256(pubKey)
 
 	return &Identity{
-		Data: base64.StdEncoding.EncodeToString(h.Sum(nil)),
+		Data: base64.StdEncoding.EncodeToString(sum[:]),
 	}
 }
 
-// TokenID 链上资产(NFT)的唯一标识
-type TokenID *big.Int
+// ParseIdentity 解析 hex(可带0x前缀)或 base64 格式的 Identity，统一为 base64 格式的 Identity
+func ParseIdentity(s string) (*Identity, error) {
+	if identity, err := NewIdentityFromHex(s); err == nil {
+		return identity, nil
+	}
+
+	if err := ValidateIdentity(s); err != nil {
+		return nil, err
+	}
+
+	return &Identity{Data: s}, nil
+}
 
-// GetTokenID 获取token(如：md5值)对应的tokenID(uint256)
-func GetTokenID(token string) TokenID {
-	v, _ := big.NewInt(0).SetString(token, 16)
+// TokenID 链上资产(NFT)的唯一标识
+type TokenID = Uint256
 
-	return TokenID(v)
+// GetTokenID 获取token(如：md5值，十六进制)对应的tokenID(uint256)，结果会被缓存，见 SetMemoSize
+func GetTokenID(token string) (*TokenID, error) {
+	return defaultMemo.tokenID(token)
 }
 
 // ParseOutput 解析合约方法返回的output
@@ -126,8 +309,58 @@ func ParseOutput(data string) (string, error) {
 	b, err := base64.StdEncoding.DecodeString(data)
 
 	if err != nil {
-		return "", err
+		return "", wrapErr(ErrDecodeFailed, err)
 	}
 
 	return hex.EncodeToString(b), nil
 }
+
+// ParseOutputToString 解析合约方法返回的 string 类型 output(ABI编码：offset、length、data)
+func ParseOutputToString(data string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(data)
+
+	if err != nil {
+		return "", wrapErr(ErrDecodeFailed, err)
+	}
+
+	offset, err := abiWordToInt(b, 0)
+
+	if err != nil {
+		return "", err
+	}
+
+	length, err := abiWordToInt(b, offset)
+
+	if err != nil {
+		return "", err
+	}
+
+	start := offset + 32
+
+	if length > len(b)-start {
+		return "", wrapErr(ErrDecodeFailed, fmt.Errorf("string length %d out of range", length))
+	}
+
+	s := b[start : start+length]
+
+	if !utf8.Valid(s) {
+		return "", wrapErr(ErrDecodeFailed, errors.New("output is not a valid utf-8 string"))
+	}
+
+	return string(s), nil
+}
+
+// abiWordToInt 读取 pos 处的 32 字节 ABI 字，并转换为 int
+func abiWordToInt(b []byte, pos int) (int, error) {
+	if pos < 0 || pos > len(b)-32 {
+		return 0, wrapErr(ErrDecodeFailed, fmt.Errorf("abi word at %d out of range", pos))
+	}
+
+	v := new(big.Int).SetBytes(b[pos : pos+32])
+
+	if !v.IsInt64() || v.Int64() > int64(len(b)) {
+		return 0, wrapErr(ErrDecodeFailed, fmt.Errorf("abi value %s out of range", v.String()))
+	}
+
+	return int(v.Int64()), nil
+}
@@ -0,0 +1,48 @@
+package antchain
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"testing"
+)
+
+func TestLoadSignerSM2RequiresExternalSigner(t *testing.T) {
+	if _, err := LoadSigner(SignSM3WithSM2, "key.pem"); !errors.Is(err, ErrInvalidKey) {
+		t.Fatalf("err = %v, want ErrInvalidKey", err)
+	}
+}
+
+func TestSignerFuncSM2(t *testing.T) {
+	gw := newTestGateway(t, func(params X) (interface{}, bool) { return "0x1", true })
+
+	var hashes []crypto.Hash
+
+	signer := SignerFunc(func(hash crypto.Hash, data []byte) ([]byte, error) {
+		hashes = append(hashes, hash)
+
+		return []byte("sm2-signature"), nil
+	})
+
+	cli, err := NewClient(&Config{
+		BizID:    "biz",
+		AccessID: "access-id",
+		Endpoint: gw.URL,
+		SignType: SignSM3WithSM2,
+	}, WithCredentialsProvider(NewStaticProvider("access-id", signer)))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer cli.Close(context.Background())
+
+	if _, err = cli.QueryLastBlock(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// SM3WithSM2 由签名器内部计算摘要
+	if len(hashes) != 1 || hashes[0] != 0 {
+		t.Fatalf("hashes = %v", hashes)
+	}
+}
@@ -0,0 +1,77 @@
+package antchain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tidwall/gjson"
+)
+
+// ErrSchemaViolation 网关响应未通过 WithResponseValidator 注册的校验
+var ErrSchemaViolation = errors.New("antchain: response schema violation")
+
+// SchemaError 网关响应校验失败的详细信息
+type SchemaError struct {
+	Method Method
+	Err    error // 校验器返回的错误
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("antchain: response of %s violates schema: %v", e.Method, e.Err)
+}
+
+func (e *SchemaError) Unwrap() error {
+	return e.Err
+}
+
+// Is 支持 errors.Is(err, ErrSchemaViolation)
+func (e *SchemaError) Is(target error) bool {
+	return target == ErrSchemaViolation
+}
+
+// ResponseValidator 校验网关响应的 data，返回的错误会被包装为 *SchemaError
+type ResponseValidator func(data string) error
+
+// RequireFields 要求响应包含指定的字段(gjson 路径)，如：RequireFields("gasUsed", "logs")
+func RequireFields(paths ...string) ResponseValidator {
+	return func(data string) error {
+		if !gjson.Valid(data) {
+			return fmt.Errorf("invalid json: %.256s", data)
+		}
+
+		for _, path := range paths {
+			if !gjson.Get(data, path).Exists() {
+				return fmt.Errorf("missing field %q", path)
+			}
+		}
+
+		return nil
+	}
+}
+
+// WithResponseValidator 为网关查询方法注册响应校验器，校验失败时请求返回 *SchemaError 且不返回数据，
+// 适用于不允许处理不完整数据的场景(如：合规流水)；校验通过的结果才会被缓存；
+// 交易提交(CHAIN_CALL_FOR_BIZ)的结果不做校验，避免交易已上链却丢失交易hash
+func WithResponseValidator(method Method, validators ...ResponseValidator) ClientOption {
+	return func(c *client) {
+		if c.validators == nil {
+			c.validators = make(map[Method][]ResponseValidator)
+		}
+
+		c.validators[method] = append(c.validators[method], validators...)
+	}
+}
+
+// validate 执行方法注册的响应校验器
+func (c *client) validate(method Method, data string) error {
+	for _, v := range c.validators[method] {
+		if err := v(data); err != nil {
+			return &SchemaError{
+				Method: method,
+				Err:    err,
+			}
+		}
+	}
+
+	return nil
+}
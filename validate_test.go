@@ -0,0 +1,31 @@
+package antchain
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestResponseValidator(t *testing.T) {
+	gw := newTestGateway(t, func(params X) (interface{}, bool) {
+		return `{"hash":"0xhash"}`, true
+	})
+
+	cli := newTestClient(t, gw,
+		WithResponseValidator(MethodQueryTransaction, RequireFields("blockNumber")),
+		WithResponseValidator(MethodDeposit, RequireFields("blockNumber")),
+	)
+
+	data, err := cli.QueryTransaction(context.Background(), "0xhash")
+
+	if !errors.Is(err, ErrSchemaViolation) || data != "" {
+		t.Fatalf("query: data = %q, err = %v", data, err)
+	}
+
+	// 交易提交的结果不做校验，不丢失交易hash
+	data, err = cli.Deposit(context.Background(), "hello", 100)
+
+	if err != nil || data == "" {
+		t.Fatalf("deposit: data = %q, err = %v", data, err)
+	}
+}
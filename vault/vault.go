@@ -0,0 +1,124 @@
+// Package vault 从 HashiCorp Vault 获取 AccessKey，私钥无需落盘
+package vault
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/shenghui0779/antchain"
+	"github.com/tidwall/gjson"
+)
+
+// Config Vault 配置
+type Config struct {
+	Address   string // Vault 地址，如：https://vault.example.com:8200
+	Token     string // Vault Token
+	Namespace string // Vault Enterprise 命名空间(可选)
+	Path      string // 密钥路径，如：secret/data/antchain(KV v2) 或 secret/antchain(KV v1)
+
+	AccessIDField  string // 存放 AccessID 的字段，默认 access_id
+	AccessKeyField string // 存放 AccessKey 私钥(PEM)的字段，默认 access_key
+
+	// RefreshInterval 重新获取密钥的间隔；密钥带有租约(lease_duration)时以较早者为准
+	RefreshInterval time.Duration
+
+	HTTPClient *http.Client
+}
+
+type provider struct {
+	cfg *Config
+	cli *http.Client
+}
+
+// NewProvider 返回从 Vault 获取凭证的 CredentialsProvider，凭证在租约到期或 RefreshInterval 后重新获取
+func NewProvider(cfg *Config) antchain.CredentialsProvider {
+	p := &provider{
+		cfg: cfg,
+		cli: cfg.HTTPClient,
+	}
+
+	if p.cli == nil {
+		p.cli = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return antchain.NewCachedProvider(p, cfg.RefreshInterval)
+}
+
+func (p *provider) Retrieve(ctx context.Context) (*antchain.Credentials, error) {
+	reqURL := strings.TrimRight(p.cfg.Address, "/") + "/v1/" + strings.TrimLeft(p.cfg.Path, "/")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("X-Vault-Token", p.cfg.Token)
+
+	if len(p.cfg.Namespace) != 0 {
+		req.Header.Set("X-Vault-Namespace", p.cfg.Namespace)
+	}
+
+	resp, err := p.cli.Do(req)
+
+	if err != nil {
+		return nil, fmt.Errorf("vault: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+
+	if err != nil {
+		return nil, fmt.Errorf("vault: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault: %s | %s", resp.Status, gjson.GetBytes(b, "errors").String())
+	}
+
+	ret := gjson.ParseBytes(b)
+
+	// KV v2 的数据位于 data.data
+	data := ret.Get("data")
+
+	if v := data.Get("data"); v.IsObject() && data.Get("metadata").Exists() {
+		data = v
+	}
+
+	accessID := data.Get(field(p.cfg.AccessIDField, "access_id")).String()
+	accessKey := data.Get(field(p.cfg.AccessKeyField, "access_key")).String()
+
+	if len(accessID) == 0 || len(accessKey) == 0 {
+		return nil, fmt.Errorf("%w: vault secret %s has no access id or key", antchain.ErrNoCredentials, p.cfg.Path)
+	}
+
+	pk, err := antchain.NewPrivateKeyFromPem([]byte(accessKey))
+
+	if err != nil {
+		return nil, err
+	}
+
+	creds := &antchain.Credentials{
+		AccessID: accessID,
+		Signer:   pk,
+	}
+
+	if lease := ret.Get("lease_duration").Int(); lease > 0 {
+		creds.Expiry = time.Now().Add(time.Duration(lease) * time.Second)
+	}
+
+	return creds, nil
+}
+
+func field(name, def string) string {
+	if len(name) == 0 {
+		return def
+	}
+
+	return name
+}
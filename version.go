@@ -0,0 +1,87 @@
+package antchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// WithRestAPIVersion 指定单次请求的 restApiVersion(优先于 Config.RestAPIVersion 及 Probe 协商的版本)
+func WithRestAPIVersion(version string) ChainCallOption {
+	return WithParam("restApiVersion", version)
+}
+
+// apiVersion 当前使用的 restApiVersion
+type apiVersion struct {
+	mutex sync.RWMutex
+	value string
+}
+
+func (v *apiVersion) get() string {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+
+	return v.value
+}
+
+func (v *apiVersion) set(version string) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	v.value = version
+}
+
+// applyVersion 请求未指定 restApiVersion 时使用客户端的版本
+func (c *client) applyVersion(params X) {
+	if _, ok := params["restApiVersion"]; ok {
+		return
+	}
+
+	if version := c.version.get(); len(version) != 0 {
+		params["restApiVersion"] = version
+	}
+}
+
+// Probe 按顺序探测网关支持的 restApiVersion，并将首个可用的版本用于后续请求；
+// 建议在服务启动时调用，避免因网关版本不一致在业务请求时才报错；
+// 未指定 versions 时探测 Config.RestAPIVersion，均未配置(空版本会被忽略)则不探测，直接返回空字符串
+func (c *client) Probe(ctx context.Context, versions ...string) (string, error) {
+	if len(versions) == 0 {
+		versions = []string{c.credential().cfg.RestAPIVersion}
+	}
+
+	candidates := make([]string, 0, len(versions))
+
+	for _, v := range versions {
+		if len(v) != 0 {
+			candidates = append(candidates, v)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return "", nil
+	}
+
+	for _, v := range candidates {
+		_, err := c.chainCall(ctx, MethodQueryLastBlock, WithRestAPIVersion(v))
+
+		if err == nil {
+			c.version.set(v)
+
+			return v, nil
+		}
+
+		if !IsUnsupportedVersion(err) {
+			return "", err
+		}
+	}
+
+	return "", fmt.Errorf("%w: tried [%s]", ErrUnsupportedVersion, strings.Join(candidates, ", "))
+}
+
+// IsUnsupportedVersion 判断是否为网关不支持 restApiVersion 的错误
+func IsUnsupportedVersion(err error) bool {
+	return errors.Is(err, ErrUnsupportedVersion)
+}
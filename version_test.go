@@ -0,0 +1,41 @@
+package antchain
+
+import (
+	"context"
+	"testing"
+)
+
+func TestProbeSkipsEmptyVersion(t *testing.T) {
+	gw := newTestGateway(t, func(params X) (interface{}, bool) { return "1", true })
+
+	cli := newTestClient(t, gw)
+	cli.version.set("v1")
+
+	v, err := cli.Probe(context.Background())
+
+	if err != nil || len(v) != 0 {
+		t.Fatalf("version = %q, err = %v", v, err)
+	}
+
+	if gw.last() != nil {
+		t.Fatalf("unexpected probe request: %v", gw.last())
+	}
+
+	if got := cli.version.get(); got != "v1" {
+		t.Fatalf("stored version = %q", got)
+	}
+}
+
+func TestProbeStoresVersion(t *testing.T) {
+	gw := newTestGateway(t, func(params X) (interface{}, bool) { return "1", true })
+
+	cli := newTestClient(t, gw)
+
+	if v, err := cli.Probe(context.Background(), "", "v2"); err != nil || v != "v2" {
+		t.Fatalf("version = %q, err = %v", v, err)
+	}
+
+	if got := gw.last()["restApiVersion"]; got != "v2" {
+		t.Fatalf("restApiVersion = %v", got)
+	}
+}
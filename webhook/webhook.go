@@ -0,0 +1,290 @@
+// Package webhook 将链上事件以 HTTP POST 推送到用户配置的 Webhook 地址，
+// 请求带 HMAC-SHA256 签名，失败按指数退避重试，仍失败则写入死信队列；
+// Pusher 实现了 sink.Publisher，可直接用于 sink.Relay
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/shenghui0779/antchain/sink"
+)
+
+const (
+	// HeaderTopic 消息的 Topic
+	HeaderTopic = "X-Antchain-Topic"
+	// HeaderKey 消息键，可用于接收方去重
+	HeaderKey = "X-Antchain-Key"
+	// HeaderTimestamp 推送时间(秒级时间戳)
+	HeaderTimestamp = "X-Antchain-Timestamp"
+	// HeaderSignature 签名：sha256=hex(HMAC-SHA256(secret, timestamp + "." + body))
+	HeaderSignature = "X-Antchain-Signature"
+)
+
+const (
+	defaultAttempts = 3
+	defaultBackoff  = time.Second
+	defaultTimeout  = 10 * time.Second
+)
+
+// Endpoint Webhook 地址
+type Endpoint struct {
+	URL    string
+	Secret string   // 签名密钥
+	Topics []string // 订阅的 Topic，为空则订阅全部
+}
+
+func (e *Endpoint) subscribed(topic string) bool {
+	if len(e.Topics) == 0 {
+		return true
+	}
+
+	for _, v := range e.Topics {
+		if v == topic {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DeadLetter 重试后仍推送失败的消息
+type DeadLetter struct {
+	URL      string        `json:"url"`
+	Message  *sink.Message `json:"message"`
+	Attempts int           `json:"attempts"`
+	Error    string        `json:"error"`
+	Time     time.Time     `json:"time"`
+}
+
+// DeadLetterQueue 死信队列
+type DeadLetterQueue interface {
+	Put(ctx context.Context, dl *DeadLetter) error
+}
+
+// FileDeadLetterQueue 以 JSON Lines 追加写入本地文件的死信队列
+type FileDeadLetterQueue struct {
+	mutex sync.Mutex
+	path  string
+}
+
+// NewFileDeadLetterQueue 返回写入本地文件的死信队列
+func NewFileDeadLetterQueue(path string) *FileDeadLetterQueue {
+	return &FileDeadLetterQueue{path: path}
+}
+
+// Put 追加写入死信
+func (q *FileDeadLetterQueue) Put(ctx context.Context, dl *DeadLetter) error {
+	b, err := json.Marshal(dl)
+
+	if err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	f, err := os.OpenFile(q.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(append(b, '\n'))
+
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+
+	return err
+}
+
+// Config 推送配置
+type Config struct {
+	Endpoints  []*Endpoint
+	Attempts   int             // 每个地址的最大推送次数，默认 3
+	Backoff    time.Duration   // 首次重试前的等待时长(按指数增长)，默认 1 秒
+	Timeout    time.Duration   // 单次请求超时，默认 10 秒
+	HTTPClient *http.Client    // 默认 http.DefaultClient
+	DLQ        DeadLetterQueue // 死信队列，为空则推送失败时 Publish 返回错误(sink.Relay 将从检查点重试)
+}
+
+// Pusher Webhook 推送
+type Pusher struct {
+	cfg *Config
+	cli *http.Client
+	now func() time.Time
+}
+
+// NewPusher 返回 Webhook 推送
+func NewPusher(cfg *Config) *Pusher {
+	cli := cfg.HTTPClient
+
+	if cli == nil {
+		cli = http.DefaultClient
+	}
+
+	return &Pusher{
+		cfg: cfg,
+		cli: cli,
+		now: time.Now,
+	}
+}
+
+// Publish 将消息推送到订阅了对应 Topic 的全部地址
+func (p *Pusher) Publish(ctx context.Context, msgs []*sink.Message) error {
+	for _, m := range msgs {
+		for _, e := range p.cfg.Endpoints {
+			if !e.subscribed(m.Topic) {
+				continue
+			}
+
+			if err := p.deliver(ctx, e, m); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// deliver 推送消息，失败按配置重试，仍失败则写入死信队列
+func (p *Pusher) deliver(ctx context.Context, e *Endpoint, m *sink.Message) error {
+	attempts := p.cfg.Attempts
+
+	if attempts <= 0 {
+		attempts = defaultAttempts
+	}
+
+	backoff := p.cfg.Backoff
+
+	if backoff <= 0 {
+		backoff = defaultBackoff
+	}
+
+	var err error
+
+	for n := 0; n < attempts; n++ {
+		if n > 0 {
+			timer := time.NewTimer(backoff << uint(n-1))
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		var retry bool
+
+		retry, err = p.post(ctx, e, m)
+
+		if err == nil {
+			return nil
+		}
+
+		if !retry {
+			break
+		}
+	}
+
+	if p.cfg.DLQ == nil {
+		return fmt.Errorf("webhook: push %s to %s: %w", m.Key, e.URL, err)
+	}
+
+	return p.cfg.DLQ.Put(ctx, &DeadLetter{
+		URL:      e.URL,
+		Message:  m,
+		Attempts: attempts,
+		Error:    err.Error(),
+		Time:     p.now(),
+	})
+}
+
+// post 发送一次请求，返回失败时是否可以重试
+func (p *Pusher) post(ctx context.Context, e *Endpoint, m *sink.Message) (bool, error) {
+	timeout := p.cfg.Timeout
+
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.URL, bytes.NewReader(m.Value))
+
+	if err != nil {
+		return false, err
+	}
+
+	timestamp := strconv.FormatInt(p.now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set(HeaderTopic, m.Topic)
+	req.Header.Set(HeaderKey, m.Key)
+	req.Header.Set(HeaderTimestamp, timestamp)
+
+	if len(e.Secret) != 0 {
+		req.Header.Set(HeaderSignature, Sign(e.Secret, timestamp, m.Value))
+	}
+
+	resp, err := p.cli.Do(req)
+
+	if err != nil {
+		return true, err
+	}
+
+	defer resp.Body.Close()
+
+	// 读完响应以复用连接
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusRequestTimeout
+
+	return retry, fmt.Errorf("webhook: unexpected status %d", resp.StatusCode)
+}
+
+// Sign 计算推送签名
+func Sign(secret, timestamp string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(timestamp + "."))
+	h.Write(body)
+
+	return "sha256=" + hex.EncodeToString(h.Sum(nil))
+}
+
+// Verify 供接收方校验推送签名，maxAge 为允许的最大时间差(防重放)，为 0 则不校验时间
+func Verify(secret, timestamp, signature string, body []byte, maxAge time.Duration) bool {
+	if maxAge > 0 {
+		sec, err := strconv.ParseInt(timestamp, 10, 64)
+
+		if err != nil {
+			return false
+		}
+
+		if d := time.Since(time.Unix(sec, 0)); d > maxAge || d < -maxAge {
+			return false
+		}
+	}
+
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
@@ -0,0 +1,32 @@
+// Package zaplog 将 zap 日志接入 antchain.Logger
+package zaplog
+
+import (
+	"context"
+
+	"github.com/shenghui0779/antchain"
+	"go.uber.org/zap"
+)
+
+type logger struct {
+	l *zap.SugaredLogger
+}
+
+// New 返回基于 zap 的 antchain.Logger，如：antchain.WithHook(antchain.NewLogHook(zaplog.New(l)))
+func New(l *zap.Logger) antchain.Logger {
+	return &logger{l: l.WithOptions(zap.AddCallerSkip(1)).Sugar()}
+}
+
+// Log 按级别输出日志
+func (z *logger) Log(ctx context.Context, level antchain.LogLevel, msg string, keyvals ...interface{}) {
+	switch level {
+	case antchain.LevelDebug:
+		z.l.Debugw(msg, keyvals...)
+	case antchain.LevelWarn:
+		z.l.Warnw(msg, keyvals...)
+	case antchain.LevelError:
+		z.l.Errorw(msg, keyvals...)
+	default:
+		z.l.Infow(msg, keyvals...)
+	}
+}